@@ -1,35 +1,78 @@
 package main
 
 import (
+	"context"
 	"database/sql"
-	"fmt"
-	"log"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
 
+	_ "github.com/jackc/pgx/v5/stdlib"
 	_ "github.com/lib/pq"
 	"github.com/nilesh0729/Transactly/internal/api"
-	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/logging"
+	"github.com/nilesh0729/Transactly/internal/tracing"
 	"github.com/nilesh0729/Transactly/internal/util"
 )
 
 func main() {
 	config, err := util.LoadConfig(".")
 	if err != nil {
-		log.Fatal("Cannot Load Config", err)
+		fatal("Cannot Load Config", err)
 	}
 
-	conn, err := sql.Open(config.DBDriver, config.DBSource)
+	logger, err := logging.New(os.Stdout, config.LogFormat, config.LogLevel)
 	if err != nil {
-		log.Fatal("Cannot Connect To db: ", err)
+		fatal("Cannot Set Up Logging", err)
+	}
+	slog.SetDefault(logger)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), config.TracingExporter, config.OTLPEndpoint)
+	if err != nil {
+		fatal("Cannot Set Up Tracing", err)
+	}
+	defer shutdownTracing(context.Background())
+
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		conn, err := sql.Open(config.DBDriver, config.DBSource)
+		if err != nil {
+			fatal("Cannot Connect To db", err)
+		}
+		err = runMigrate(context.Background(), conn, os.Args[2:])
+		conn.Close() // fatal exits without running deferred calls
+		if err != nil {
+			fatal("Cannot Migrate", err)
+		}
+		return
+	}
+
+	store, closeStore, err := openStore(context.Background(), config)
+	if err != nil {
+		fatal("Cannot Open Store", err)
 	}
 
-	store := Anuskh.NewTxConn(conn)
 	server, err := api.NewServer(store, config)
 	if err != nil {
-		fmt.Printf("cannot create Server: %v", err)
+		fatal("Cannot Create Server", err)
 	}
 
-	err = server.Start(config.ServerAddress)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	slog.Info("starting server", "address", config.ServerAddress)
+	err = server.Start(ctx, config.ServerAddress)
 	if err != nil {
-		log.Fatal("Cannot Start Server : ", err)
+		fatal("Cannot Start Server", err)
+	}
+
+	if err := closeStore(); err != nil {
+		slog.Error("cannot close database", "error", err)
 	}
+	slog.Info("server stopped")
+}
+
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
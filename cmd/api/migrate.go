@@ -0,0 +1,51 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/nilesh0729/Transactly/internal/db/migration"
+)
+
+const migrateUsage = "usage: main migrate up | down [N] | version"
+
+// runMigrate handles the "migrate" subcommand. down rolls back one migration
+// unless told otherwise, so a stray command can't wipe the schema.
+func runMigrate(ctx context.Context, db *sql.DB, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		if err := migration.Up(ctx, db); err != nil {
+			return err
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			var err error
+			steps, err = strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid number of steps %q: %s", args[1], migrateUsage)
+			}
+		}
+		if err := migration.Down(ctx, db, steps); err != nil {
+			return err
+		}
+	case "version":
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	version, dirty, err := migration.Version(ctx, db)
+	if err != nil {
+		return err
+	}
+	slog.Info("schema version", "version", version, "dirty", dirty)
+	return nil
+}
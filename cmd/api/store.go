@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/stdlib"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/db/migration"
+	"github.com/nilesh0729/Transactly/internal/metrics"
+	"github.com/nilesh0729/Transactly/internal/util"
+)
+
+const (
+	memoryDriver = "memory"
+	pgxDriver    = "pgx"
+)
+
+// openStore builds the Store selected by DB_DRIVER: postgres (lib/pq), pgx
+// (pgxpool) or memory. The returned close func releases the database once the
+// server has drained.
+func openStore(ctx context.Context, config util.Config) (Anuskh.Store, func() error, error) {
+	if config.DBDriver == memoryDriver {
+		slog.Warn("using the in-memory store, data is lost on exit")
+		return Anuskh.NewMemoryStore(), func() error { return nil }, nil
+	}
+
+	isolation, err := Anuskh.ParseIsolationLevel(config.TransferIsolation)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid DB_TRANSFER_ISOLATION: %w", err)
+	}
+	retryPolicy := Anuskh.DefaultRetryPolicy
+	retryPolicy.MaxAttempts = config.TxMaxAttempts
+	opts := []Anuskh.StoreOption{
+		Anuskh.WithTransferIsolation(isolation),
+		Anuskh.WithRetryPolicy(retryPolicy),
+	}
+
+	poolConfig := Anuskh.PoolConfig{
+		MaxConns:               config.DBMaxConns,
+		MinConns:               config.DBMinConns,
+		MaxConnLifetime:        config.DBMaxConnLifetime,
+		MaxConnIdleTime:        config.DBMaxConnIdleTime,
+		HealthCheckPeriod:      config.DBHealthCheckPeriod,
+		StatementCacheCapacity: config.DBStatementCache,
+	}
+
+	var (
+		store     Anuskh.Store
+		conn      *sql.DB
+		closeConn func() error
+	)
+	if config.DBDriver == pgxDriver {
+		pool, err := Anuskh.NewPgxPool(ctx, config.DBSource, poolConfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot create pgx pool: %w", err)
+		}
+		pgxStore := Anuskh.NewPgxStore(pool, opts...)
+		store, conn, closeConn = pgxStore, pgxStore.DB(), pgxStore.Close
+	} else {
+		conn, err = sql.Open(config.DBDriver, config.DBSource)
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot connect to db: %w", err)
+		}
+		Anuskh.ConfigureSQLPool(conn, poolConfig)
+		store, closeConn = Anuskh.NewTxConn(conn, opts...), conn.Close
+	}
+
+	if err := prepareDB(ctx, config, conn); err != nil {
+		closeConn()
+		return nil, nil, err
+	}
+
+	if len(config.DBReplicaSources) == 0 {
+		return store, closeConn, nil
+	}
+	if config.DBReplicaCheck <= 0 {
+		// replicas only take reads once their lag has been measured
+		closeConn()
+		return nil, nil, fmt.Errorf("DB_REPLICA_CHECK_PERIOD must be positive, got %s", config.DBReplicaCheck)
+	}
+	replicas, closeReplicas, err := openReplicas(ctx, config, poolConfig)
+	if err != nil {
+		closeConn()
+		return nil, nil, err
+	}
+	replicaStore := Anuskh.NewReplicaStore(store, replicas, Anuskh.ReplicaConfig{
+		MaxLag:      config.DBReplicaMaxLag,
+		CheckPeriod: config.DBReplicaCheck,
+	})
+	monitorCtx, stopMonitor := context.WithCancel(context.WithoutCancel(ctx))
+	monitorDone := make(chan struct{})
+	go func() {
+		defer close(monitorDone)
+		replicaStore.MonitorLag(monitorCtx)
+	}()
+
+	return replicaStore, func() error {
+		// the monitor queries the replicas, so it stops before they close
+		stopMonitor()
+		<-monitorDone
+		return errors.Join(closeReplicas(), closeConn())
+	}, nil
+}
+
+// openReplicas connects to every DB_REPLICA_SOURCES entry with the primary's
+// driver and pool settings.
+func openReplicas(ctx context.Context, config util.Config, poolConfig Anuskh.PoolConfig) ([]Anuskh.Replica, func() error, error) {
+	var (
+		replicas []Anuskh.Replica
+		closers  []func() error
+	)
+	closeAll := func() error {
+		var errs []error
+		for _, closeFn := range closers {
+			errs = append(errs, closeFn())
+		}
+		return errors.Join(errs...)
+	}
+
+	for i, source := range config.DBReplicaSources {
+		var conn *sql.DB
+		if config.DBDriver == pgxDriver {
+			pool, err := Anuskh.NewPgxPool(ctx, source, poolConfig)
+			if err != nil {
+				closeAll()
+				return nil, nil, fmt.Errorf("cannot create pgx pool for replica %d: %w", i, err)
+			}
+			conn = stdlib.OpenDBFromPool(pool)
+			closers = append(closers, func() error {
+				err := conn.Close()
+				pool.Close()
+				return err
+			})
+		} else {
+			var err error
+			conn, err = sql.Open(config.DBDriver, source)
+			if err != nil {
+				closeAll()
+				return nil, nil, fmt.Errorf("cannot connect to replica %d: %w", i, err)
+			}
+			Anuskh.ConfigureSQLPool(conn, poolConfig)
+			closers = append(closers, conn.Close)
+		}
+		replicas = append(replicas, Anuskh.NewSQLReplica(conn))
+	}
+	return replicas, closeAll, nil
+}
+
+// prepareDB applies or checks migrations and exports pool metrics.
+func prepareDB(ctx context.Context, config util.Config, conn *sql.DB) error {
+	if config.AutoMigrate {
+		slog.Info("applying migrations")
+		if err := migration.Up(ctx, conn); err != nil {
+			return fmt.Errorf("cannot apply migrations: %w", err)
+		}
+	}
+
+	version, dirty, err := migration.Version(ctx, conn)
+	if err != nil {
+		return fmt.Errorf("cannot read schema version: %w", err)
+	}
+	if err := migration.CheckVersion(version, dirty); err != nil {
+		return fmt.Errorf("refusing to start, run migrations first: %w", err)
+	}
+
+	if err := metrics.RegisterDBStats(conn); err != nil {
+		return fmt.Errorf("cannot register db metrics: %w", err)
+	}
+	return nil
+}
@@ -7,6 +7,9 @@ const Dashboard = () => {
     const [loading, setLoading] = useState(true);
     const [showCreateModal, setShowCreateModal] = useState(false);
     const [currency, setCurrency] = useState('USD');
+    const [nickname, setNickname] = useState('');
+    const [accountType, setAccountType] = useState('checking');
+    const [currencies, setCurrencies] = useState(['USD']);
 
     const fetchAccounts = async () => {
         try {
@@ -19,14 +22,25 @@ const Dashboard = () => {
         }
     };
 
+    const fetchCurrencies = async () => {
+        try {
+            const response = await api.get('/currencies');
+            setCurrencies((response.data || []).map(c => c.code));
+        } catch (error) {
+            console.error("Failed to fetch currencies", error);
+        }
+    };
+
     useEffect(() => {
         fetchAccounts();
+        fetchCurrencies();
     }, []);
 
     const handleCreateAccount = async () => {
         try {
-            await api.post('/accounts', { currency });
+            await api.post('/accounts', { currency, nickname, account_type: accountType });
             setShowCreateModal(false);
+            setNickname('');
             fetchAccounts();
         } catch (error) {
             alert("Failed to create account: " + (error.response?.data?.error || error.message));
@@ -50,13 +64,14 @@ const Dashboard = () => {
                         <div key={account.id} className="card account-card">
                             <div className="account-header">
                                 <span className="currency-badge">{account.currency}</span>
-                                <span className="account-id">#{account.id}</span>
+                                <span className="account-id">{account.nickname || `#${account.id}`} · {account.account_type}</span>
                             </div>
                             <div className="account-balance">
-                                <h3>{new Intl.NumberFormat('en-US', { style: 'currency', currency: account.currency }).format(account.balance)}</h3>
+                                <h3>{new Intl.NumberFormat('en-US', { style: 'currency', currency: account.currency }).format(account.balance_money.amount)}</h3>
                                 <p>Available Balance</p>
                             </div>
                             <div className="account-footer">
+                                <small>{account.account_number?.replace(/(.{4})/g, '$1 ').trim()}</small>
                                 <small>Created: {new Date(account.created_at).toLocaleDateString()}</small>
                                 <button className="btn-link" onClick={() => window.location.href = `/transactions/${account.id}`}>View History</button>
                             </div>
@@ -72,14 +87,20 @@ const Dashboard = () => {
                         <div className="form-group">
                             <label>Currency</label>
                             <select value={currency} onChange={(e) => setCurrency(e.target.value)}>
-                                <option value="USD">USD</option>
-                                <option value="EUR">EUR</option>
-                                <option value="CAD">CAD</option>
-                                <option value="INR">INR</option>
-                                <option value="YEN">YEN</option>
-                                <option value="BDT">BDT</option>
-                                <option value="BRL">BRL</option>
-                                <option value="FJD">FJD</option>
+                                {currencies.map(code => (
+                                    <option key={code} value={code}>{code}</option>
+                                ))}
+                            </select>
+                        </div>
+                        <div className="form-group">
+                            <label>Nickname</label>
+                            <input value={nickname} maxLength={50} placeholder="e.g. Bills" onChange={(e) => setNickname(e.target.value)} />
+                        </div>
+                        <div className="form-group">
+                            <label>Type</label>
+                            <select value={accountType} onChange={(e) => setAccountType(e.target.value)}>
+                                <option value="checking">Checking</option>
+                                <option value="savings">Savings</option>
                             </select>
                         </div>
                         <div className="modal-actions">
@@ -5,9 +5,12 @@ import './Transfer.css';
 
 const Transfer = () => {
     const [accounts, setAccounts] = useState([]);
+    const [payees, setPayees] = useState([]);
+    const [currencies, setCurrencies] = useState(['USD']);
     const [formData, setFormData] = useState({
         from_account_id: '',
-        to_account_id: '',
+        payee_id: '',
+        to_account_number: '',
         amount: '',
         currency: 'USD'
     });
@@ -25,7 +28,25 @@ const Transfer = () => {
                 console.error("Failed to load accounts", err);
             }
         };
+        const fetchPayees = async () => {
+            try {
+                const response = await api.get('/payees?page_id=1&page_size=100');
+                setPayees(response.data || []);
+            } catch (err) {
+                console.error("Failed to load payees", err);
+            }
+        };
+        const fetchCurrencies = async () => {
+            try {
+                const response = await api.get('/currencies');
+                setCurrencies((response.data || []).map(c => c.code));
+            } catch (err) {
+                console.error("Failed to load currencies", err);
+            }
+        };
         fetchAccounts();
+        fetchPayees();
+        fetchCurrencies();
     }, []);
 
     const handleChange = (e) => {
@@ -42,7 +63,9 @@ const Transfer = () => {
             // Need to convert IDs to numbers and amount to number
             const payload = {
                 from_account_id: parseInt(formData.from_account_id),
-                to_account_id: parseInt(formData.to_account_id),
+                ...(formData.payee_id
+                    ? { payee_id: parseInt(formData.payee_id) }
+                    : { to_account_number: formData.to_account_number }),
                 amount: parseInt(formData.amount), // Backend expects integer amount (e.g. cents) or just amount?
                 // Wait, the API spec says "amount" int64. Let's assume it's standard unit for now or user inputs whole numbers.
                 // Assuming the backend handles currency. But the Request body needs Currency field provided! 
@@ -50,8 +73,10 @@ const Transfer = () => {
                 currency: formData.currency
             };
 
-            await api.post('/transfers', payload);
-            setSuccess('Transfer successful!');
+            const res = await api.post('/transfers', payload);
+            setSuccess(res.status === 202
+                ? 'Transfer is on hold for review. It will be sent once approved.'
+                : 'Transfer successful!');
             setTimeout(() => navigate('/dashboard'), 2000);
         } catch (err) {
             setError(err.response?.data?.error || "Transfer failed");
@@ -80,23 +105,40 @@ const Transfer = () => {
                             <option value="">Select Account</option>
                             {accounts.map(acc => (
                                 <option key={acc.id} value={acc.id}>
-                                    ID: {acc.id} ({acc.currency} {acc.balance})
+                                    ID: {acc.id} ({acc.currency} {acc.balance_money.amount})
                                 </option>
                             ))}
                         </select>
                     </div>
 
                     <div className="form-group">
-                        <label>To Account ID</label>
+                        <label>To Payee</label>
+                        <select
+                            name="payee_id"
+                            value={formData.payee_id}
+                            onChange={handleChange}
+                        >
+                            <option value="">Enter an account number</option>
+                            {payees.map(payee => (
+                                <option key={payee.id} value={payee.id}>
+                                    {payee.nickname} ({payee.currency})
+                                    {new Date(payee.cooling_off_ends_at) > new Date() ? ' - new payee, reduced limit' : ''}
+                                </option>
+                            ))}
+                        </select>
+                    </div>
+
+                    {!formData.payee_id && <div className="form-group">
+                        <label>To Account Number</label>
                         <input
-                            type="number"
-                            name="to_account_id"
-                            value={formData.to_account_id}
+                            type="text"
+                            name="to_account_number"
+                            value={formData.to_account_number}
                             onChange={handleChange}
-                            placeholder="Recipient Account ID"
+                            placeholder="TX00 0000 0000 0000 0000"
                             required
                         />
-                    </div>
+                    </div>}
 
                     <div className="form-row">
                         <div className="form-group">
@@ -118,14 +160,9 @@ const Transfer = () => {
                                 value={formData.currency}
                                 onChange={handleChange}
                             >
-                                <option value="USD">USD</option>
-                                <option value="EUR">EUR</option>
-                                <option value="CAD">CAD</option>
-                                <option value="INR">INR</option>
-                                <option value="YEN">YEN</option>
-                                <option value="BDT">BDT</option>
-                                <option value="BRL">BRL</option>
-                                <option value="FJD">FJD</option>
+                                {currencies.map(code => (
+                                    <option key={code} value={code}>{code}</option>
+                                ))}
                             </select>
                         </div>
                     </div>
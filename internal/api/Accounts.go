@@ -6,14 +6,26 @@ import (
 	"net/http"
 
 	"github.com/nilesh0729/Transactly/internal/token"
+	"github.com/nilesh0729/Transactly/internal/util"
 
 	"github.com/gin-gonic/gin"
-	"github.com/lib/pq"
 	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
 )
 
+// AccountResponse adds the balance in the account's currency to an account.
+type AccountResponse struct {
+	Anuskh.Account
+	BalanceMoney util.Money `json:"balance_money"`
+}
+
+func newAccountResponse(account Anuskh.Account) AccountResponse {
+	return AccountResponse{Account: account, BalanceMoney: util.NewMoney(account.Balance, account.Currency)}
+}
+
 type CreateAccountRequest struct {
-	Currency string `json:"currency" binding:"required,currency"`
+	Currency    string `json:"currency" binding:"required,currency"`
+	Nickname    string `json:"nickname" binding:"max=50"`
+	AccountType string `json:"account_type" binding:"omitempty,account_type"` // checking when empty
 }
 
 func (server *Server) CreateAccount(ctx *gin.Context) {
@@ -25,26 +37,39 @@ func (server *Server) CreateAccount(ctx *gin.Context) {
 		return
 	}
 
+	if req.AccountType == "" {
+		req.AccountType = Anuskh.AccountChecking
+	}
+
 	authPaload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	arg := Anuskh.CreateAccountsParams{
-		Owner:    authPaload.Username,
-		Currency: req.Currency,
-		Balance:  0,
+	arg := Anuskh.CreateAccountTxParams{
+		CreateAccountsParams: Anuskh.CreateAccountsParams{
+			Owner:       authPaload.Username,
+			Currency:    req.Currency,
+			Balance:     0,
+			Nickname:    req.Nickname,
+			AccountType: req.AccountType,
+		},
+		MaxAccounts:           server.config.MaxAccountsPerUser,
+		MaxUnverifiedAccounts: server.config.UnverifiedMaxAccounts,
 	}
 
-	account, err := server.store.CreateAccounts(ctx, arg)
+	account, err := server.store.CreateAccountTx(ctx, arg)
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code.Name() {
-			case "foreign_key_violation", "unique_violation":
-				ctx.JSON(http.StatusForbidden, errorResponse(err))
-				return
-			}
+		if errors.Is(err, Anuskh.ErrAccountLimitReached) || err == sql.ErrNoRows {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		switch Anuskh.ErrorCode(err) {
+		case Anuskh.ForeignKeyViolation, Anuskh.UniqueViolation:
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
 		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, account)
+	setAuditSnapshot(ctx, nil, account)
+	ctx.JSON(http.StatusOK, newAccountResponse(account))
 }
 
 type GetAccountRequest struct {
@@ -77,12 +102,14 @@ func (server *Server) GetAccount(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, account)
+	ctx.JSON(http.StatusOK, newAccountResponse(account))
 }
 
 type ListAccountRequest struct {
-	PageID   int32 `form:"page_id" binding:"required,min=1"`
-	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+	PageID      int32  `form:"page_id" binding:"required,min=1"`
+	PageSize    int32  `form:"page_size" binding:"required,min=5,max=100"`
+	AccountType string `form:"account_type" binding:"omitempty,account_type"`
+	SortBy      string `form:"sort_by" binding:"omitempty,oneof=id nickname balance created_at"`
 }
 
 func (server *Server) ListAccount(ctx *gin.Context) {
@@ -96,9 +123,11 @@ func (server *Server) ListAccount(ctx *gin.Context) {
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
 	arg := Anuskh.ListAccountsParams{
-		Owner:  authPayload.Username,
-		Limit:  (req.PageSize),
-		Offset: (req.PageID - 1) * req.PageSize,
+		Owner:       authPayload.Username,
+		Limit:       (req.PageSize),
+		Offset:      (req.PageID - 1) * req.PageSize,
+		AccountType: sql.NullString{String: req.AccountType, Valid: req.AccountType != ""},
+		SortBy:      req.SortBy,
 	}
 
 	account, err := server.store.ListAccounts(ctx, arg)
@@ -108,5 +137,9 @@ func (server *Server) ListAccount(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, account)
+	resp := make([]AccountResponse, len(account))
+	for i := range account {
+		resp[i] = newAccountResponse(account[i])
+	}
+	ctx.JSON(http.StatusOK, resp)
 }
@@ -138,12 +138,19 @@ func TestPostAccountAPI(t *testing.T) {
 	_, user := RandomUser(t)
 	account1 := randomAccount(user.Username)
 
-	arg := Anuskh.CreateAccountsParams{
-		Owner:    account1.Owner,
-		Currency: account1.Currency,
-		Balance:  0,
+	arg := Anuskh.CreateAccountTxParams{
+		CreateAccountsParams: Anuskh.CreateAccountsParams{
+			Owner:       account1.Owner,
+			Currency:    account1.Currency,
+			Balance:     0,
+			AccountType: Anuskh.AccountChecking,
+		},
 	}
 
+	savings := randomAccount(user.Username)
+	savings.AccountType = Anuskh.AccountSavings
+	savings.Nickname = "rainy day"
+
 	testcases := []struct {
 		name          string
 		body          gin.H
@@ -163,7 +170,7 @@ func TestPostAccountAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockDB.MockStore) {
 				store.EXPECT().
-					CreateAccounts(gomock.Any(), gomock.Eq(arg)).
+					CreateAccountTx(gomock.Any(), gomock.Eq(arg)).
 					Times(1).
 					Return(account1, nil)
 			},
@@ -173,6 +180,74 @@ func TestPostAccountAPI(t *testing.T) {
 			},
 		},
 
+		{
+			name: "SavingsWithNickname",
+			body: gin.H{
+				"currency":     savings.Currency,
+				"nickname":     savings.Nickname,
+				"account_type": Anuskh.AccountSavings,
+			},
+			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				arg := Anuskh.CreateAccountTxParams{
+					CreateAccountsParams: Anuskh.CreateAccountsParams{
+						Owner:       user.Username,
+						Currency:    savings.Currency,
+						Nickname:    savings.Nickname,
+						AccountType: Anuskh.AccountSavings,
+					},
+				}
+				store.EXPECT().
+					CreateAccountTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(savings, nil)
+			},
+			CheckResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchingAccount(t, recorder.Body, savings)
+			},
+		},
+
+		{
+			name: "InvalidAccountType",
+			body: gin.H{
+				"currency":     account1.Currency,
+				"account_type": "brokerage",
+			},
+			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					CreateAccountTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			CheckResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+
+		{
+			name: "AccountLimitReached",
+			body: gin.H{
+				"currency": account1.Currency,
+			},
+			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					CreateAccountTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(Anuskh.Account{}, fmt.Errorf("%w: %s already holds 10 accounts", Anuskh.ErrAccountLimitReached, user.Username))
+			},
+			CheckResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+
 		{
 			name: "BadRequest",
 			body: gin.H{
@@ -185,7 +260,7 @@ func TestPostAccountAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockDB.MockStore) {
 				store.EXPECT().
-					CreateAccounts(gomock.Any(), gomock.Any()).
+					CreateAccountTx(gomock.Any(), gomock.Any()).
 					Times(0)
 			},
 			CheckResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -206,7 +281,7 @@ func TestPostAccountAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockDB.MockStore) {
 				store.EXPECT().
-					CreateAccounts(gomock.Any(), gomock.Any()).
+					CreateAccountTx(gomock.Any(), gomock.Any()).
 					Times(1).
 					Return(Anuskh.Account{}, sql.ErrConnDone)
 			},
@@ -258,8 +333,10 @@ func TestListAccountAPI(t *testing.T) {
 	}
 
 	type Query struct {
-		page_size int
-		page_id   int
+		page_size    int
+		page_id      int
+		account_type string
+		sort_by      string
 	}
 
 	testcases := []struct {
@@ -298,6 +375,59 @@ func TestListAccountAPI(t *testing.T) {
 			},
 		},
 
+		{
+			name: "FilterAndSort",
+			query: Query{
+				page_size:    n,
+				page_id:      1,
+				account_type: Anuskh.AccountSavings,
+				sort_by:      "balance",
+			},
+
+			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+
+			buildStubs: func(store *mockDB.MockStore) {
+				arg := Anuskh.ListAccountsParams{
+					Owner:       user.Username,
+					Limit:       int32(n),
+					Offset:      0,
+					AccountType: sql.NullString{String: Anuskh.AccountSavings, Valid: true},
+					SortBy:      "balance",
+				}
+				store.EXPECT().
+					ListAccounts(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(accounts, nil)
+			},
+			CheckResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchingAccounts(t, recorder.Body, accounts)
+			},
+		},
+
+		{
+			name: "InvalidSortBy",
+			query: Query{
+				page_size: n,
+				page_id:   1,
+				sort_by:   "owner",
+			},
+
+			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					ListAccounts(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			CheckResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+
 		{
 			name: "BadRequest",
 			query: Query{
@@ -368,6 +498,12 @@ func TestListAccountAPI(t *testing.T) {
 			q := request.URL.Query()
 			q.Add("page_id", fmt.Sprintf("%d", tc.query.page_id))
 			q.Add("page_size", fmt.Sprintf("%d", tc.query.page_size))
+			if tc.query.account_type != "" {
+				q.Add("account_type", tc.query.account_type)
+			}
+			if tc.query.sort_by != "" {
+				q.Add("sort_by", tc.query.sort_by)
+			}
 			request.URL.RawQuery = q.Encode()
 
 			tc.setAuth(t, request, Server.tokenMaker)
@@ -384,11 +520,14 @@ func requireBodyMatchingAccounts(t *testing.T, body *bytes.Buffer, expected []An
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
 
-	var got []Anuskh.Account
+	var got []AccountResponse
 	err = json.Unmarshal(data, &got)
 	require.NoError(t, err)
 
-	require.Equal(t, expected, got)
+	require.Len(t, got, len(expected))
+	for i := range expected {
+		require.Equal(t, newAccountResponse(expected[i]), got[i])
+	}
 }
 
 func requireBodyMatchingAccount(t *testing.T, body *bytes.Buffer, account Anuskh.Account) {
@@ -396,19 +535,22 @@ func requireBodyMatchingAccount(t *testing.T, body *bytes.Buffer, account Anuskh
 	data, err := io.ReadAll(body)
 	require.NoError(t, err)
 
-	var GotAccount Anuskh.Account
+	var GotAccount AccountResponse
 
 	err = json.Unmarshal(data, &GotAccount)
 	require.NoError(t, err)
 
-	require.Equal(t, account, GotAccount)
+	require.Equal(t, newAccountResponse(account), GotAccount)
+	require.Contains(t, string(data), fmt.Sprintf(`"balance_money":{"amount":%q`, GotAccount.BalanceMoney.Decimal()))
 }
 
 func randomAccount(owner string) Anuskh.Account {
 	return Anuskh.Account{
-		ID:       util.RandomInt(1, 100),
-		Balance:  20000,
-		Owner:    owner,
-		Currency: util.RandomCurrency(),
+		ID:          util.RandomInt(1, 100),
+		Balance:     20000,
+		Owner:       owner,
+		Currency:    util.RandomCurrency(),
+		Status:      Anuskh.AccountActive,
+		AccountType: Anuskh.AccountChecking,
 	}
 }
@@ -0,0 +1,103 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/token"
+)
+
+type AccountStatusUri struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type CloseAccountRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// CloseAccount lets the owner close an empty account.
+func (server *Server) CloseAccount(ctx *gin.Context) {
+	var req CloseAccountRequest
+	// the body is optional
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	server.changeAccountStatus(ctx, Anuskh.AccountActive, Anuskh.AccountClosed, req.Reason, true)
+}
+
+// ReopenAccount lets the owner reopen an account they closed.
+func (server *Server) ReopenAccount(ctx *gin.Context) {
+	server.changeAccountStatus(ctx, Anuskh.AccountClosed, Anuskh.AccountActive, "", true)
+}
+
+type AdminAccountStatusRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+func (server *Server) FreezeAccount(ctx *gin.Context) {
+	var req AdminAccountStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	server.changeAccountStatus(ctx, Anuskh.AccountActive, Anuskh.AccountFrozen, req.Reason, false)
+}
+
+func (server *Server) UnfreezeAccount(ctx *gin.Context) {
+	var req AdminAccountStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	server.changeAccountStatus(ctx, Anuskh.AccountFrozen, Anuskh.AccountActive, req.Reason, false)
+}
+
+// changeAccountStatus moves the account in the URI from one status to another.
+// Owner routes only act on the caller's own accounts; admin routes act on any.
+func (server *Server) changeAccountStatus(ctx *gin.Context, from string, to string, reason string, ownerOnly bool) {
+	var uri AccountStatusUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	before, err := server.store.GetAccounts(ctx, uri.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if ownerOnly && before.Owner != authPayload.Username {
+		err := errors.New("Account Does not belong to the Authenticated user")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return
+	}
+
+	account, err := server.store.ChangeAccountStatus(ctx, Anuskh.ChangeAccountStatusParams{
+		AccountID: uri.ID,
+		From:      from,
+		To:        to,
+		Reason:    reason,
+	})
+	if err != nil {
+		if errors.Is(err, Anuskh.ErrInvalidStatusTransition) || errors.Is(err, Anuskh.ErrAccountNotEmpty) {
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	setAuditSnapshot(ctx, before, account)
+	ctx.JSON(http.StatusOK, newAccountResponse(account))
+}
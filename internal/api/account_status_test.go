@@ -0,0 +1,216 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockDB "github.com/nilesh0729/Transactly/internal/db/Mock"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountStatusAPI(t *testing.T) {
+	_, owner := RandomUser(t)
+	_, other := RandomUser(t)
+	_, admin := RandomUser(t)
+	admin.Role = util.AdminRole
+
+	account := randomAccount(owner.Username)
+	account.Balance = 0
+	closed := account
+	closed.Status = Anuskh.AccountClosed
+	frozen := account
+	frozen.Status = Anuskh.AccountFrozen
+	frozen.StatusReason = "chargeback"
+
+	testCases := []struct {
+		name          string
+		path          string
+		body          any
+		username      string
+		buildStubs    func(store *mockDB.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Close",
+			path:     fmt.Sprintf("/accounts/%d/close", account.ID),
+			username: owner.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					ChangeAccountStatus(gomock.Any(), gomock.Eq(Anuskh.ChangeAccountStatusParams{
+						AccountID: account.ID,
+						From:      Anuskh.AccountActive,
+						To:        Anuskh.AccountClosed,
+					})).
+					Times(1).
+					Return(closed, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchingAccount(t, recorder.Body, closed)
+			},
+		},
+		{
+			name:     "CloseNotEmpty",
+			path:     fmt.Sprintf("/accounts/%d/close", account.ID),
+			body:     gin.H{"reason": "moving banks"},
+			username: owner.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ChangeAccountStatus(gomock.Any(), gomock.Any()).Times(1).Return(Anuskh.Account{}, Anuskh.ErrAccountNotEmpty)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "CloseOtherUsersAccount",
+			path:     fmt.Sprintf("/accounts/%d/close", account.ID),
+			username: other.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().ChangeAccountStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:     "CloseNotFound",
+			path:     fmt.Sprintf("/accounts/%d/close", account.ID),
+			username: owner.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(Anuskh.Account{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "ReopenFrozen",
+			path:     fmt.Sprintf("/accounts/%d/reopen", account.ID),
+			username: owner.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(frozen, nil)
+				store.EXPECT().
+					ChangeAccountStatus(gomock.Any(), gomock.Eq(Anuskh.ChangeAccountStatusParams{
+						AccountID: account.ID,
+						From:      Anuskh.AccountClosed,
+						To:        Anuskh.AccountActive,
+					})).
+					Times(1).
+					Return(Anuskh.Account{}, Anuskh.ErrInvalidStatusTransition)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "Freeze",
+			path:     fmt.Sprintf("/admin/accounts/%d/freeze", account.ID),
+			body:     gin.H{"reason": "chargeback"},
+			username: admin.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					ChangeAccountStatus(gomock.Any(), gomock.Eq(Anuskh.ChangeAccountStatusParams{
+						AccountID: account.ID,
+						From:      Anuskh.AccountActive,
+						To:        Anuskh.AccountFrozen,
+						Reason:    "chargeback",
+					})).
+					Times(1).
+					Return(frozen, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchingAccount(t, recorder.Body, frozen)
+			},
+		},
+		{
+			name:     "FreezeWithoutReason",
+			path:     fmt.Sprintf("/admin/accounts/%d/freeze", account.ID),
+			body:     gin.H{},
+			username: admin.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().ChangeAccountStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "FreezeNotAdmin",
+			path:     fmt.Sprintf("/admin/accounts/%d/freeze", account.ID),
+			body:     gin.H{"reason": "chargeback"},
+			username: owner.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(owner.Username)).Times(1).Return(owner, nil)
+				store.EXPECT().ChangeAccountStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "Unfreeze",
+			path:     fmt.Sprintf("/admin/accounts/%d/unfreeze", account.ID),
+			body:     gin.H{"reason": "resolved"},
+			username: admin.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(frozen, nil)
+				store.EXPECT().
+					ChangeAccountStatus(gomock.Any(), gomock.Eq(Anuskh.ChangeAccountStatusParams{
+						AccountID: account.ID,
+						From:      Anuskh.AccountFrozen,
+						To:        Anuskh.AccountActive,
+						Reason:    "resolved",
+					})).
+					Times(1).
+					Return(account, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			if tc.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+			}
+			request, err := http.NewRequest(http.MethodPost, tc.path, &body)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
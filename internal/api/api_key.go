@@ -0,0 +1,231 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/token"
+	"github.com/nilesh0729/Transactly/internal/util"
+)
+
+var errInvalidApiKey = errors.New("api key is invalid")
+
+type CreateApiKeyRequest struct {
+	Name       string     `json:"name" binding:"required,max=64"`
+	Scopes     []string   `json:"scopes" binding:"required,min=1,dive,oneof=accounts:read accounts:write transfers:read transfers:write"`
+	AllowedIPs []string   `json:"allowed_ips" binding:"omitempty,dive,ip|cidr"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+}
+
+type ApiKeyResponse struct {
+	ID         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	AllowedIPs []string   `json:"allowed_ips"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CreateApiKeyResponse struct {
+	Key    string         `json:"key"` // only ever shown once
+	ApiKey ApiKeyResponse `json:"api_key"`
+}
+
+func newApiKeyResponse(key Anuskh.ApiKey) ApiKeyResponse {
+	resp := ApiKeyResponse{
+		ID:         key.ID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		AllowedIPs: key.AllowedIps,
+		CreatedAt:  key.CreatedAt,
+	}
+	if key.ExpiresAt.Valid {
+		resp.ExpiresAt = &key.ExpiresAt.Time
+	}
+	return resp
+}
+
+func (server *Server) CreateApiKey(ctx *gin.Context) {
+	var req CreateApiKeyRequest
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var expiresAt sql.NullTime
+	if req.ExpiresAt != nil {
+		if !req.ExpiresAt.After(time.Now()) {
+			err := errors.New("expires_at must be in the future")
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		expiresAt = sql.NullTime{Time: *req.ExpiresAt, Valid: true}
+	}
+
+	allowedIPs := req.AllowedIPs
+	if allowedIPs == nil {
+		allowedIPs = []string{}
+	}
+
+	key, prefix, secret, err := util.GenerateAPIKey()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := Anuskh.CreateApiKeyParams{
+		Owner:        authPayload.Username,
+		Name:         req.Name,
+		Prefix:       prefix,
+		HashedSecret: util.HashSecret(secret),
+		Scopes:       req.Scopes,
+		AllowedIps:   allowedIPs,
+		ExpiresAt:    expiresAt,
+	}
+
+	apiKey, err := server.store.CreateApiKey(ctx, arg)
+	if err != nil {
+		switch Anuskh.ErrorCode(err) {
+		case Anuskh.ForeignKeyViolation, Anuskh.UniqueViolation:
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	resp := newApiKeyResponse(apiKey)
+	setAuditSnapshot(ctx, nil, resp)
+	ctx.JSON(http.StatusOK, CreateApiKeyResponse{
+		Key:    key,
+		ApiKey: resp,
+	})
+}
+
+type ListApiKeyRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+func (server *Server) ListApiKey(ctx *gin.Context) {
+	var req ListApiKeyRequest
+
+	err := ctx.ShouldBindQuery(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	arg := Anuskh.ListApiKeysParams{
+		Owner:  authPayload.Username,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	keys, err := server.store.ListApiKeys(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	resp := make([]ApiKeyResponse, len(keys))
+	for i, key := range keys {
+		resp[i] = newApiKeyResponse(key)
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type DeleteApiKeyRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) DeleteApiKey(ctx *gin.Context) {
+	var req DeleteApiKeyRequest
+
+	err := ctx.ShouldBindUri(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	rows, err := server.store.DeleteApiKey(ctx, Anuskh.DeleteApiKeyParams{
+		ID:    req.ID,
+		Owner: authPayload.Username,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows)) // Don't leak other users' keys
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+// verifyApiKey resolves a raw key from the Authorization header into a scoped
+// payload for the key's owner.
+func verifyApiKey(ctx *gin.Context, store Anuskh.Store, rawKey string) (*token.Payload, error) {
+	prefix, secret, err := util.SplitAPIKey(rawKey)
+	if err != nil {
+		return nil, errInvalidApiKey
+	}
+
+	key, err := store.GetApiKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, errInvalidApiKey
+		}
+		return nil, err
+	}
+
+	if err := util.CheckSecret(secret, key.HashedSecret); err != nil {
+		return nil, errInvalidApiKey
+	}
+
+	if key.ExpiresAt.Valid && time.Now().After(key.ExpiresAt.Time) {
+		return nil, fmt.Errorf("%w: key has expired", errInvalidApiKey)
+	}
+
+	if len(key.AllowedIps) > 0 && !ipAllowed(key.AllowedIps, ctx.ClientIP()) {
+		return nil, fmt.Errorf("%w: client ip %s is not allowed", errInvalidApiKey, ctx.ClientIP())
+	}
+
+	return &token.Payload{
+		Username: key.Owner,
+		Scopes:   key.Scopes,
+	}, nil
+}
+
+func ipAllowed(allowed []string, clientIP string) bool {
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		return false
+	}
+	for _, entry := range allowed {
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if allowedIP := net.ParseIP(entry); allowedIP != nil && allowedIP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
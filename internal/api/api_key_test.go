@@ -0,0 +1,456 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockDB "github.com/nilesh0729/Transactly/internal/db/Mock"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/token"
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func addApiKeyAuthorization(t *testing.T, request *http.Request, key string) {
+	request.Header.Set(authorizationHeaderKey, fmt.Sprintf("ApiKey %s", key))
+}
+
+func randomApiKey(t *testing.T, owner string, scopes ...string) (string, Anuskh.ApiKey) {
+	key, prefix, secret, err := util.GenerateAPIKey()
+	require.NoError(t, err)
+
+	return key, Anuskh.ApiKey{
+		ID:           util.RandomInt(1, 100),
+		Owner:        owner,
+		Name:         util.RandomOwner(),
+		Prefix:       prefix,
+		HashedSecret: util.HashSecret(secret),
+		Scopes:       scopes,
+		AllowedIps:   []string{},
+	}
+}
+
+func TestApiKeyAuthMiddleware(t *testing.T) {
+	_, user := RandomUser(t)
+
+	testCases := []struct {
+		name          string
+		scope         string
+		setAuth       func(t *testing.T, request *http.Request, store *mockDB.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			scope: scopeAccountsRead,
+			setAuth: func(t *testing.T, request *http.Request, store *mockDB.MockStore) {
+				key, apiKey := randomApiKey(t, user.Username, scopeAccountsRead)
+				store.EXPECT().
+					GetApiKeyByPrefix(gomock.Any(), gomock.Eq(apiKey.Prefix)).
+					Times(1).
+					Return(apiKey, nil)
+				addApiKeyAuthorization(t, request, key)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "MissingScope",
+			scope: scopeTransfersWrite,
+			setAuth: func(t *testing.T, request *http.Request, store *mockDB.MockStore) {
+				key, apiKey := randomApiKey(t, user.Username, scopeAccountsRead)
+				store.EXPECT().
+					GetApiKeyByPrefix(gomock.Any(), gomock.Eq(apiKey.Prefix)).
+					Times(1).
+					Return(apiKey, nil)
+				addApiKeyAuthorization(t, request, key)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "WrongSecret",
+			scope: scopeAccountsRead,
+			setAuth: func(t *testing.T, request *http.Request, store *mockDB.MockStore) {
+				_, apiKey := randomApiKey(t, user.Username, scopeAccountsRead)
+				other, _ := randomApiKey(t, user.Username, scopeAccountsRead)
+				prefix, _, err := util.SplitAPIKey(other)
+				require.NoError(t, err)
+				apiKey.Prefix = prefix
+
+				store.EXPECT().
+					GetApiKeyByPrefix(gomock.Any(), gomock.Eq(prefix)).
+					Times(1).
+					Return(apiKey, nil)
+				addApiKeyAuthorization(t, request, other)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "UnknownKey",
+			scope: scopeAccountsRead,
+			setAuth: func(t *testing.T, request *http.Request, store *mockDB.MockStore) {
+				key, apiKey := randomApiKey(t, user.Username, scopeAccountsRead)
+				store.EXPECT().
+					GetApiKeyByPrefix(gomock.Any(), gomock.Eq(apiKey.Prefix)).
+					Times(1).
+					Return(Anuskh.ApiKey{}, sql.ErrNoRows)
+				addApiKeyAuthorization(t, request, key)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "MalformedKey",
+			scope: scopeAccountsRead,
+			setAuth: func(t *testing.T, request *http.Request, store *mockDB.MockStore) {
+				store.EXPECT().
+					GetApiKeyByPrefix(gomock.Any(), gomock.Any()).
+					Times(0)
+				addApiKeyAuthorization(t, request, util.RandomString(20))
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "ExpiredKey",
+			scope: scopeAccountsRead,
+			setAuth: func(t *testing.T, request *http.Request, store *mockDB.MockStore) {
+				key, apiKey := randomApiKey(t, user.Username, scopeAccountsRead)
+				apiKey.ExpiresAt = sql.NullTime{Time: time.Now().Add(-time.Minute), Valid: true}
+				store.EXPECT().
+					GetApiKeyByPrefix(gomock.Any(), gomock.Eq(apiKey.Prefix)).
+					Times(1).
+					Return(apiKey, nil)
+				addApiKeyAuthorization(t, request, key)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "AllowedIP",
+			scope: scopeAccountsRead,
+			setAuth: func(t *testing.T, request *http.Request, store *mockDB.MockStore) {
+				key, apiKey := randomApiKey(t, user.Username, scopeAccountsRead)
+				apiKey.AllowedIps = []string{"192.168.1.7", "10.0.0.0/8"}
+				store.EXPECT().
+					GetApiKeyByPrefix(gomock.Any(), gomock.Eq(apiKey.Prefix)).
+					Times(1).
+					Return(apiKey, nil)
+				addApiKeyAuthorization(t, request, key)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "IPNotAllowed",
+			scope: scopeAccountsRead,
+			setAuth: func(t *testing.T, request *http.Request, store *mockDB.MockStore) {
+				key, apiKey := randomApiKey(t, user.Username, scopeAccountsRead)
+				apiKey.AllowedIps = []string{"192.168.1.7"}
+				store.EXPECT().
+					GetApiKeyByPrefix(gomock.Any(), gomock.Eq(apiKey.Prefix)).
+					Times(1).
+					Return(apiKey, nil)
+				addApiKeyAuthorization(t, request, key)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			scope: scopeAccountsRead,
+			setAuth: func(t *testing.T, request *http.Request, store *mockDB.MockStore) {
+				key, apiKey := randomApiKey(t, user.Username, scopeAccountsRead)
+				store.EXPECT().
+					GetApiKeyByPrefix(gomock.Any(), gomock.Eq(apiKey.Prefix)).
+					Times(1).
+					Return(Anuskh.ApiKey{}, sql.ErrConnDone)
+				addApiKeyAuthorization(t, request, key)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			server := newTestServer(t, store)
+
+			authPath := "/auth"
+			server.router.GET(
+				authPath,
+				authMiddleware(server.tokenMaker, server.store),
+				requireScope(tc.scope),
+				func(ctx *gin.Context) {
+					ctx.JSON(http.StatusOK, gin.H{})
+				},
+			)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, authPath, nil)
+			require.NoError(t, err)
+			request.RemoteAddr = "10.1.2.3:4567"
+
+			tc.setAuth(t, request, store)
+			server.router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCreateApiKeyAPI(t *testing.T) {
+	_, user := RandomUser(t)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		setAuth       func(t *testing.T, request *http.Request, tokenMaker token.Maker, store *mockDB.MockStore)
+		buildStubs    func(store *mockDB.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"name":        "ledger-sync",
+				"scopes":      []string{scopeAccountsRead, scopeTransfersRead},
+				"allowed_ips": []string{"10.0.0.0/8"},
+			},
+			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker, store *mockDB.MockStore) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					CreateApiKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ interface{}, arg Anuskh.CreateApiKeyParams) (Anuskh.ApiKey, error) {
+						require.Equal(t, user.Username, arg.Owner)
+						require.Equal(t, []string{scopeAccountsRead, scopeTransfersRead}, arg.Scopes)
+						require.False(t, arg.ExpiresAt.Valid)
+						return Anuskh.ApiKey{
+							ID:           1,
+							Owner:        arg.Owner,
+							Name:         arg.Name,
+							Prefix:       arg.Prefix,
+							HashedSecret: arg.HashedSecret,
+							Scopes:       arg.Scopes,
+							AllowedIps:   arg.AllowedIps,
+						}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got CreateApiKeyResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+
+				prefix, _, err := util.SplitAPIKey(got.Key)
+				require.NoError(t, err)
+				require.Equal(t, prefix, got.ApiKey.Prefix)
+				require.NotContains(t, recorder.Body.String(), "hashed_secret")
+			},
+		},
+		{
+			name: "UnknownScope",
+			body: gin.H{
+				"name":   "ledger-sync",
+				"scopes": []string{scopeUserSession},
+			},
+			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker, store *mockDB.MockStore) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					CreateApiKey(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidAllowedIP",
+			body: gin.H{
+				"name":        "ledger-sync",
+				"scopes":      []string{scopeAccountsRead},
+				"allowed_ips": []string{"not-an-ip"},
+			},
+			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker, store *mockDB.MockStore) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					CreateApiKey(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ExpiryInPast",
+			body: gin.H{
+				"name":       "ledger-sync",
+				"scopes":     []string{scopeAccountsRead},
+				"expires_at": time.Now().Add(-time.Hour),
+			},
+			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker, store *mockDB.MockStore) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					CreateApiKey(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ApiKeyCannotManageKeys",
+			body: gin.H{
+				"name":   "ledger-sync",
+				"scopes": []string{scopeAccountsRead},
+			},
+			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker, store *mockDB.MockStore) {
+				key, apiKey := randomApiKey(t, user.Username, scopeAccountsRead, scopeAccountsWrite, scopeTransfersRead, scopeTransfersWrite)
+				store.EXPECT().
+					GetApiKeyByPrefix(gomock.Any(), gomock.Eq(apiKey.Prefix)).
+					Times(1).
+					Return(apiKey, nil)
+				addApiKeyAuthorization(t, request, key)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					CreateApiKey(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/api-keys", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			tc.setAuth(t, request, server.tokenMaker, store)
+			server.router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeleteApiKeyAPI(t *testing.T) {
+	_, user := RandomUser(t)
+	_, apiKey := randomApiKey(t, user.Username, scopeAccountsRead)
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockDB.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockDB.MockStore) {
+				arg := Anuskh.DeleteApiKeyParams{ID: apiKey.ID, Owner: user.Username}
+				store.EXPECT().
+					DeleteApiKey(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(int64(1), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					DeleteApiKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					DeleteApiKey(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(int64(0), sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/api-keys/%d", apiKey.ID)
+			request, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockDB "github.com/nilesh0729/Transactly/internal/db/Mock"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/token"
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// randomAuditChain builds n correctly linked events following prevHash.
+func randomAuditChain(t *testing.T, n int, prevHash string) []Anuskh.AuditEvent {
+	events := make([]Anuskh.AuditEvent, n)
+	for i := range events {
+		events[i] = Anuskh.AuditEvent{
+			ID:        int64(i + 10),
+			Actor:     util.RandomOwner(),
+			Action:    "POST /accounts",
+			Resource:  "/accounts",
+			Ip:        "127.0.0.1",
+			Status:    http.StatusOK,
+			Before:    json.RawMessage("null"),
+			After:     json.RawMessage(fmt.Sprintf(`{"balance":%d}`, util.RandomBalance())),
+			PrevHash:  prevHash,
+			CreatedAt: time.Now().UTC().Truncate(time.Microsecond),
+		}
+		events[i].Hash = Anuskh.AuditEventHash(events[i])
+		prevHash = events[i].Hash
+	}
+	return events
+}
+
+func TestListAuditEventAPI(t *testing.T) {
+	_, auditor := RandomUser(t)
+	auditor.Role = util.AuditorRole
+	_, depositor := RandomUser(t)
+
+	previous := randomAuditChain(t, 1, "")[0]
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockDB.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "page_size=5",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, auditor.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				events := randomAuditChain(t, 5, previous.Hash)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(auditor.Username)).Times(1).Return(auditor, nil)
+				store.EXPECT().ListAuditEvents(gomock.Any(), gomock.Any()).Times(1).Return(events, nil)
+				store.EXPECT().GetAuditEventBefore(gomock.Any(), gomock.Eq(events[0].ID)).Times(1).Return(previous, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				resp := readListAuditEventResponse(t, recorder.Body)
+				require.True(t, resp.ChainValid)
+				require.Len(t, resp.Events, 5)
+			},
+		},
+		{
+			name:  "StartOfLog",
+			query: "page_size=5",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, auditor.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				events := randomAuditChain(t, 5, "")
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(auditor.Username)).Times(1).Return(auditor, nil)
+				store.EXPECT().ListAuditEvents(gomock.Any(), gomock.Any()).Times(1).Return(events, nil)
+				store.EXPECT().GetAuditEventBefore(gomock.Any(), gomock.Any()).Times(1).Return(Anuskh.AuditEvent{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				resp := readListAuditEventResponse(t, recorder.Body)
+				require.True(t, resp.ChainValid)
+			},
+		},
+		{
+			name:  "Tampered",
+			query: "page_size=5",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, auditor.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				events := randomAuditChain(t, 5, previous.Hash)
+				events[3].Status = http.StatusForbidden
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(auditor.Username)).Times(1).Return(auditor, nil)
+				store.EXPECT().ListAuditEvents(gomock.Any(), gomock.Any()).Times(1).Return(events, nil)
+				store.EXPECT().GetAuditEventBefore(gomock.Any(), gomock.Any()).Times(1).Return(previous, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				resp := readListAuditEventResponse(t, recorder.Body)
+				require.False(t, resp.ChainValid)
+				require.NotEmpty(t, resp.ChainError)
+			},
+		},
+		{
+			name:  "FilteredByActor",
+			query: "page_size=5&actor=" + depositor.Username,
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, auditor.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				arg := Anuskh.ListAuditEventsParams{
+					Actor:    sql.NullString{String: depositor.Username, Valid: true},
+					PageSize: 5,
+				}
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(auditor.Username)).Times(1).Return(auditor, nil)
+				store.EXPECT().ListAuditEvents(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]Anuskh.AuditEvent{}, nil)
+				store.EXPECT().GetAuditEventBefore(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "DepositorForbidden",
+			query: "page_size=5",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, depositor.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(depositor.Username)).Times(1).Return(depositor, nil)
+				store.EXPECT().ListAuditEvents(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "NoAuthorization",
+			query: "page_size=5",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListAuditEvents(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidPageSize",
+			query: "page_size=1000",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, auditor.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(auditor.Username)).Times(1).Return(auditor, nil)
+				store.EXPECT().ListAuditEvents(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/audit-events?"+tc.query, nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, request, server.tokenMaker)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestAuditMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockDB.NewMockStore(ctrl)
+	store.EXPECT().
+		AppendAuditEvent(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ any, arg Anuskh.AppendAuditEventParams) error {
+			require.Equal(t, "alice", arg.Actor)
+			require.Equal(t, "POST /audited", arg.Action)
+			require.Equal(t, "/audited", arg.Resource)
+			require.Equal(t, "req-1", arg.RequestID)
+			require.Equal(t, int32(http.StatusCreated), arg.Status)
+			require.Equal(t, gin.H{"id": 1}, arg.After)
+			return nil
+		})
+
+	router := gin.New()
+	router.Use(requestIDMiddleware(), auditMiddleware(store))
+	router.POST("/audited", func(ctx *gin.Context) {
+		setAuditActor(ctx, "alice")
+		setAuditSnapshot(ctx, nil, gin.H{"id": 1})
+		ctx.Status(http.StatusCreated)
+	})
+	router.GET("/audited", func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodPost, "/audited", nil)
+	require.NoError(t, err)
+	request.Header.Set(requestIDHeaderKey, "req-1")
+	router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusCreated, recorder.Code)
+
+	// reads are not audited
+	recorder = httptest.NewRecorder()
+	request, err = http.NewRequest(http.MethodGet, "/audited", nil)
+	require.NoError(t, err)
+	router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func readListAuditEventResponse(t *testing.T, body *bytes.Buffer) ListAuditEventResponse {
+	data, err := io.ReadAll(body)
+	require.NoError(t, err)
+
+	var resp ListAuditEventResponse
+	err = json.Unmarshal(data, &resp)
+	require.NoError(t, err)
+	return resp
+}
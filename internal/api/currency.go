@@ -0,0 +1,114 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/util"
+)
+
+type CurrencyResponse struct {
+	util.Currency
+	Enabled bool `json:"enabled"`
+}
+
+// ListCurrency lists the currencies accounts can be opened and money moved in.
+func (server *Server) ListCurrency(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, server.currencies.EnabledCurrencies())
+}
+
+// ListAdminCurrency lists every ISO 4217 currency and whether it is enabled.
+func (server *Server) ListAdminCurrency(ctx *gin.Context) {
+	currencies := util.ISOCurrencies()
+	resp := make([]CurrencyResponse, len(currencies))
+	for i, currency := range currencies {
+		resp[i] = CurrencyResponse{Currency: currency, Enabled: server.currencies.Enabled(currency.Code)}
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type CurrencyUri struct {
+	Code string `uri:"code" binding:"required,len=3"`
+}
+
+func (server *Server) EnableCurrency(ctx *gin.Context) {
+	server.setCurrencyEnabled(ctx, true)
+}
+
+// DisableCurrency stops new accounts and payees in a currency. Accounts
+// already holding it can still be read, transferred from, closed and paid
+// interest.
+func (server *Server) DisableCurrency(ctx *gin.Context) {
+	server.setCurrencyEnabled(ctx, false)
+}
+
+// setCurrencyEnabled stores the switch, so it outlives a restart and reaches
+// the other servers at their next refresh, and applies it here at once.
+func (server *Server) setCurrencyEnabled(ctx *gin.Context, enabled bool) {
+	var uri CurrencyUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	currency, ok := util.LookupCurrency(uri.Code)
+	if !ok {
+		ctx.JSON(http.StatusNotFound, errorResponse(fmt.Errorf("unknown currency %s", uri.Code)))
+		return
+	}
+	before := CurrencyResponse{Currency: currency, Enabled: server.currencies.Enabled(currency.Code)}
+
+	_, err := server.store.SetCurrencyEnabled(ctx, Anuskh.SetCurrencyEnabledParams{
+		Code:    currency.Code,
+		Enabled: enabled,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if err := server.currencies.SetEnabled(currency.Code, enabled); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	after := CurrencyResponse{Currency: currency, Enabled: enabled}
+	setAuditSnapshot(ctx, before, after)
+	ctx.JSON(http.StatusOK, after)
+}
+
+// refreshCurrencies applies the switches admins have stored, including those
+// made through other servers, on top of the configured currencies.
+func (server *Server) refreshCurrencies(ctx context.Context) error {
+	settings, err := server.store.ListCurrencySettings(ctx)
+	if err != nil {
+		return err
+	}
+	overrides := make(map[string]bool, len(settings))
+	for _, setting := range settings {
+		overrides[setting.Code] = setting.Enabled
+	}
+	server.currencies.Reset(overrides)
+	return nil
+}
+
+// watchCurrencies refreshes the currencies every period until ctx is done.
+func (server *Server) watchCurrencies(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := server.refreshCurrencies(ctx); err != nil {
+			slog.WarnContext(ctx, "currency refresh failed, keeping the current set", "error", err)
+		}
+	}
+}
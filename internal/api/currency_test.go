@@ -0,0 +1,254 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockDB "github.com/nilesh0729/Transactly/internal/db/Mock"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCurrencyAPI(t *testing.T) {
+	_, user := RandomUser(t)
+	_, admin := RandomUser(t)
+	admin.Role = util.AdminRole
+
+	testCases := []struct {
+		name          string
+		method        string
+		path          string
+		username      string // empty for no authorization
+		buildStubs    func(store *mockDB.MockStore)
+		checkResponse func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:       "ListEnabled",
+			method:     http.MethodGet,
+			path:       "/currencies",
+			buildStubs: func(store *mockDB.MockStore) {},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var currencies []util.Currency
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &currencies))
+				var codes []string
+				for _, currency := range currencies {
+					codes = append(codes, currency.Code)
+				}
+				require.Equal(t, []string{util.BDT, util.BRL, util.CAD, util.EUR, util.FJD, util.INR, util.JPY, util.USD}, codes)
+			},
+		},
+		{
+			name:     "ListAll",
+			method:   http.MethodGet,
+			path:     "/admin/currencies",
+			username: admin.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var currencies []CurrencyResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &currencies))
+				require.Len(t, currencies, len(util.ISOCurrencies()))
+				for _, currency := range currencies {
+					require.Equal(t, server.currencies.Enabled(currency.Code), currency.Enabled, currency.Code)
+				}
+			},
+		},
+		{
+			name:     "Disable",
+			method:   http.MethodPost,
+			path:     "/admin/currencies/JPY/disable",
+			username: admin.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().
+					SetCurrencyEnabled(gomock.Any(), gomock.Eq(Anuskh.SetCurrencyEnabledParams{Code: util.JPY, Enabled: false})).
+					Times(1).
+					Return(Anuskh.CurrencySetting{Code: util.JPY, Enabled: false}, nil)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var currency CurrencyResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &currency))
+				require.Equal(t, util.JPY, currency.Code)
+				require.Zero(t, currency.MinorUnits)
+				require.False(t, currency.Enabled)
+				require.False(t, server.currencies.Enabled(util.JPY))
+			},
+		},
+		{
+			name:     "Enable",
+			method:   http.MethodPost,
+			path:     "/admin/currencies/GBP/enable",
+			username: admin.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().
+					SetCurrencyEnabled(gomock.Any(), gomock.Eq(Anuskh.SetCurrencyEnabledParams{Code: "GBP", Enabled: true})).
+					Times(1).
+					Return(Anuskh.CurrencySetting{Code: "GBP", Enabled: true}, nil)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.True(t, server.currencies.Enabled("GBP"))
+			},
+		},
+		{
+			name:     "UnknownCurrency",
+			method:   http.MethodPost,
+			path:     "/admin/currencies/YEN/enable",
+			username: admin.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().SetCurrencyEnabled(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "StoreError",
+			method:   http.MethodPost,
+			path:     "/admin/currencies/JPY/disable",
+			username: admin.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().SetCurrencyEnabled(gomock.Any(), gomock.Any()).Times(1).Return(Anuskh.CurrencySetting{}, errors.New("db down"))
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+				require.True(t, server.currencies.Enabled(util.JPY))
+			},
+		},
+		{
+			name:     "NotAdmin",
+			method:   http.MethodPost,
+			path:     "/admin/currencies/JPY/disable",
+			username: user.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().SetCurrencyEnabled(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, server *Server, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(tc.method, tc.path, nil)
+			require.NoError(t, err)
+			if tc.username != "" {
+				addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+			}
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, server, recorder)
+		})
+	}
+}
+
+func TestDisabledCurrencyRejected(t *testing.T) {
+	_, user := RandomUser(t)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockDB.NewMockStore(ctrl)
+	store.EXPECT().CreateAccountTx(gomock.Any(), gomock.Any()).Times(0)
+
+	server := newTestServer(t, store)
+	require.NoError(t, server.currencies.SetEnabled(util.JPY, false))
+	recorder := httptest.NewRecorder()
+
+	var body bytes.Buffer
+	require.NoError(t, json.NewEncoder(&body).Encode(gin.H{"currency": util.JPY}))
+	request, err := http.NewRequest(http.MethodPost, "/accounts", &body)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestDisabledCurrencyTransfer(t *testing.T) {
+	_, user1 := RandomUser(t)
+	_, user2 := RandomUser(t)
+	account1 := randomAccount(user1.Username)
+	account2 := randomAccount(user2.Username)
+	account1.Currency = util.JPY
+	account2.Currency = util.JPY
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// balances already held in a disabled currency can still be moved out
+	store := mockDB.NewMockStore(ctrl)
+	store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(account1.ID)).Times(1).Return(account1, nil)
+	store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(account2.ID)).Times(1).Return(account2, nil)
+	store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1)
+
+	server := newTestServer(t, store)
+	require.NoError(t, server.currencies.SetEnabled(util.JPY, false))
+	recorder := httptest.NewRecorder()
+
+	var body bytes.Buffer
+	require.NoError(t, json.NewEncoder(&body).Encode(gin.H{
+		"from_account_id": account1.ID,
+		"to_account_id":   account2.ID,
+		"amount":          10,
+		"currency":        util.JPY,
+	}))
+	request, err := http.NewRequest(http.MethodPost, "/transfers", &body)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestRefreshCurrencies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockDB.NewMockStore(ctrl)
+	store.EXPECT().
+		ListCurrencySettings(gomock.Any()).
+		Times(1).
+		Return([]Anuskh.CurrencySetting{{Code: util.USD, Enabled: false}, {Code: "GBP", Enabled: true}}, nil)
+
+	server := newTestServer(t, store)
+	require.NoError(t, server.currencies.SetEnabled(util.JPY, false))
+
+	require.NoError(t, server.refreshCurrencies(context.Background()))
+	require.False(t, server.currencies.Enabled(util.USD))
+	require.True(t, server.currencies.Enabled("GBP"))
+	// a switch this server made but never stored is dropped
+	require.True(t, server.currencies.Enabled(util.JPY))
+}
@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/util"
+)
+
+type TransferQuoteResponse struct {
+	Amount     int64      `json:"amount"`
+	Fee        int64      `json:"fee"`
+	Total      int64      `json:"total"` // what leaves the sender's account
+	Currency   string     `json:"currency"`
+	TotalMoney util.Money `json:"total_money"`
+}
+
+// QuoteTransfer previews the fee on a transfer, taking the same fields as
+// CreateTransfer in the query string. It checks the accounts as the transfer
+// would but not its limits or the fraud rules.
+func (server *Server) QuoteTransfer(ctx *gin.Context) {
+	var req TransferRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	from, to, _, valid := server.transferAccounts(ctx, req)
+	if !valid {
+		return
+	}
+
+	fee, _ := Anuskh.TransferFee(server.fees, from, to, req.Amount)
+	total, err := util.NewMoney(req.Amount, req.Currency).Add(util.NewMoney(fee, req.Currency))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, TransferQuoteResponse{
+		Amount:     req.Amount,
+		Fee:        fee,
+		Total:      total.Amount,
+		Currency:   req.Currency,
+		TotalMoney: total,
+	})
+}
@@ -0,0 +1,150 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockDB "github.com/nilesh0729/Transactly/internal/db/Mock"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuoteTransferAPI(t *testing.T) {
+	_, user := RandomUser(t)
+	_, other := RandomUser(t)
+	from := randomAccount(user.Username)
+	savings := randomAccount(user.Username)
+	to := randomAccount(other.Username)
+	from.Currency, savings.Currency, to.Currency = util.USD, util.USD, util.USD
+
+	query := func(toAccountID int64, amount int64) string {
+		return fmt.Sprintf("?from_account_id=%d&to_account_id=%d&amount=%d&currency=USD", from.ID, toAccountID, amount)
+	}
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockDB.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Ok",
+			query: query(to.ID, 1000),
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(from.ID)).Times(1).Return(from, nil)
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(to.ID)).Times(1).Return(to, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var quote TransferQuoteResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &quote))
+				require.Equal(t, TransferQuoteResponse{
+					Amount:     1000,
+					Fee:        30,
+					Total:      1030,
+					Currency:   util.USD,
+					TotalMoney: util.NewMoney(1030, util.USD),
+				}, quote)
+			},
+		},
+		{
+			name:  "HigherTier",
+			query: query(to.ID, 100000),
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(from.ID)).Times(1).Return(from, nil)
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(to.ID)).Times(1).Return(to, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var quote TransferQuoteResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &quote))
+				require.Equal(t, int64(250), quote.Fee)
+				require.Equal(t, int64(100250), quote.Total)
+			},
+		},
+		{
+			name:  "OwnAccountsAreFree",
+			query: query(savings.ID, 1000),
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(from.ID)).Times(1).Return(from, nil)
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(savings.ID)).Times(1).Return(savings, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var quote TransferQuoteResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &quote))
+				require.Zero(t, quote.Fee)
+				require.Equal(t, int64(1000), quote.Total)
+			},
+		},
+		{
+			name:  "NotOwner",
+			query: fmt.Sprintf("?from_account_id=%d&to_account_id=%d&amount=1000&currency=USD", to.ID, from.ID),
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(to.ID)).Times(1).Return(to, nil)
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(from.ID)).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "ToAccountNotFound",
+			query: query(to.ID, 1000),
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(from.ID)).Times(1).Return(from, nil)
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(to.ID)).Times(1).Return(Anuskh.Account{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidAmount",
+			query: query(to.ID, 0),
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.fees = util.FeeSchedule{util.USD: {Account: 1, Tiers: []util.FeeTier{
+				{From: 0, Flat: 25, Bps: 50},
+				{From: 100000, Bps: 25},
+			}}}
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/transfers/quote"+tc.query, nil)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/db/migration"
+)
+
+// readinessTimeout bounds how long a probe may wait on the database.
+const readinessTimeout = 2 * time.Second
+
+type ReadinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// Healthz reports that the process is up. It deliberately touches nothing
+// else, so a struggling database doesn't get the container restarted.
+func (server *Server) Healthz(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Readyz reports whether this instance should receive traffic: the database
+// answers and its schema is at least as new as the code expects.
+func (server *Server) Readyz(ctx *gin.Context) {
+	checkCtx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	resp := ReadinessResponse{
+		Status: "ok",
+		Checks: map[string]string{"database": "ok", "migrations": "ok"},
+	}
+
+	if err := server.store.Ping(checkCtx); err != nil {
+		resp.Status = "unavailable"
+		resp.Checks["database"] = err.Error()
+		resp.Checks["migrations"] = "skipped"
+		ctx.JSON(http.StatusServiceUnavailable, resp)
+		return
+	}
+
+	if err := checkMigrationVersion(checkCtx, server.store); err != nil {
+		resp.Status = "unavailable"
+		resp.Checks["migrations"] = err.Error()
+		ctx.JSON(http.StatusServiceUnavailable, resp)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func checkMigrationVersion(ctx context.Context, store Anuskh.Store) error {
+	version, dirty, err := store.MigrationVersion(ctx)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errors.New("no migrations applied")
+		}
+		return err
+	}
+	return migration.CheckVersion(uint(version), dirty)
+}
@@ -0,0 +1,166 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/metrics"
+	"github.com/nilesh0729/Transactly/internal/token"
+)
+
+type HeldTransferResponse struct {
+	ID            int64      `json:"id"`
+	RequestedBy   string     `json:"requested_by"`
+	FromAccountID int64      `json:"from_account_id"`
+	ToAccountID   int64      `json:"to_account_id"`
+	Amount        int64      `json:"amount"`
+	Currency      string     `json:"currency"`
+	Status        string     `json:"status"`
+	Reasons       []string   `json:"reasons,omitempty"` // admins only, senders shouldn't learn which rules they tripped
+	ReviewedBy    string     `json:"reviewed_by,omitempty"`
+	ReviewNote    string     `json:"review_note,omitempty"`
+	TransferID    *int64     `json:"transfer_id,omitempty"` // once approved
+	CreatedAt     time.Time  `json:"created_at"`
+	ReviewedAt    *time.Time `json:"reviewed_at,omitempty"`
+}
+
+func newHeldTransferResponse(held Anuskh.HeldTransfer, withReasons bool) HeldTransferResponse {
+	resp := HeldTransferResponse{
+		ID:            held.ID,
+		RequestedBy:   held.RequestedBy,
+		FromAccountID: held.FromAccountID,
+		ToAccountID:   held.ToAccountID,
+		Amount:        held.Amount,
+		Currency:      held.Currency,
+		Status:        held.Status,
+		ReviewedBy:    held.ReviewedBy,
+		ReviewNote:    held.ReviewNote,
+		CreatedAt:     held.CreatedAt,
+	}
+	if withReasons {
+		resp.Reasons = held.Reasons
+	}
+	if held.TransferID.Valid {
+		resp.TransferID = &held.TransferID.Int64
+	}
+	if held.ReviewedAt.Valid {
+		resp.ReviewedAt = &held.ReviewedAt.Time
+	}
+	return resp
+}
+
+type ListHeldTransferRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=pending approved rejected"` // pending by default
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+// ListHeldTransfer is the review queue, oldest first.
+func (server *Server) ListHeldTransfer(ctx *gin.Context) {
+	var req ListHeldTransferRequest
+
+	err := ctx.ShouldBindQuery(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Status == "" {
+		req.Status = Anuskh.HeldTransferPending
+	}
+
+	held, err := server.store.ListHeldTransfers(ctx, Anuskh.ListHeldTransfersParams{
+		Status: req.Status,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	resp := make([]HeldTransferResponse, len(held))
+	for i, h := range held {
+		resp[i] = newHeldTransferResponse(h, true)
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type HeldTransferUri struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+type ApproveHeldTransferRequest struct {
+	Note string `json:"note" binding:"max=500"`
+}
+
+type RejectHeldTransferRequest struct {
+	Note string `json:"note" binding:"required,max=500"`
+}
+
+func (server *Server) ApproveHeldTransfer(ctx *gin.Context) {
+	var req ApproveHeldTransferRequest
+	// the body is optional
+	if err := ctx.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	server.reviewHeldTransfer(ctx, true, req.Note)
+}
+
+func (server *Server) RejectHeldTransfer(ctx *gin.Context) {
+	var req RejectHeldTransferRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	server.reviewHeldTransfer(ctx, false, req.Note)
+}
+
+// reviewHeldTransfer settles the held transfer in the URI. Approving it makes
+// the transfer, so it can still fail the way any transfer can.
+func (server *Server) reviewHeldTransfer(ctx *gin.Context, approve bool, note string) {
+	var uri HeldTransferUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	result, err := server.store.ReviewHeldTransferTx(auditContext(ctx), Anuskh.ReviewHeldTransferTxParams{
+		ID:         uri.ID,
+		Approve:    approve,
+		Reviewer:   authPayload.Username,
+		Note:       note,
+		Limits:     server.transferLimits,
+		CoolingOff: server.coolingOff,
+		Fees:       server.fees,
+	})
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, Anuskh.ErrHeldTransferReviewed),
+			errors.Is(err, Anuskh.ErrAccountNotActive),
+			errors.Is(err, Anuskh.ErrTransferLimitExceeded),
+			errors.Is(err, Anuskh.ErrPayeeCoolingOff):
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	if result.Transfer != nil {
+		metrics.Transfers.WithLabelValues(result.HeldTransfer.Currency).Inc()
+		metrics.TransferVolume.WithLabelValues(result.HeldTransfer.Currency).Add(float64(result.HeldTransfer.Amount))
+		metrics.TransferFees.WithLabelValues(result.HeldTransfer.Currency).Add(float64(result.Transfer.Transfer.Fee))
+	}
+	resp := newHeldTransferResponse(result.HeldTransfer, true)
+	setAuditSnapshot(ctx, nil, resp)
+	ctx.JSON(http.StatusOK, resp)
+}
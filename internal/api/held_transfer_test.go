@@ -0,0 +1,380 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockDB "github.com/nilesh0729/Transactly/internal/db/Mock"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/risk"
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// outcomeRule gives every transfer the same outcome.
+type outcomeRule risk.Outcome
+
+func (rule outcomeRule) Name() string { return "test" }
+
+func (rule outcomeRule) Evaluate(ctx context.Context, store risk.Store, transfer risk.Transfer) (risk.Outcome, string, error) {
+	return risk.Outcome(rule), "matched", nil
+}
+
+func randomHeldTransfer(from Anuskh.Account, to Anuskh.Account) Anuskh.HeldTransfer {
+	return Anuskh.HeldTransfer{
+		ID:            util.RandomInt(1, 1000),
+		RequestedBy:   from.Owner,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        util.RandomInt(1, 1000),
+		Currency:      from.Currency,
+		Reasons:       []string{"test: matched"},
+		Status:        Anuskh.HeldTransferPending,
+		CreatedAt:     time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func TestTransferFraudChecksAPI(t *testing.T) {
+	_, user1 := RandomUser(t)
+	_, user2 := RandomUser(t)
+
+	from := randomAccount(user1.Username)
+	to := randomAccount(user2.Username)
+	to.ID = from.ID + 1
+	to.Currency = from.Currency
+	held := randomHeldTransfer(from, to)
+	arg := Anuskh.TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        held.Amount,
+		CoolingOff:    Anuskh.CoolingOff{Period: time.Hour, Limit: 100},
+	}
+
+	testCases := []struct {
+		name          string
+		outcome       risk.Outcome
+		buildStubs    func(store *mockDB.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "Allow",
+			outcome: risk.Allow,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().CreateHeldTransfer(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:    "Review",
+			outcome: risk.Review,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					CheckTransferTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(nil)
+				store.EXPECT().
+					CreateHeldTransfer(gomock.Any(), gomock.Eq(Anuskh.CreateHeldTransferParams{
+						RequestedBy:   user1.Username,
+						FromAccountID: from.ID,
+						ToAccountID:   to.ID,
+						Amount:        held.Amount,
+						Currency:      from.Currency,
+						Reasons:       []string{"test: matched"},
+					})).
+					Times(1).
+					Return(held, nil)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusAccepted, recorder.Code)
+
+				var resp HeldTransferResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+				require.Equal(t, held.ID, resp.ID)
+				require.Equal(t, Anuskh.HeldTransferPending, resp.Status)
+				require.Empty(t, resp.Reasons)
+			},
+		},
+		{
+			// a transfer that could never be approved isn't held
+			name:    "ReviewOverLimit",
+			outcome: risk.Review,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					CheckTransferTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(fmt.Errorf("%w: 0 USD remains", Anuskh.ErrPayeeCoolingOff))
+				store.EXPECT().CreateHeldTransfer(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:    "Block",
+			outcome: risk.Block,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().CreateHeldTransfer(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().TransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				require.NotContains(t, recorder.Body.String(), "matched")
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(from.ID)).Times(1).Return(from, nil)
+			store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(to.ID)).Times(1).Return(to, nil)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.riskEngine = risk.NewEngine(store, outcomeRule(tc.outcome))
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{
+				"from_account_id": from.ID,
+				"to_account_id":   to.ID,
+				"amount":          held.Amount,
+				"currency":        from.Currency,
+			})
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(data))
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestReviewHeldTransferAPI(t *testing.T) {
+	_, user := RandomUser(t)
+	_, admin := RandomUser(t)
+	admin.Role = util.AdminRole
+
+	from := randomAccount(user.Username)
+	to := randomAccount(util.RandomOwner())
+	held := randomHeldTransfer(from, to)
+
+	approved := held
+	approved.Status = Anuskh.HeldTransferApproved
+	approved.ReviewedBy = admin.Username
+	approved.TransferID = sql.NullInt64{Int64: util.RandomInt(1, 1000), Valid: true}
+	approved.ReviewedAt = sql.NullTime{Time: time.Now().UTC().Truncate(time.Second), Valid: true}
+
+	rejected := held
+	rejected.Status = Anuskh.HeldTransferRejected
+	rejected.ReviewNote = "mule account"
+
+	testCases := []struct {
+		name          string
+		path          string
+		body          gin.H
+		username      string
+		buildStubs    func(store *mockDB.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Approve",
+			path:     fmt.Sprintf("/admin/held-transfers/%d/approve", held.ID),
+			username: admin.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().
+					ReviewHeldTransferTx(gomock.Any(), gomock.Eq(Anuskh.ReviewHeldTransferTxParams{
+						ID:         held.ID,
+						Approve:    true,
+						Reviewer:   admin.Username,
+						CoolingOff: Anuskh.CoolingOff{Period: time.Hour, Limit: 100},
+					})).
+					Times(1).
+					Return(Anuskh.ReviewHeldTransferTxResult{HeldTransfer: approved, Transfer: &Anuskh.TransferTxResult{}}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var resp HeldTransferResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+				require.Equal(t, Anuskh.HeldTransferApproved, resp.Status)
+				require.Equal(t, approved.TransferID.Int64, *resp.TransferID)
+				require.Equal(t, held.Reasons, resp.Reasons)
+			},
+		},
+		{
+			name:     "Reject",
+			path:     fmt.Sprintf("/admin/held-transfers/%d/reject", held.ID),
+			body:     gin.H{"note": "mule account"},
+			username: admin.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().
+					ReviewHeldTransferTx(gomock.Any(), gomock.Eq(Anuskh.ReviewHeldTransferTxParams{
+						ID:         held.ID,
+						Reviewer:   admin.Username,
+						Note:       "mule account",
+						CoolingOff: Anuskh.CoolingOff{Period: time.Hour, Limit: 100},
+					})).
+					Times(1).
+					Return(Anuskh.ReviewHeldTransferTxResult{HeldTransfer: rejected}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var resp HeldTransferResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+				require.Equal(t, Anuskh.HeldTransferRejected, resp.Status)
+				require.Nil(t, resp.TransferID)
+			},
+		},
+		{
+			name:     "RejectWithoutNote",
+			path:     fmt.Sprintf("/admin/held-transfers/%d/reject", held.ID),
+			body:     gin.H{},
+			username: admin.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().ReviewHeldTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "AlreadyReviewed",
+			path:     fmt.Sprintf("/admin/held-transfers/%d/approve", held.ID),
+			username: admin.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().
+					ReviewHeldTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(Anuskh.ReviewHeldTransferTxResult{}, Anuskh.ErrHeldTransferReviewed)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "PayeeCoolingOff",
+			path:     fmt.Sprintf("/admin/held-transfers/%d/approve", held.ID),
+			username: admin.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().
+					ReviewHeldTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(Anuskh.ReviewHeldTransferTxResult{}, Anuskh.ErrPayeeCoolingOff)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "NotFound",
+			path:     fmt.Sprintf("/admin/held-transfers/%d/approve", held.ID),
+			username: admin.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().
+					ReviewHeldTransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(Anuskh.ReviewHeldTransferTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:     "NotAdmin",
+			path:     fmt.Sprintf("/admin/held-transfers/%d/approve", held.ID),
+			username: user.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().ReviewHeldTransferTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			if tc.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+			}
+			request, err := http.NewRequest(http.MethodPost, tc.path, &body)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestListHeldTransferAPI(t *testing.T) {
+	_, admin := RandomUser(t)
+	admin.Role = util.AdminRole
+	held := randomHeldTransfer(randomAccount(util.RandomOwner()), randomAccount(util.RandomOwner()))
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockDB.NewMockStore(ctrl)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+	store.EXPECT().
+		ListHeldTransfers(gomock.Any(), gomock.Eq(Anuskh.ListHeldTransfersParams{Status: Anuskh.HeldTransferPending, Limit: 5, Offset: 0})).
+		Times(1).
+		Return([]Anuskh.HeldTransfer{held}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/admin/held-transfers?page_id=1&page_size=5", nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var resp []HeldTransferResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	require.Len(t, resp, 1)
+	require.Equal(t, held.Reasons, resp[0].Reasons)
+}
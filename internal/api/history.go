@@ -7,8 +7,31 @@ import (
 	"github.com/gin-gonic/gin"
 	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
 	"github.com/nilesh0729/Transactly/internal/token"
+	"github.com/nilesh0729/Transactly/internal/util"
 )
 
+// EntryResponse adds the amount in the account's currency to an entry.
+type EntryResponse struct {
+	Anuskh.Entry
+	AmountMoney util.Money `json:"amount_money"`
+}
+
+// TransferResponse adds the amount and fee in the currency of the accounts,
+// which always match, to a transfer.
+type TransferResponse struct {
+	Anuskh.Transfer
+	AmountMoney util.Money `json:"amount_money"`
+	FeeMoney    util.Money `json:"fee_money"`
+}
+
+func newTransferResponse(transfer Anuskh.Transfer, currency string) TransferResponse {
+	return TransferResponse{
+		Transfer:    transfer,
+		AmountMoney: util.NewMoney(transfer.Amount, currency),
+		FeeMoney:    util.NewMoney(transfer.Fee, currency),
+	}
+}
+
 type listEntryRequest struct {
 	PageID   int32 `form:"page_id" binding:"required,min=1"`
 	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
@@ -58,7 +81,11 @@ func (server *Server) ListEntry(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, entries)
+	resp := make([]EntryResponse, len(entries))
+	for i, entry := range entries {
+		resp[i] = EntryResponse{Entry: entry, AmountMoney: util.NewMoney(entry.Amount, account.Currency)}
+	}
+	ctx.JSON(http.StatusOK, resp)
 }
 
 type listTransferRequest struct {
@@ -104,5 +131,9 @@ func (server *Server) ListTransfer(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, transfers)
+	resp := make([]TransferResponse, len(transfers))
+	for i, transfer := range transfers {
+		resp[i] = newTransferResponse(transfer, account.Currency)
+	}
+	ctx.JSON(http.StatusOK, resp)
 }
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/token"
+)
+
+type listInterestRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+type InterestAccrualResponse struct {
+	Date         string `json:"date"`
+	Balance      int64  `json:"balance"`       // at the end of the day
+	RateBps      int64  `json:"rate_bps"`      // annual, in hundredths of a percent
+	AmountMicros int64  `json:"amount_micros"` // millionths of a minor unit
+	Posted       bool   `json:"posted"`
+}
+
+type InterestHistoryResponse struct {
+	AccountID int64                     `json:"account_id"`
+	RateBps   int64                     `json:"rate_bps"` // what the account earns now, 0 for none
+	Accruals  []InterestAccrualResponse `json:"accruals"` // newest first
+}
+
+// ListInterest shows the interest the caller's account accrued day by day and
+// whether it has been posted yet.
+func (server *Server) ListInterest(ctx *gin.Context) {
+	var req listInterestRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	accountIDURI := struct {
+		ID int64 `uri:"id" binding:"required,min=1"`
+	}{}
+	if err := ctx.ShouldBindUri(&accountIDURI); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, err := server.store.GetAccounts(ctx, accountIDURI.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if account.Owner != authPayload.Username {
+		ctx.JSON(http.StatusUnauthorized, errorResponse(sql.ErrNoRows)) // Don't leak existence
+		return
+	}
+
+	accruals, err := server.store.ListInterestAccruals(ctx, Anuskh.ListInterestAccrualsParams{
+		AccountID: account.ID,
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rate, _ := server.interestRates.For(account.AccountType, account.Currency)
+	resp := InterestHistoryResponse{
+		AccountID: account.ID,
+		RateBps:   rate.Bps,
+		Accruals:  make([]InterestAccrualResponse, len(accruals)),
+	}
+	for i, accrual := range accruals {
+		resp.Accruals[i] = InterestAccrualResponse{
+			Date:         accrual.AccrualDate.Format(time.DateOnly),
+			Balance:      accrual.Balance,
+			RateBps:      accrual.RateBps,
+			AmountMicros: accrual.AmountMicros,
+			Posted:       accrual.PostingID.Valid,
+		}
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
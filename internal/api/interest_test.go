@@ -0,0 +1,144 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockDB "github.com/nilesh0729/Transactly/internal/db/Mock"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListInterestAPI(t *testing.T) {
+	_, user := RandomUser(t)
+	_, other := RandomUser(t)
+	account := randomAccount(user.Username)
+	account.AccountType = Anuskh.AccountSavings
+	account.Currency = util.USD
+	otherAccount := randomAccount(other.Username)
+
+	day := time.Date(2026, time.March, 2, 0, 0, 0, 0, time.UTC)
+	accruals := []Anuskh.InterestAccrual{
+		{ID: 2, AccountID: account.ID, AccrualDate: day, Balance: 36500, RateBps: 250, AmountMicros: 2500000},
+		{ID: 1, AccountID: account.ID, AccrualDate: day.AddDate(0, 0, -1), Balance: 36500, RateBps: 250, AmountMicros: 2500000,
+			PostingID: sql.NullInt64{Int64: 7, Valid: true}},
+	}
+
+	testCases := []struct {
+		name          string
+		accountID     int64
+		query         string
+		buildStubs    func(store *mockDB.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "Ok",
+			accountID: account.ID,
+			query:     "?page_id=1&page_size=5",
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(account, nil)
+				store.EXPECT().
+					ListInterestAccruals(gomock.Any(), gomock.Eq(Anuskh.ListInterestAccrualsParams{AccountID: account.ID, Limit: 5, Offset: 0})).
+					Times(1).
+					Return(accruals, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var resp InterestHistoryResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+				require.Equal(t, InterestHistoryResponse{
+					AccountID: account.ID,
+					RateBps:   250,
+					Accruals: []InterestAccrualResponse{
+						{Date: "2026-03-02", Balance: 36500, RateBps: 250, AmountMicros: 2500000, Posted: false},
+						{Date: "2026-03-01", Balance: 36500, RateBps: 250, AmountMicros: 2500000, Posted: true},
+					},
+				}, resp)
+			},
+		},
+		{
+			name:      "NotOwner",
+			accountID: otherAccount.ID,
+			query:     "?page_id=1&page_size=5",
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(otherAccount.ID)).Times(1).Return(otherAccount, nil)
+				store.EXPECT().ListInterestAccruals(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "NotFound",
+			accountID: account.ID,
+			query:     "?page_id=1&page_size=5",
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(account.ID)).Times(1).Return(Anuskh.Account{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidPageSize",
+			accountID: account.ID,
+			query:     "?page_id=1&page_size=1000",
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccounts(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.interestRates = util.InterestRates{"savings/USD": {Bps: 250, FundingAccount: 1}}
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/accounts/%d/interest%s", tc.accountID, tc.query)
+			request, err := http.NewRequest(http.MethodGet, url, nil)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestNewServerInterestRunPeriod(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockDB.NewMockStore(ctrl)
+
+	config := util.Config{
+		TokenSymmetricKey: util.RandomString(32),
+		Currencies:        util.DefaultCurrencies,
+		InterestRates:     "savings/USD@3:2.5%",
+	}
+	_, err := NewServer(store, config)
+	require.Error(t, err)
+
+	config.InterestRunPeriod = time.Hour
+	_, err = NewServer(store, config)
+	require.NoError(t, err)
+}
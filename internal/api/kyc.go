@@ -0,0 +1,360 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/token"
+	"github.com/nilesh0729/Transactly/internal/util"
+)
+
+// kycContentTypes are the document formats accepted, as sniffed from the
+// file itself rather than taken from the client.
+var kycContentTypes = []string{"image/jpeg", "image/png", "application/pdf"}
+
+// multipartOverhead leaves room in the request body for the form fields and
+// part headers around the document.
+const multipartOverhead = 64 << 10
+
+type KycDocumentResponse struct {
+	ID          int64     `json:"id"`
+	Kind        string    `json:"kind"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int64     `json:"size_bytes"`
+	Sha256      string    `json:"sha256"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func newKycDocumentResponse(document Anuskh.KycDocument) KycDocumentResponse {
+	return KycDocumentResponse{
+		ID:          document.ID,
+		Kind:        document.Kind,
+		ContentType: document.ContentType,
+		SizeBytes:   document.SizeBytes,
+		Sha256:      document.Sha256,
+		CreatedAt:   document.CreatedAt,
+	}
+}
+
+type KycResponse struct {
+	Username   string                `json:"username"`
+	Status     string                `json:"status"`
+	Note       string                `json:"note,omitempty"` // why it was rejected
+	ReviewedBy string                `json:"reviewed_by,omitempty"`
+	UpdatedAt  time.Time             `json:"updated_at"`
+	Documents  []KycDocumentResponse `json:"documents,omitempty"`
+}
+
+func newKycResponse(user Anuskh.User, documents []Anuskh.KycDocument) KycResponse {
+	resp := KycResponse{
+		Username:   user.Username,
+		Status:     user.KycStatus,
+		Note:       user.KycNote,
+		ReviewedBy: user.KycReviewedBy,
+		UpdatedAt:  user.KycUpdatedAt,
+	}
+	for _, document := range documents {
+		resp.Documents = append(resp.Documents, newKycDocumentResponse(document))
+	}
+	return resp
+}
+
+// kycOf loads a user's KYC status and documents. It writes the response and
+// returns false on failure.
+func (server *Server) kycOf(ctx *gin.Context, username string) (KycResponse, bool) {
+	user, err := server.store.GetUser(ctx, username)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return KycResponse{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return KycResponse{}, false
+	}
+
+	documents, err := server.store.ListKycDocuments(ctx, username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return KycResponse{}, false
+	}
+	return newKycResponse(user, documents), true
+}
+
+// GetKyc shows the caller's KYC status and the documents they uploaded.
+func (server *Server) GetKyc(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if resp, ok := server.kycOf(ctx, authPayload.Username); ok {
+		ctx.JSON(http.StatusOK, resp)
+	}
+}
+
+type UploadKycDocumentRequest struct {
+	Kind string `form:"kind" binding:"required,oneof=passport id_card driving_licence proof_of_address"`
+}
+
+// UploadKycDocument takes a multipart form with the document in the
+// "document" field. Documents can be added until the user submits, and again
+// after a rejection.
+func (server *Server) UploadKycDocument(ctx *gin.Context) {
+	maxSize := server.config.KycMaxDocumentSize
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxSize+multipartOverhead)
+
+	var req UploadKycDocumentRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		uploadError(ctx, err)
+		return
+	}
+	header, err := ctx.FormFile("document")
+	if err != nil {
+		uploadError(ctx, err)
+		return
+	}
+	if header.Size > maxSize {
+		ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(fmt.Errorf("document is over %d bytes", maxSize)))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if user.KycStatus != Anuskh.KycUnverified && user.KycStatus != Anuskh.KycRejected {
+		ctx.JSON(http.StatusForbidden, errorResponse(fmt.Errorf("cannot add documents while kyc is %s", user.KycStatus)))
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	defer file.Close()
+	data, err := io.ReadAll(io.LimitReader(file, maxSize))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	contentType := http.DetectContentType(data)
+	if !slices.Contains(kycContentTypes, contentType) {
+		ctx.JSON(http.StatusUnsupportedMediaType, errorResponse(fmt.Errorf("%s documents are not accepted, upload a JPEG, PNG or PDF", contentType)))
+		return
+	}
+
+	suffix, err := util.RandomHex(16)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	key := "kyc/" + user.Username + "/" + suffix
+	if err := server.blobs.Put(ctx, key, bytes.NewReader(data)); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	document, err := server.store.CreateKycDocument(ctx, Anuskh.CreateKycDocumentParams{
+		Owner:       user.Username,
+		Kind:        req.Kind,
+		ContentType: contentType,
+		SizeBytes:   int64(len(data)),
+		Sha256:      hex.EncodeToString(sum[:]),
+		BlobKey:     key,
+	})
+	if err != nil {
+		// nothing refers to the blob, don't leave it behind
+		if delErr := server.blobs.Delete(context.WithoutCancel(ctx), key); delErr != nil {
+			slog.ErrorContext(ctx, "cannot delete orphaned kyc document", "key", key, "error", delErr)
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	resp := newKycDocumentResponse(document)
+	setAuditSnapshot(ctx, nil, resp)
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// uploadError answers a request whose form couldn't be read, telling an
+// oversized body apart from a malformed one.
+func uploadError(ctx *gin.Context, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		ctx.JSON(http.StatusRequestEntityTooLarge, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusBadRequest, errorResponse(err))
+}
+
+// SubmitKyc puts the caller's documents in the review queue.
+func (server *Server) SubmitKyc(ctx *gin.Context) {
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	server.changeKycStatus(ctx, Anuskh.ChangeKycStatusParams{
+		Username: authPayload.Username,
+		To:       Anuskh.KycPending,
+	})
+}
+
+type ListKycReviewRequest struct {
+	Status   string `form:"status" binding:"omitempty,oneof=unverified pending verified rejected"` // pending by default
+	PageID   int32  `form:"page_id" binding:"required,min=1"`
+	PageSize int32  `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+// ListKycReview is the review queue, longest waiting first.
+func (server *Server) ListKycReview(ctx *gin.Context) {
+	var req ListKycReviewRequest
+
+	err := ctx.ShouldBindQuery(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.Status == "" {
+		req.Status = Anuskh.KycPending
+	}
+
+	users, err := server.store.ListUsersByKycStatus(ctx, Anuskh.ListUsersByKycStatusParams{
+		KycStatus: req.Status,
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	resp := make([]KycResponse, len(users))
+	for i, user := range users {
+		resp[i] = newKycResponse(user, nil)
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type KycUserUri struct {
+	Username string `uri:"username" binding:"required,alphanum"`
+}
+
+func (server *Server) GetUserKyc(ctx *gin.Context) {
+	var uri KycUserUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if resp, ok := server.kycOf(ctx, uri.Username); ok {
+		ctx.JSON(http.StatusOK, resp)
+	}
+}
+
+type KycDocumentUri struct {
+	Username string `uri:"username" binding:"required,alphanum"`
+	ID       int64  `uri:"id" binding:"required,min=1"`
+}
+
+// DownloadKycDocument sends a document as an attachment, so a browser never
+// renders what a user uploaded.
+func (server *Server) DownloadKycDocument(ctx *gin.Context) {
+	var uri KycDocumentUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	document, err := server.store.GetKycDocument(ctx, uri.ID)
+	if err == nil && document.Owner != uri.Username {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	r, err := server.blobs.Open(ctx, document.BlobKey)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	defer r.Close()
+
+	ctx.Header("X-Content-Type-Options", "nosniff")
+	ctx.DataFromReader(http.StatusOK, document.SizeBytes, document.ContentType, r, map[string]string{
+		"Content-Disposition": `attachment; filename="` + document.Kind + "-" + strconv.FormatInt(document.ID, 10) + `"`,
+	})
+}
+
+type RejectKycRequest struct {
+	Note string `json:"note" binding:"required,max=500"` // shown to the user
+}
+
+func (server *Server) ApproveKyc(ctx *gin.Context) {
+	server.reviewKyc(ctx, Anuskh.KycVerified, "")
+}
+
+func (server *Server) RejectKyc(ctx *gin.Context) {
+	var req RejectKycRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	server.reviewKyc(ctx, Anuskh.KycRejected, req.Note)
+}
+
+// reviewKyc settles the submission of the user in the URI. Admins can't
+// review their own.
+func (server *Server) reviewKyc(ctx *gin.Context, to string, note string) {
+	var uri KycUserUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if uri.Username == authPayload.Username {
+		ctx.JSON(http.StatusForbidden, errorResponse(errors.New("cannot review your own kyc")))
+		return
+	}
+	server.changeKycStatus(ctx, Anuskh.ChangeKycStatusParams{
+		Username: uri.Username,
+		To:       to,
+		Reviewer: authPayload.Username,
+		Note:     note,
+	})
+}
+
+func (server *Server) changeKycStatus(ctx *gin.Context, arg Anuskh.ChangeKycStatusParams) {
+	user, err := server.store.ChangeKycStatus(ctx, arg)
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, Anuskh.ErrInvalidKycTransition),
+			errors.Is(err, Anuskh.ErrKycNoDocuments):
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	resp := newKycResponse(user, nil)
+	setAuditSnapshot(ctx, nil, resp)
+	ctx.JSON(http.StatusOK, resp)
+}
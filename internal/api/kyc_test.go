@@ -0,0 +1,468 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/nilesh0729/Transactly/internal/blob"
+	mockDB "github.com/nilesh0729/Transactly/internal/db/Mock"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// pngDocument is enough of a PNG for content sniffing.
+var pngDocument = append([]byte("\x89PNG\r\n\x1a\n"), bytes.Repeat([]byte{0}, 100)...)
+
+func newUploadRequest(t *testing.T, kind string, document []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	require.NoError(t, writer.WriteField("kind", kind))
+	part, err := writer.CreateFormFile("document", "scan")
+	require.NoError(t, err)
+	_, err = part.Write(document)
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+
+	request, err := http.NewRequest(http.MethodPost, "/kyc/documents", &body)
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	return request
+}
+
+func TestUploadKycDocumentAPI(t *testing.T) {
+	_, user := RandomUser(t)
+	user.KycStatus = Anuskh.KycUnverified
+	sum := sha256.Sum256(pngDocument)
+	var storedKey string
+
+	testCases := []struct {
+		name          string
+		kind          string
+		document      []byte
+		buildStubs    func(store *mockDB.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder, blobs blob.Store)
+	}{
+		{
+			name:     "OK",
+			kind:     Anuskh.KycPassport,
+			document: pngDocument,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().
+					CreateKycDocument(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg Anuskh.CreateKycDocumentParams) (Anuskh.KycDocument, error) {
+						require.Equal(t, user.Username, arg.Owner)
+						require.Equal(t, Anuskh.KycPassport, arg.Kind)
+						require.Equal(t, "image/png", arg.ContentType)
+						require.Equal(t, int64(len(pngDocument)), arg.SizeBytes)
+						require.Equal(t, hex.EncodeToString(sum[:]), arg.Sha256)
+						require.True(t, strings.HasPrefix(arg.BlobKey, "kyc/"+user.Username+"/"))
+						storedKey = arg.BlobKey
+						return Anuskh.KycDocument{ID: 1, Owner: arg.Owner, Kind: arg.Kind, ContentType: arg.ContentType, SizeBytes: arg.SizeBytes, Sha256: arg.Sha256, BlobKey: arg.BlobKey}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, blobs blob.Store) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var got KycDocumentResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, "image/png", got.ContentType)
+				require.NotContains(t, recorder.Body.String(), "kyc/", "blob keys stay internal")
+
+				r, err := blobs.Open(context.Background(), storedKey)
+				require.NoError(t, err)
+				defer r.Close()
+				stored, err := io.ReadAll(r)
+				require.NoError(t, err)
+				require.Equal(t, pngDocument, stored)
+			},
+		},
+		{
+			name:     "UnsupportedType",
+			kind:     Anuskh.KycPassport,
+			document: []byte("just some text pretending to be a passport"),
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(user, nil)
+				store.EXPECT().CreateKycDocument(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, blobs blob.Store) {
+				require.Equal(t, http.StatusUnsupportedMediaType, recorder.Code)
+			},
+		},
+		{
+			name:     "TooLarge",
+			kind:     Anuskh.KycPassport,
+			document: append(bytes.Clone(pngDocument), make([]byte, 1<<20)...),
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateKycDocument(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, blobs blob.Store) {
+				require.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code)
+			},
+		},
+		{
+			name:     "InvalidKind",
+			kind:     "library_card",
+			document: pngDocument,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreateKycDocument(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, blobs blob.Store) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "AlreadySubmitted",
+			kind:     Anuskh.KycPassport,
+			document: pngDocument,
+			buildStubs: func(store *mockDB.MockStore) {
+				pending := user
+				pending.KycStatus = Anuskh.KycPending
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(pending, nil)
+				store.EXPECT().CreateKycDocument(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, blobs blob.Store) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request := newUploadRequest(t, tc.kind, tc.document)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder, server.blobs)
+		})
+	}
+}
+
+// TestUploadKycDocumentNotRecorded checks that a document the database
+// refused doesn't stay in the blob store.
+func TestUploadKycDocumentNotRecorded(t *testing.T) {
+	_, user := RandomUser(t)
+	user.KycStatus = Anuskh.KycRejected
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var key string
+	store := mockDB.NewMockStore(ctrl)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(1).Return(user, nil)
+	store.EXPECT().
+		CreateKycDocument(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(ctx context.Context, arg Anuskh.CreateKycDocumentParams) (Anuskh.KycDocument, error) {
+			key = arg.BlobKey
+			return Anuskh.KycDocument{}, errors.New("connection reset")
+		})
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request := newUploadRequest(t, Anuskh.KycIDCard, pngDocument)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+	_, err := server.blobs.Open(context.Background(), key)
+	require.ErrorIs(t, err, blob.ErrNotFound)
+}
+
+func TestGetKycAPI(t *testing.T) {
+	_, user := RandomUser(t)
+	user.KycStatus = Anuskh.KycRejected
+	user.KycNote = "photo is blurry"
+	documents := []Anuskh.KycDocument{{ID: 1, Owner: user.Username, Kind: Anuskh.KycPassport, BlobKey: "kyc/" + user.Username + "/a"}}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockDB.NewMockStore(ctrl)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+	store.EXPECT().ListKycDocuments(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(documents, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/kyc", nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got KycResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Equal(t, Anuskh.KycRejected, got.Status)
+	require.Equal(t, "photo is blurry", got.Note)
+	require.Len(t, got.Documents, 1)
+}
+
+func TestChangeKycStatusAPI(t *testing.T) {
+	_, user := RandomUser(t)
+	_, admin := RandomUser(t)
+	admin.Role = util.AdminRole
+
+	testCases := []struct {
+		name          string
+		path          string
+		username      string
+		body          gin.H
+		buildStubs    func(store *mockDB.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Submit",
+			path:     "/kyc/submit",
+			username: user.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				pending := user
+				pending.KycStatus = Anuskh.KycPending
+				store.EXPECT().
+					ChangeKycStatus(gomock.Any(), gomock.Eq(Anuskh.ChangeKycStatusParams{Username: user.Username, To: Anuskh.KycPending})).
+					Times(1).
+					Return(pending, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "SubmitWithoutDocuments",
+			path:     "/kyc/submit",
+			username: user.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().ChangeKycStatus(gomock.Any(), gomock.Any()).Times(1).Return(Anuskh.User{}, Anuskh.ErrKycNoDocuments)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "Approve",
+			path:     fmt.Sprintf("/admin/kyc/%s/approve", user.Username),
+			username: admin.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				verified := user
+				verified.KycStatus = Anuskh.KycVerified
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().
+					ChangeKycStatus(gomock.Any(), gomock.Eq(Anuskh.ChangeKycStatusParams{
+						Username: user.Username,
+						To:       Anuskh.KycVerified,
+						Reviewer: admin.Username,
+					})).
+					Times(1).
+					Return(verified, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var got KycResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, Anuskh.KycVerified, got.Status)
+			},
+		},
+		{
+			name:     "ApproveNotPending",
+			path:     fmt.Sprintf("/admin/kyc/%s/approve", user.Username),
+			username: admin.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().ChangeKycStatus(gomock.Any(), gomock.Any()).Times(1).Return(Anuskh.User{}, Anuskh.ErrInvalidKycTransition)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "Reject",
+			path:     fmt.Sprintf("/admin/kyc/%s/reject", user.Username),
+			username: admin.Username,
+			body:     gin.H{"note": "passport has expired"},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().
+					ChangeKycStatus(gomock.Any(), gomock.Eq(Anuskh.ChangeKycStatusParams{
+						Username: user.Username,
+						To:       Anuskh.KycRejected,
+						Reviewer: admin.Username,
+						Note:     "passport has expired",
+					})).
+					Times(1).
+					Return(user, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "RejectWithoutNote",
+			path:     fmt.Sprintf("/admin/kyc/%s/reject", user.Username),
+			username: admin.Username,
+			body:     gin.H{},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().ChangeKycStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "OwnKyc",
+			path:     fmt.Sprintf("/admin/kyc/%s/approve", admin.Username),
+			username: admin.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+				store.EXPECT().ChangeKycStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:     "NotAdmin",
+			path:     fmt.Sprintf("/admin/kyc/%s/approve", admin.Username),
+			username: user.Username,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().ChangeKycStatus(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var body io.Reader = http.NoBody
+			if tc.body != nil {
+				data, err := json.Marshal(tc.body)
+				require.NoError(t, err)
+				body = bytes.NewReader(data)
+			}
+			request, err := http.NewRequest(http.MethodPost, tc.path, body)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, tc.username, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDownloadKycDocumentAPI(t *testing.T) {
+	_, user := RandomUser(t)
+	_, admin := RandomUser(t)
+	admin.Role = util.AdminRole
+	document := Anuskh.KycDocument{
+		ID:          4,
+		Owner:       user.Username,
+		Kind:        Anuskh.KycPassport,
+		ContentType: "image/png",
+		SizeBytes:   int64(len(pngDocument)),
+		BlobKey:     "kyc/" + user.Username + "/abc",
+	}
+
+	for _, owner := range []string{user.Username, admin.Username} {
+		t.Run(owner, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+			store.EXPECT().GetKycDocument(gomock.Any(), gomock.Eq(document.ID)).Times(1).Return(document, nil)
+
+			server := newTestServer(t, store)
+			require.NoError(t, server.blobs.Put(context.Background(), document.BlobKey, bytes.NewReader(pngDocument)))
+			recorder := httptest.NewRecorder()
+
+			// the document has to belong to the user in the path
+			request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/admin/kyc/%s/documents/%d", owner, document.ID), nil)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			if owner != document.Owner {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+				return
+			}
+			require.Equal(t, http.StatusOK, recorder.Code)
+			require.Equal(t, pngDocument, recorder.Body.Bytes())
+			require.Equal(t, "image/png", recorder.Header().Get("Content-Type"))
+			require.Equal(t, "nosniff", recorder.Header().Get("X-Content-Type-Options"))
+			require.True(t, strings.HasPrefix(recorder.Header().Get("Content-Disposition"), "attachment"))
+		})
+	}
+}
+
+func TestListKycReviewAPI(t *testing.T) {
+	_, user := RandomUser(t)
+	user.KycStatus = Anuskh.KycPending
+	_, admin := RandomUser(t)
+	admin.Role = util.AdminRole
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mockDB.NewMockStore(ctrl)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(admin.Username)).Times(1).Return(admin, nil)
+	store.EXPECT().
+		ListUsersByKycStatus(gomock.Any(), gomock.Eq(Anuskh.ListUsersByKycStatusParams{KycStatus: Anuskh.KycPending, Limit: 5})).
+		Times(1).
+		Return([]Anuskh.User{user}, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/admin/kyc?page_id=1&page_size=5", nil)
+	require.NoError(t, err)
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, admin.Username, time.Minute)
+
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got []KycResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Len(t, got, 1)
+	require.Equal(t, user.Username, got[0].Username)
+	require.NotContains(t, recorder.Body.String(), "hashed_password")
+}
@@ -0,0 +1,82 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/token"
+	"github.com/nilesh0729/Transactly/internal/util"
+)
+
+type GetLimitsRequest struct {
+	Currency string `form:"currency" binding:"required,iso_currency"`
+}
+
+// LimitAllowance is one windowed limit. Limit 0 means there is none, and then
+// Remaining is left out.
+type LimitAllowance struct {
+	Limit     int64      `json:"limit"`
+	Used      int64      `json:"used"`
+	Remaining *int64     `json:"remaining,omitempty"`
+	ResetsAt  *time.Time `json:"resets_at,omitempty"` // absent for the rolling hour
+}
+
+type LimitsResponse struct {
+	Tier           string         `json:"tier"`
+	KycStatus      string         `json:"kyc_status"` // until verified the unverified limits apply too
+	Currency       string         `json:"currency"`
+	PerTransaction int64          `json:"per_transaction"`
+	Daily          LimitAllowance `json:"daily"`
+	Monthly        LimitAllowance `json:"monthly"`
+	HourlyCount    LimitAllowance `json:"hourly_count"`
+}
+
+func newLimitAllowance(limit int64, used int64, resetsAt *time.Time) LimitAllowance {
+	allowance := LimitAllowance{Limit: limit, Used: used, ResetsAt: resetsAt}
+	if limit > 0 {
+		remaining := max(limit-used, 0)
+		allowance.Remaining = &remaining
+	}
+	return allowance
+}
+
+// GetLimits shows the caller's transfer limits in a currency and how much of
+// each they have left.
+func (server *Server) GetLimits(ctx *gin.Context) {
+	var req GetLimitsRequest
+
+	err := ctx.ShouldBindQuery(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	user, err := server.store.GetUser(ctx, authPayload.Username)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	now := time.Now()
+	usage, err := Anuskh.TransferUsage(ctx, server.store, user.Username, req.Currency, now)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	limit := Anuskh.TransferLimitFor(server.transferLimits, user, req.Currency)
+	_, day, month := util.LimitWindows(now)
+	nextDay, nextMonth := day.AddDate(0, 0, 1), month.AddDate(0, 1, 0)
+	ctx.JSON(http.StatusOK, LimitsResponse{
+		Tier:           user.Tier,
+		KycStatus:      user.KycStatus,
+		Currency:       req.Currency,
+		PerTransaction: limit.PerTransaction,
+		Daily:          newLimitAllowance(limit.Daily, usage.DailyAmount, &nextDay),
+		Monthly:        newLimitAllowance(limit.Monthly, usage.MonthlyAmount, &nextMonth),
+		HourlyCount:    newLimitAllowance(limit.HourlyCount, usage.HourlyCount, nil),
+	})
+}
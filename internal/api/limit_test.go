@@ -0,0 +1,142 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockDB "github.com/nilesh0729/Transactly/internal/db/Mock"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLimitsAPI(t *testing.T) {
+	_, user := RandomUser(t)
+	user.Tier = util.StandardTier
+	user.KycStatus = Anuskh.KycVerified
+	unverified := user
+	unverified.KycStatus = Anuskh.KycPending
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockDB.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "Ok",
+			query: "?currency=USD",
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().
+					SumTransfers(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(_ any, arg Anuskh.SumTransfersParams) (Anuskh.SumTransfersRow, error) {
+						require.Equal(t, user.Username, arg.Owner)
+						require.Equal(t, util.USD, arg.Currency)
+						return Anuskh.SumTransfersRow{DailyAmount: 4000, MonthlyAmount: 12000, HourlyCount: 3}, nil
+					})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var resp LimitsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+				require.Equal(t, util.StandardTier, resp.Tier)
+				require.Equal(t, int64(1000), resp.PerTransaction)
+
+				require.Equal(t, int64(4000), resp.Daily.Used)
+				require.Equal(t, int64(1000), *resp.Daily.Remaining)
+				require.True(t, resp.Daily.ResetsAt.After(time.Now()))
+				require.Equal(t, int64(0), *resp.Monthly.Remaining) // over it since the limit was lowered
+				require.Equal(t, int64(7), *resp.HourlyCount.Remaining)
+				require.Nil(t, resp.HourlyCount.ResetsAt)
+			},
+		},
+		{
+			name:  "NoLimitInCurrency",
+			query: "?currency=EUR",
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().SumTransfers(gomock.Any(), gomock.Any()).Times(1).Return(Anuskh.SumTransfersRow{DailyAmount: 50}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var resp LimitsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+				require.Equal(t, int64(0), resp.Daily.Limit)
+				require.Equal(t, int64(50), resp.Daily.Used)
+				require.Nil(t, resp.Daily.Remaining)
+			},
+		},
+		{
+			name:  "Unverified",
+			query: "?currency=USD",
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(unverified, nil)
+				store.EXPECT().SumTransfers(gomock.Any(), gomock.Any()).Times(1).Return(Anuskh.SumTransfersRow{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var resp LimitsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+				require.Equal(t, Anuskh.KycPending, resp.KycStatus)
+				require.Equal(t, int64(200), resp.PerTransaction)
+				require.Equal(t, int64(5000), resp.Daily.Limit)
+			},
+		},
+		{
+			name:  "DisabledCurrency",
+			query: "?currency=GBP",
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).Times(1).Return(user, nil)
+				store.EXPECT().SumTransfers(gomock.Any(), gomock.Any()).Times(1).Return(Anuskh.SumTransfersRow{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidCurrency",
+			query: "?currency=XYZ",
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.transferLimits = util.TransferLimits{
+				"standard/USD": {PerTransaction: 1000, Daily: 5000, Monthly: 10000, HourlyCount: 10},
+				"unverified/*": {PerTransaction: 200},
+			}
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/limits"+tc.query, nil)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nilesh0729/Transactly/internal/logging"
+	"github.com/nilesh0729/Transactly/internal/token"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestIDMiddleware(t *testing.T) {
+	var seen string
+	router := gin.New()
+	router.Use(requestIDMiddleware())
+	router.GET("/", func(ctx *gin.Context) {
+		seen = logging.RequestIDFromContext(ctx.Request.Context())
+	})
+
+	testCases := []struct {
+		name   string
+		header string
+		check  func(t *testing.T, requestID string)
+	}{
+		{
+			name:   "Reused",
+			header: "abc-123",
+			check: func(t *testing.T, requestID string) {
+				require.Equal(t, "abc-123", requestID)
+			},
+		},
+		{
+			name:   "Generated",
+			header: "",
+			check: func(t *testing.T, requestID string) {
+				require.Len(t, requestID, 32)
+			},
+		},
+		{
+			name:   "Invalid",
+			header: "bad id\nwith newline",
+			check: func(t *testing.T, requestID string) {
+				require.Len(t, requestID, 32)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, err)
+			request.Header.Set(requestIDHeaderKey, tc.header)
+
+			router.ServeHTTP(recorder, request)
+			tc.check(t, seen)
+			require.Equal(t, seen, recorder.Header().Get(requestIDHeaderKey))
+		})
+	}
+}
+
+func TestAccessLogMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := logging.New(&buf, "json", "")
+	require.NoError(t, err)
+
+	previous := slog.Default()
+	slog.SetDefault(logger)
+	defer slog.SetDefault(previous)
+
+	router := gin.New()
+	router.Use(requestIDMiddleware(), accessLogMiddleware())
+	router.GET("/accounts/:id", func(ctx *gin.Context) {
+		ctx.Set(authorizationPayloadKey, &token.Payload{Username: "alice"})
+		ctx.Status(http.StatusNotFound)
+	})
+
+	request, err := http.NewRequest(http.MethodGet, "/accounts/7?access_token=secret", nil)
+	require.NoError(t, err)
+	request.Header.Set(requestIDHeaderKey, "req-9")
+	router.ServeHTTP(httptest.NewRecorder(), request)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	require.Equal(t, "WARN", record["level"])
+	require.Equal(t, "req-9", record["request_id"])
+	require.Equal(t, "alice", record["user"])
+	require.Equal(t, "/accounts/:id", record["route"])
+	require.Equal(t, "/accounts/7", record["path"])
+	require.EqualValues(t, http.StatusNotFound, record["status"])
+	require.Contains(t, record, "latency_ms")
+	require.NotContains(t, buf.String(), "secret")
+}
@@ -1,20 +1,38 @@
 package api
 
 import (
+	"io"
+	"log/slog"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockDB "github.com/nilesh0729/Transactly/internal/db/Mock"
 	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
 	"github.com/nilesh0729/Transactly/internal/util"
 	"github.com/stretchr/testify/require"
 )
 
 func newTestServer(t *testing.T, store Anuskh.Store) *Server {
+	// Every state-changing request is audited; tests that care about the
+	// events build their own expectations on a server without this stub.
+	if mockStore, ok := store.(*mockDB.MockStore); ok {
+		mockStore.EXPECT().
+			AppendAuditEvent(gomock.Any(), gomock.Any()).
+			AnyTimes()
+	}
+
 	config := util.Config{
 		TokenSymmetricKey:   util.RandomString(32),
 		AccessTokenDuration: time.Minute,
+		MetricsAllowedIPs:   []string{"127.0.0.1"},
+		PayeeCoolingOff:     time.Hour,
+		PayeeCoolingLimit:   100,
+		RateLimitPayeeCheck: "3/1h",
+		KycMaxDocumentSize:  1 << 20,
+		Currencies:          util.DefaultCurrencies,
 	}
 
 	server, err := NewServer(store, config)
@@ -25,6 +43,7 @@ func newTestServer(t *testing.T, store Anuskh.Store) *Server {
 }
 func TestMain(m *testing.M) {
 	gin.SetMode(gin.TestMode)
+	slog.SetDefault(slog.New(slog.NewTextHandler(io.Discard, nil)))
 
 	os.Exit(m.Run())
 
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nilesh0729/Transactly/internal/metrics"
+)
+
+// metricsMiddleware records request latency by route template rather than raw
+// path, so account IDs don't blow up the number of series.
+func metricsMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.HTTPRequestDuration.
+			WithLabelValues(ctx.Request.Method, route, strconv.Itoa(ctx.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
+
+// metricsAllowlist keeps /metrics to the scrapers in allowed, since the
+// series reveal transfer volumes and failed logins. The client IP only comes
+// from X-Forwarded-For when the request passed through a trusted proxy.
+func metricsAllowlist(allowed []string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !ipAllowed(allowed, ctx.ClientIP()) {
+			err := fmt.Errorf("client ip %s may not read metrics", ctx.ClientIP())
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,58 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockDB "github.com/nilesh0729/Transactly/internal/db/Mock"
+	"github.com/nilesh0729/Transactly/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricsAPI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	password, user := RandomUser(t)
+	store := mockDB.NewMockStore(ctrl)
+	store.EXPECT().GetUser(gomock.Any(), gomock.Eq(user.Username)).AnyTimes().Return(user, nil)
+
+	server := newTestServer(t, store)
+	failedLogins := testutil.ToFloat64(metrics.FailedLogins.WithLabelValues("wrong_password"))
+
+	for _, pw := range []string{password, "wrong-" + password} {
+		body, err := json.Marshal(gin.H{"username": user.Username, "password": pw})
+		require.NoError(t, err)
+
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodPost, "/user/login", bytes.NewReader(body))
+		require.NoError(t, err)
+		server.router.ServeHTTP(recorder, request)
+	}
+	require.Equal(t, failedLogins+1, testutil.ToFloat64(metrics.FailedLogins.WithLabelValues("wrong_password")))
+
+	// only allowed scrapers can read the metrics
+	recorder := httptest.NewRecorder()
+	request, err := http.NewRequest(http.MethodGet, "/metrics", nil)
+	require.NoError(t, err)
+	request.RemoteAddr = "203.0.113.7:40000"
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusForbidden, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	request.RemoteAddr = "127.0.0.1:40000"
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	data, err := io.ReadAll(recorder.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(data), `transactly_http_request_duration_seconds_count{method="POST",route="/user/login",status="200"}`)
+	require.Contains(t, string(data), `transactly_http_request_duration_seconds_count{method="POST",route="/user/login",status="401"}`)
+}
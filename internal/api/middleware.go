@@ -1,22 +1,36 @@
 package api
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
 	"github.com/nilesh0729/Transactly/internal/token"
 )
 
 const (
 	authorizationHeaderKey  = "authorization"         //for header matching
 	authorizationTypeBearer = "bearer"                // for checking token type
+	authorizationTypeApiKey = "apikey"                // machine clients authenticating with an API key
 	authorizationPayloadKey = "authorization_payload" //used as key for ctx.set(key,TheValueYouWantToStore) to store payload
 )
 
-func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
+// Scopes restrict what API keys and OAuth tokens may do. User sessions carry no
+// scopes and are allowed everything, see token.Payload.HasScope.
+const (
+	scopeAccountsRead   = "accounts:read"
+	scopeAccountsWrite  = "accounts:write"
+	scopeTransfersRead  = "transfers:read"
+	scopeTransfersWrite = "transfers:write"
+	scopeUserSession    = "user:session" // never granted to API keys or OAuth clients, only logged-in users have it
+)
+
+func authMiddleware(tokenMaker token.Maker, store Anuskh.Store) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
 		if len(authorizationHeader) == 0 {
@@ -34,15 +48,29 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 
 		authorizationType := strings.ToLower(fields[0])
 
-		if authorizationType != authorizationTypeBearer {
+		var payload *token.Payload
+		var err error
+		switch authorizationType {
+		case authorizationTypeBearer:
+			payload, err = tokenMaker.VerifyToken(fields[1])
+			if err == nil {
+				err = verifyOauthToken(ctx, store, payload)
+				if err != nil && !errors.Is(err, errRevokedToken) {
+					ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+					return
+				}
+			}
+		case authorizationTypeApiKey:
+			payload, err = verifyApiKey(ctx, store, fields[1])
+			if err != nil && !errors.Is(err, errInvalidApiKey) {
+				ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+				return
+			}
+		default:
 			err := fmt.Errorf("unsupported authorization type %s", authorizationType)
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
 			return
 		}
-
-		accessToken := fields[1]
-
-		payload, err := tokenMaker.VerifyToken(accessToken)
 		if err != nil {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
 			return
@@ -52,3 +80,40 @@ func authMiddleware(tokenMaker token.Maker) gin.HandlerFunc {
 		ctx.Next()
 	}
 }
+
+// requireScope rejects requests whose payload does not grant scope. It must run after authMiddleware.
+func requireScope(scope string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		if !authPayload.HasScope(scope) {
+			err := fmt.Errorf("missing required scope %s", scope)
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.Next()
+	}
+}
+
+// requireRole only lets through users holding one of roles. The role is read
+// from the database on every request so that taking it away works immediately.
+func requireRole(store Anuskh.Store, roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		user, err := store.GetUser(ctx, authPayload.Username)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+				return
+			}
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+
+		if !slices.Contains(roles, user.Role) {
+			err := fmt.Errorf("role %s is not allowed to access this resource", user.Role)
+			ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.Next()
+	}
+}
@@ -0,0 +1,563 @@
+package api
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/token"
+	"github.com/nilesh0729/Transactly/internal/util"
+)
+
+const (
+	oauthCodeDuration     = 10 * time.Minute
+	oauthGrantAuthCode    = "authorization_code"
+	oauthGrantClientCreds = "client_credentials"
+)
+
+// Error codes from RFC 6749 section 5.2.
+const (
+	oauthErrInvalidRequest     = "invalid_request"
+	oauthErrInvalidClient      = "invalid_client"
+	oauthErrInvalidGrant       = "invalid_grant"
+	oauthErrUnauthorizedClient = "unauthorized_client"
+	oauthErrInvalidScope       = "invalid_scope"
+	oauthErrAccessDenied       = "access_denied"
+	oauthErrServerError        = "server_error"
+)
+
+var (
+	errInvalidOauthClient = errors.New("client authentication failed")
+	errRevokedToken       = errors.New("token has been revoked")
+)
+
+func oauthErrorResponse(code string, err error) gin.H {
+	return gin.H{"error": code, "error_description": err.Error()}
+}
+
+type RegisterOauthClientRequest struct {
+	Name         string   `json:"name" binding:"required,max=64"`
+	RedirectURIs []string `json:"redirect_uris" binding:"required,min=1,dive,url"`
+	Scopes       []string `json:"scopes" binding:"required,min=1,dive,oneof=accounts:read transfers:read"`
+	Confidential bool     `json:"confidential"` // confidential clients get a secret and may use client_credentials
+}
+
+type OauthClientResponse struct {
+	ClientID     string    `json:"client_id"`
+	ClientSecret string    `json:"client_secret,omitempty"` // only ever shown once
+	Name         string    `json:"name"`
+	RedirectURIs []string  `json:"redirect_uris"`
+	Scopes       []string  `json:"scopes"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func (server *Server) RegisterOauthClient(ctx *gin.Context) {
+	var req RegisterOauthClientRequest
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	clientID, err := util.RandomHex(16)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	var secret, hashedSecret string
+	if req.Confidential {
+		secret, err = util.RandomHex(32)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		hashedSecret = util.HashSecret(secret)
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	client, err := server.store.CreateOauthClient(ctx, Anuskh.CreateOauthClientParams{
+		ID:           clientID,
+		Owner:        authPayload.Username,
+		Name:         req.Name,
+		HashedSecret: hashedSecret,
+		RedirectUris: req.RedirectURIs,
+		Scopes:       req.Scopes,
+	})
+	if err != nil {
+		switch Anuskh.ErrorCode(err) {
+		case Anuskh.ForeignKeyViolation, Anuskh.UniqueViolation:
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, OauthClientResponse{
+		ClientID:     client.ID,
+		ClientSecret: secret,
+		Name:         client.Name,
+		RedirectURIs: client.RedirectUris,
+		Scopes:       client.Scopes,
+		CreatedAt:    client.CreatedAt,
+	})
+}
+
+type OauthAuthorizeRequest struct {
+	ResponseType        string `form:"response_type" json:"response_type" binding:"required,eq=code"`
+	ClientID            string `form:"client_id" json:"client_id" binding:"required"`
+	RedirectURI         string `form:"redirect_uri" json:"redirect_uri" binding:"required,url"`
+	Scope               string `form:"scope" json:"scope" binding:"required"`
+	State               string `form:"state" json:"state"`
+	CodeChallenge       string `form:"code_challenge" json:"code_challenge" binding:"required,len=43"`
+	CodeChallengeMethod string `form:"code_challenge_method" json:"code_challenge_method" binding:"required,eq=S256"`
+}
+
+type OauthConsentResponse struct {
+	ClientID   string   `json:"client_id"`
+	ClientName string   `json:"client_name"`
+	Scopes     []string `json:"scopes"`
+	Consented  bool     `json:"consented"` // the user already approved these scopes for this client
+}
+
+// GetOauthAuthorize validates an authorization request and describes it so the
+// frontend can render a consent screen.
+func (server *Server) GetOauthAuthorize(ctx *gin.Context) {
+	var req OauthAuthorizeRequest
+
+	err := ctx.ShouldBindQuery(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, oauthErrorResponse(oauthErrInvalidRequest, err))
+		return
+	}
+
+	client, scopes, ok := server.validateOauthAuthorizeRequest(ctx, req)
+	if !ok {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	consent, err := server.store.GetOauthConsent(ctx, Anuskh.GetOauthConsentParams{
+		Username: authPayload.Username,
+		ClientID: client.ID,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		ctx.JSON(http.StatusInternalServerError, oauthErrorResponse(oauthErrServerError, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, OauthConsentResponse{
+		ClientID:   client.ID,
+		ClientName: client.Name,
+		Scopes:     scopes,
+		Consented:  err == nil && scopesAllowed(scopes, consent.Scopes),
+	})
+}
+
+type OauthApproveRequest struct {
+	OauthAuthorizeRequest
+	Approve bool `json:"approve"`
+}
+
+type OauthApproveResponse struct {
+	RedirectURI string `json:"redirect_uri"`
+}
+
+// ApproveOauthAuthorize records the user's decision and returns where the
+// frontend should send the browser next, carrying either a code or an error.
+func (server *Server) ApproveOauthAuthorize(ctx *gin.Context) {
+	var req OauthApproveRequest
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, oauthErrorResponse(oauthErrInvalidRequest, err))
+		return
+	}
+
+	client, scopes, ok := server.validateOauthAuthorizeRequest(ctx, req.OauthAuthorizeRequest)
+	if !ok {
+		return
+	}
+
+	if !req.Approve {
+		ctx.JSON(http.StatusOK, OauthApproveResponse{
+			RedirectURI: buildRedirectURI(req.RedirectURI, map[string]string{"error": oauthErrAccessDenied, "state": req.State}),
+		})
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	_, err = server.store.UpsertOauthConsent(ctx, Anuskh.UpsertOauthConsentParams{
+		Username: authPayload.Username,
+		ClientID: client.ID,
+		Scopes:   scopes,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, oauthErrorResponse(oauthErrServerError, err))
+		return
+	}
+
+	code, err := util.RandomHex(32)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, oauthErrorResponse(oauthErrServerError, err))
+		return
+	}
+
+	_, err = server.store.CreateOauthAuthorizationCode(ctx, Anuskh.CreateOauthAuthorizationCodeParams{
+		CodeHash:      util.HashSecret(code),
+		ClientID:      client.ID,
+		Username:      authPayload.Username,
+		RedirectUri:   req.RedirectURI,
+		Scopes:        scopes,
+		CodeChallenge: req.CodeChallenge,
+		ExpiresAt:     time.Now().Add(oauthCodeDuration),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, oauthErrorResponse(oauthErrServerError, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, OauthApproveResponse{
+		RedirectURI: buildRedirectURI(req.RedirectURI, map[string]string{"code": code, "state": req.State}),
+	})
+}
+
+func (server *Server) validateOauthAuthorizeRequest(ctx *gin.Context, req OauthAuthorizeRequest) (Anuskh.OauthClient, []string, bool) {
+	client, err := server.store.GetOauthClient(ctx, req.ClientID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusBadRequest, oauthErrorResponse(oauthErrInvalidClient, errors.New("unknown client")))
+			return client, nil, false
+		}
+		ctx.JSON(http.StatusInternalServerError, oauthErrorResponse(oauthErrServerError, err))
+		return client, nil, false
+	}
+
+	// Never redirect to an unregistered URI, that would leak the code to whoever asked.
+	if !slices.Contains(client.RedirectUris, req.RedirectURI) {
+		err := fmt.Errorf("redirect_uri %s is not registered for this client", req.RedirectURI)
+		ctx.JSON(http.StatusBadRequest, oauthErrorResponse(oauthErrInvalidRequest, err))
+		return client, nil, false
+	}
+
+	scopes := strings.Fields(req.Scope)
+	if !scopesAllowed(scopes, client.Scopes) {
+		err := fmt.Errorf("client may only request scopes %s", strings.Join(client.Scopes, " "))
+		ctx.JSON(http.StatusBadRequest, oauthErrorResponse(oauthErrInvalidScope, err))
+		return client, nil, false
+	}
+
+	return client, scopes, true
+}
+
+type OauthTokenRequest struct {
+	GrantType    string `form:"grant_type" binding:"required,oneof=authorization_code client_credentials"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier"`
+	Scope        string `form:"scope"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+}
+
+type OauthTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	Scope       string `json:"scope"`
+}
+
+func (server *Server) OauthToken(ctx *gin.Context) {
+	var req OauthTokenRequest
+
+	err := ctx.ShouldBind(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, oauthErrorResponse(oauthErrInvalidRequest, err))
+		return
+	}
+
+	client, ok := server.authenticateOauthClient(ctx, req.ClientID, req.ClientSecret)
+	if !ok {
+		return
+	}
+
+	switch req.GrantType {
+	case oauthGrantAuthCode:
+		server.exchangeAuthorizationCode(ctx, client, req)
+	case oauthGrantClientCreds:
+		server.exchangeClientCredentials(ctx, client, req)
+	}
+}
+
+func (server *Server) exchangeAuthorizationCode(ctx *gin.Context, client Anuskh.OauthClient, req OauthTokenRequest) {
+	if req.Code == "" || req.CodeVerifier == "" {
+		err := errors.New("code and code_verifier are required")
+		ctx.JSON(http.StatusBadRequest, oauthErrorResponse(oauthErrInvalidRequest, err))
+		return
+	}
+
+	// Consuming deletes the code, so it can never be replayed even if a check below fails.
+	code, err := server.store.ConsumeOauthAuthorizationCode(ctx, util.HashSecret(req.Code))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusBadRequest, oauthErrorResponse(oauthErrInvalidGrant, errors.New("unknown or used code")))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, oauthErrorResponse(oauthErrServerError, err))
+		return
+	}
+
+	switch {
+	case code.ClientID != client.ID:
+		err = errors.New("code was issued to another client")
+	case code.RedirectUri != req.RedirectURI:
+		err = errors.New("redirect_uri does not match the authorization request")
+	case time.Now().After(code.ExpiresAt):
+		err = errors.New("code has expired")
+	case !verifyCodeChallenge(req.CodeVerifier, code.CodeChallenge):
+		err = errors.New("code_verifier does not match code_challenge")
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, oauthErrorResponse(oauthErrInvalidGrant, err))
+		return
+	}
+
+	server.issueOauthToken(ctx, code.Username, client.ID, code.Scopes)
+}
+
+func (server *Server) exchangeClientCredentials(ctx *gin.Context, client Anuskh.OauthClient, req OauthTokenRequest) {
+	if client.HashedSecret == "" {
+		err := errors.New("public clients cannot use client_credentials")
+		ctx.JSON(http.StatusBadRequest, oauthErrorResponse(oauthErrUnauthorizedClient, err))
+		return
+	}
+
+	scopes := client.Scopes
+	if req.Scope != "" {
+		scopes = strings.Fields(req.Scope)
+		if !scopesAllowed(scopes, client.Scopes) {
+			err := fmt.Errorf("client may only request scopes %s", strings.Join(client.Scopes, " "))
+			ctx.JSON(http.StatusBadRequest, oauthErrorResponse(oauthErrInvalidScope, err))
+			return
+		}
+	}
+
+	// A client acting for itself gets access to the data of the user who registered it.
+	server.issueOauthToken(ctx, client.Owner, client.ID, scopes)
+}
+
+func (server *Server) issueOauthToken(ctx *gin.Context, username string, clientID string, scopes []string) {
+	accessToken, payload, err := server.tokenMaker.CreateScopedToken(username, clientID, scopes, server.config.AccessTokenDuration)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, oauthErrorResponse(oauthErrServerError, err))
+		return
+	}
+
+	_, err = server.store.CreateOauthToken(ctx, Anuskh.CreateOauthTokenParams{
+		ID:        payload.ID,
+		ClientID:  clientID,
+		Username:  username,
+		Scopes:    scopes,
+		ExpiresAt: payload.ExpiresAt.Time,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, oauthErrorResponse(oauthErrServerError, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, OauthTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(server.config.AccessTokenDuration.Seconds()),
+		Scope:       strings.Join(scopes, " "),
+	})
+}
+
+type OauthTokenActionRequest struct {
+	Token        string `form:"token" binding:"required"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+}
+
+type OauthIntrospectResponse struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Username  string `json:"username,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+}
+
+// IntrospectOauthToken implements RFC 7662. Clients may only introspect tokens
+// issued to themselves; anything else is reported as inactive.
+func (server *Server) IntrospectOauthToken(ctx *gin.Context) {
+	var req OauthTokenActionRequest
+
+	err := ctx.ShouldBind(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, oauthErrorResponse(oauthErrInvalidRequest, err))
+		return
+	}
+
+	client, ok := server.authenticateOauthClient(ctx, req.ClientID, req.ClientSecret)
+	if !ok {
+		return
+	}
+
+	payload, err := server.tokenMaker.VerifyToken(req.Token)
+	if err != nil || payload.ClientID != client.ID {
+		ctx.JSON(http.StatusOK, OauthIntrospectResponse{Active: false})
+		return
+	}
+
+	err = verifyOauthToken(ctx, server.store, payload)
+	if err != nil {
+		if errors.Is(err, errRevokedToken) {
+			ctx.JSON(http.StatusOK, OauthIntrospectResponse{Active: false})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, oauthErrorResponse(oauthErrServerError, err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, OauthIntrospectResponse{
+		Active:    true,
+		Scope:     strings.Join(payload.Scopes, " "),
+		ClientID:  payload.ClientID,
+		Username:  payload.Username,
+		ExpiresAt: payload.ExpiresAt.Unix(),
+	})
+}
+
+// RevokeOauthToken implements RFC 7009. Unknown or foreign tokens are ignored
+// so the response never reveals whether a token exists.
+func (server *Server) RevokeOauthToken(ctx *gin.Context) {
+	var req OauthTokenActionRequest
+
+	err := ctx.ShouldBind(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, oauthErrorResponse(oauthErrInvalidRequest, err))
+		return
+	}
+
+	client, ok := server.authenticateOauthClient(ctx, req.ClientID, req.ClientSecret)
+	if !ok {
+		return
+	}
+
+	payload, err := server.tokenMaker.VerifyToken(req.Token)
+	if err == nil && payload.ClientID == client.ID {
+		err = server.store.RevokeOauthToken(ctx, payload.ID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, oauthErrorResponse(oauthErrServerError, err))
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+// authenticateOauthClient accepts credentials through HTTP Basic or the request
+// body. Public clients have no secret and must not send one.
+func (server *Server) authenticateOauthClient(ctx *gin.Context, clientID string, clientSecret string) (Anuskh.OauthClient, bool) {
+	if id, secret, ok := ctx.Request.BasicAuth(); ok {
+		clientID, clientSecret = id, secret
+	}
+	if clientID == "" {
+		ctx.JSON(http.StatusUnauthorized, oauthErrorResponse(oauthErrInvalidClient, errInvalidOauthClient))
+		return Anuskh.OauthClient{}, false
+	}
+
+	client, err := server.store.GetOauthClient(ctx, clientID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusUnauthorized, oauthErrorResponse(oauthErrInvalidClient, errInvalidOauthClient))
+			return client, false
+		}
+		ctx.JSON(http.StatusInternalServerError, oauthErrorResponse(oauthErrServerError, err))
+		return client, false
+	}
+
+	if client.HashedSecret == "" {
+		if clientSecret != "" {
+			ctx.JSON(http.StatusUnauthorized, oauthErrorResponse(oauthErrInvalidClient, errInvalidOauthClient))
+			return client, false
+		}
+		return client, true
+	}
+
+	if err := util.CheckSecret(clientSecret, client.HashedSecret); err != nil {
+		ctx.JSON(http.StatusUnauthorized, oauthErrorResponse(oauthErrInvalidClient, errInvalidOauthClient))
+		return client, false
+	}
+	return client, true
+}
+
+// verifyOauthToken checks that a token issued to an OAuth client has not been
+// revoked since. Tokens without a client are user sessions and pass through.
+func verifyOauthToken(ctx *gin.Context, store Anuskh.Store, payload *token.Payload) error {
+	if payload.ClientID == "" {
+		return nil
+	}
+
+	record, err := store.GetOauthToken(ctx, payload.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return errRevokedToken
+		}
+		return err
+	}
+	if record.RevokedAt.Valid {
+		return errRevokedToken
+	}
+	return nil
+}
+
+// verifyCodeChallenge checks a PKCE verifier against an S256 challenge (RFC 7636).
+func verifyCodeChallenge(verifier string, challenge string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	computed := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(computed), []byte(challenge)) == 1
+}
+
+func buildRedirectURI(redirectURI string, params map[string]string) string {
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		return redirectURI
+	}
+	q := u.Query()
+	for k, v := range params {
+		if v != "" {
+			q.Set(k, v)
+		}
+	}
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// scopesAllowed reports whether every requested scope is in allowed.
+func scopesAllowed(requested []string, allowed []string) bool {
+	if len(requested) == 0 {
+		return false
+	}
+	for _, scope := range requested {
+		if !slices.Contains(allowed, scope) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,549 @@
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockDB "github.com/nilesh0729/Transactly/internal/db/Mock"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/token"
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomOauthClient(t *testing.T, owner string, confidential bool) (string, Anuskh.OauthClient) {
+	client := Anuskh.OauthClient{
+		ID:           util.RandomString(16),
+		Owner:        owner,
+		Name:         util.RandomOwner(),
+		RedirectUris: []string{"https://budget.example.com/callback"},
+		Scopes:       []string{scopeAccountsRead, scopeTransfersRead},
+	}
+
+	var secret string
+	if confidential {
+		secret = util.RandomString(32)
+		client.HashedSecret = util.HashSecret(secret)
+	}
+	return secret, client
+}
+
+func randomPKCE() (verifier string, challenge string) {
+	verifier = util.RandomString(64)
+	sum := sha256.Sum256([]byte(verifier))
+	return verifier, base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func TestOauthTokenAPI(t *testing.T) {
+	_, user := RandomUser(t)
+	_, publicClient := randomOauthClient(t, user.Username, false)
+	secret, confidentialClient := randomOauthClient(t, user.Username, true)
+	verifier, challenge := randomPKCE()
+	code := util.RandomString(32)
+
+	authCode := Anuskh.OauthAuthorizationCode{
+		CodeHash:      util.HashSecret(code),
+		ClientID:      publicClient.ID,
+		Username:      user.Username,
+		RedirectUri:   publicClient.RedirectUris[0],
+		Scopes:        []string{scopeTransfersRead},
+		CodeChallenge: challenge,
+		ExpiresAt:     time.Now().Add(time.Minute),
+	}
+
+	testCases := []struct {
+		name          string
+		form          url.Values
+		setAuth       func(request *http.Request)
+		buildStubs    func(store *mockDB.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker)
+	}{
+		{
+			name: "AuthorizationCodeOK",
+			form: url.Values{
+				"grant_type":    {oauthGrantAuthCode},
+				"client_id":     {publicClient.ID},
+				"code":          {code},
+				"redirect_uri":  {publicClient.RedirectUris[0]},
+				"code_verifier": {verifier},
+			},
+			setAuth: func(request *http.Request) {},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					GetOauthClient(gomock.Any(), gomock.Eq(publicClient.ID)).
+					Times(1).
+					Return(publicClient, nil)
+				store.EXPECT().
+					ConsumeOauthAuthorizationCode(gomock.Any(), gomock.Eq(authCode.CodeHash)).
+					Times(1).
+					Return(authCode, nil)
+				store.EXPECT().
+					CreateOauthToken(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(Anuskh.OauthToken{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got OauthTokenResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, scopeTransfersRead, got.Scope)
+
+				payload, err := tokenMaker.VerifyToken(got.AccessToken)
+				require.NoError(t, err)
+				require.Equal(t, user.Username, payload.Username)
+				require.Equal(t, publicClient.ID, payload.ClientID)
+				require.False(t, payload.HasScope(scopeTransfersWrite))
+			},
+		},
+		{
+			name: "WrongCodeVerifier",
+			form: url.Values{
+				"grant_type":    {oauthGrantAuthCode},
+				"client_id":     {publicClient.ID},
+				"code":          {code},
+				"redirect_uri":  {publicClient.RedirectUris[0]},
+				"code_verifier": {util.RandomString(64)},
+			},
+			setAuth: func(request *http.Request) {},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					GetOauthClient(gomock.Any(), gomock.Eq(publicClient.ID)).
+					Times(1).
+					Return(publicClient, nil)
+				store.EXPECT().
+					ConsumeOauthAuthorizationCode(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(authCode, nil)
+				store.EXPECT().
+					CreateOauthToken(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), oauthErrInvalidGrant)
+			},
+		},
+		{
+			name: "ExpiredCode",
+			form: url.Values{
+				"grant_type":    {oauthGrantAuthCode},
+				"client_id":     {publicClient.ID},
+				"code":          {code},
+				"redirect_uri":  {publicClient.RedirectUris[0]},
+				"code_verifier": {verifier},
+			},
+			setAuth: func(request *http.Request) {},
+			buildStubs: func(store *mockDB.MockStore) {
+				expired := authCode
+				expired.ExpiresAt = time.Now().Add(-time.Minute)
+
+				store.EXPECT().
+					GetOauthClient(gomock.Any(), gomock.Eq(publicClient.ID)).
+					Times(1).
+					Return(publicClient, nil)
+				store.EXPECT().
+					ConsumeOauthAuthorizationCode(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(expired, nil)
+				store.EXPECT().
+					CreateOauthToken(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UsedCode",
+			form: url.Values{
+				"grant_type":    {oauthGrantAuthCode},
+				"client_id":     {publicClient.ID},
+				"code":          {code},
+				"redirect_uri":  {publicClient.RedirectUris[0]},
+				"code_verifier": {verifier},
+			},
+			setAuth: func(request *http.Request) {},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					GetOauthClient(gomock.Any(), gomock.Eq(publicClient.ID)).
+					Times(1).
+					Return(publicClient, nil)
+				store.EXPECT().
+					ConsumeOauthAuthorizationCode(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(Anuskh.OauthAuthorizationCode{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), oauthErrInvalidGrant)
+			},
+		},
+		{
+			name: "ClientCredentialsOK",
+			form: url.Values{
+				"grant_type": {oauthGrantClientCreds},
+				"scope":      {scopeAccountsRead},
+			},
+			setAuth: func(request *http.Request) {
+				request.SetBasicAuth(confidentialClient.ID, secret)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					GetOauthClient(gomock.Any(), gomock.Eq(confidentialClient.ID)).
+					Times(1).
+					Return(confidentialClient, nil)
+				store.EXPECT().
+					CreateOauthToken(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(Anuskh.OauthToken{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got OauthTokenResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+
+				payload, err := tokenMaker.VerifyToken(got.AccessToken)
+				require.NoError(t, err)
+				require.Equal(t, confidentialClient.Owner, payload.Username)
+				require.Equal(t, []string{scopeAccountsRead}, payload.Scopes)
+			},
+		},
+		{
+			name: "ClientCredentialsWrongSecret",
+			form: url.Values{
+				"grant_type": {oauthGrantClientCreds},
+			},
+			setAuth: func(request *http.Request) {
+				request.SetBasicAuth(confidentialClient.ID, util.RandomString(32))
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					GetOauthClient(gomock.Any(), gomock.Eq(confidentialClient.ID)).
+					Times(1).
+					Return(confidentialClient, nil)
+				store.EXPECT().
+					CreateOauthToken(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+				require.Contains(t, recorder.Body.String(), oauthErrInvalidClient)
+			},
+		},
+		{
+			name: "ClientCredentialsPublicClient",
+			form: url.Values{
+				"grant_type": {oauthGrantClientCreds},
+				"client_id":  {publicClient.ID},
+			},
+			setAuth: func(request *http.Request) {},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					GetOauthClient(gomock.Any(), gomock.Eq(publicClient.ID)).
+					Times(1).
+					Return(publicClient, nil)
+				store.EXPECT().
+					CreateOauthToken(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), oauthErrUnauthorizedClient)
+			},
+		},
+		{
+			name: "UnsupportedGrantType",
+			form: url.Values{
+				"grant_type": {"password"},
+				"client_id":  {publicClient.ID},
+			},
+			setAuth: func(request *http.Request) {},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					GetOauthClient(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder, tokenMaker token.Maker) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(tc.form.Encode()))
+			require.NoError(t, err)
+			request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			tc.setAuth(request)
+			server.router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(t, recorder, server.tokenMaker)
+		})
+	}
+}
+
+func TestApproveOauthAuthorizeAPI(t *testing.T) {
+	_, user := RandomUser(t)
+	_, client := randomOauthClient(t, util.RandomOwner(), false)
+	_, challenge := randomPKCE()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockDB.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{
+				"response_type":         "code",
+				"client_id":             client.ID,
+				"redirect_uri":          client.RedirectUris[0],
+				"scope":                 scopeTransfersRead,
+				"state":                 "xyz",
+				"code_challenge":        challenge,
+				"code_challenge_method": "S256",
+				"approve":               true,
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					GetOauthClient(gomock.Any(), gomock.Eq(client.ID)).
+					Times(1).
+					Return(client, nil)
+				store.EXPECT().
+					UpsertOauthConsent(gomock.Any(), gomock.Eq(Anuskh.UpsertOauthConsentParams{
+						Username: user.Username,
+						ClientID: client.ID,
+						Scopes:   []string{scopeTransfersRead},
+					})).
+					Times(1)
+				store.EXPECT().
+					CreateOauthAuthorizationCode(gomock.Any(), gomock.Any()).
+					Times(1)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got OauthApproveResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+
+				redirect, err := url.Parse(got.RedirectURI)
+				require.NoError(t, err)
+				require.Equal(t, "budget.example.com", redirect.Host)
+				require.NotEmpty(t, redirect.Query().Get("code"))
+				require.Equal(t, "xyz", redirect.Query().Get("state"))
+			},
+		},
+		{
+			name: "Denied",
+			body: gin.H{
+				"response_type":         "code",
+				"client_id":             client.ID,
+				"redirect_uri":          client.RedirectUris[0],
+				"scope":                 scopeTransfersRead,
+				"code_challenge":        challenge,
+				"code_challenge_method": "S256",
+				"approve":               false,
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					GetOauthClient(gomock.Any(), gomock.Eq(client.ID)).
+					Times(1).
+					Return(client, nil)
+				store.EXPECT().
+					CreateOauthAuthorizationCode(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Contains(t, recorder.Body.String(), oauthErrAccessDenied)
+			},
+		},
+		{
+			name: "UnregisteredRedirectURI",
+			body: gin.H{
+				"response_type":         "code",
+				"client_id":             client.ID,
+				"redirect_uri":          "https://evil.example.com/callback",
+				"scope":                 scopeTransfersRead,
+				"code_challenge":        challenge,
+				"code_challenge_method": "S256",
+				"approve":               true,
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					GetOauthClient(gomock.Any(), gomock.Eq(client.ID)).
+					Times(1).
+					Return(client, nil)
+				store.EXPECT().
+					CreateOauthAuthorizationCode(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ScopeNotAllowed",
+			body: gin.H{
+				"response_type":         "code",
+				"client_id":             client.ID,
+				"redirect_uri":          client.RedirectUris[0],
+				"scope":                 scopeTransfersWrite,
+				"code_challenge":        challenge,
+				"code_challenge_method": "S256",
+				"approve":               true,
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					GetOauthClient(gomock.Any(), gomock.Eq(client.ID)).
+					Times(1).
+					Return(client, nil)
+				store.EXPECT().
+					CreateOauthAuthorizationCode(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), oauthErrInvalidScope)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/oauth/authorize", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestOauthBearerToken(t *testing.T) {
+	_, user := RandomUser(t)
+	_, client := randomOauthClient(t, user.Username, false)
+
+	testCases := []struct {
+		name          string
+		scope         string
+		buildStubs    func(store *mockDB.MockStore, payload *token.Payload)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			scope: scopeTransfersRead,
+			buildStubs: func(store *mockDB.MockStore, payload *token.Payload) {
+				store.EXPECT().
+					GetOauthToken(gomock.Any(), gomock.Eq(payload.ID)).
+					Times(1).
+					Return(Anuskh.OauthToken{ID: payload.ID}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "Revoked",
+			scope: scopeTransfersRead,
+			buildStubs: func(store *mockDB.MockStore, payload *token.Payload) {
+				store.EXPECT().
+					GetOauthToken(gomock.Any(), gomock.Eq(payload.ID)).
+					Times(1).
+					Return(Anuskh.OauthToken{ID: payload.ID, RevokedAt: sql.NullTime{Time: time.Now(), Valid: true}}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:  "ScopeNotGranted",
+			scope: scopeTransfersWrite,
+			buildStubs: func(store *mockDB.MockStore, payload *token.Payload) {
+				store.EXPECT().
+					GetOauthToken(gomock.Any(), gomock.Eq(payload.ID)).
+					Times(1).
+					Return(Anuskh.OauthToken{ID: payload.ID}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			server := newTestServer(t, store)
+
+			accessToken, payload, err := server.tokenMaker.CreateScopedToken(user.Username, client.ID, []string{scopeTransfersRead}, time.Minute)
+			require.NoError(t, err)
+			tc.buildStubs(store, payload)
+
+			authPath := "/auth"
+			server.router.GET(
+				authPath,
+				authMiddleware(server.tokenMaker, server.store),
+				requireScope(tc.scope),
+				func(ctx *gin.Context) {
+					ctx.JSON(http.StatusOK, gin.H{})
+				},
+			)
+
+			recorder := httptest.NewRecorder()
+			request, err := http.NewRequest(http.MethodGet, authPath, nil)
+			require.NoError(t, err)
+			request.Header.Set(authorizationHeaderKey, "Bearer "+accessToken)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
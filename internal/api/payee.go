@@ -0,0 +1,216 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/token"
+	"github.com/nilesh0729/Transactly/internal/util"
+)
+
+type CreatePayeeRequest struct {
+	AccountNumber string `json:"account_number" binding:"required,account_number"`
+	Nickname      string `json:"nickname" binding:"required,max=50"`
+	Currency      string `json:"currency" binding:"required,currency"` // must match the account's
+}
+
+type PayeeResponse struct {
+	ID               int64     `json:"id"`
+	AccountID        int64     `json:"account_id"`
+	AccountNumber    string    `json:"account_number"`
+	Nickname         string    `json:"nickname"`
+	Currency         string    `json:"currency"`
+	CreatedAt        time.Time `json:"created_at"`
+	CoolingOffEndsAt time.Time `json:"cooling_off_ends_at"` // transfers are capped at PAYEE_COOLING_OFF_LIMIT until then
+}
+
+func (server *Server) newPayeeResponse(payee Anuskh.Payee) PayeeResponse {
+	return PayeeResponse{
+		ID:               payee.ID,
+		AccountID:        payee.AccountID,
+		AccountNumber:    payee.AccountNumber,
+		Nickname:         payee.Nickname,
+		Currency:         payee.Currency,
+		CreatedAt:        payee.CreatedAt,
+		CoolingOffEndsAt: payee.CreatedAt.Add(server.config.PayeeCoolingOff),
+	}
+}
+
+func (server *Server) CreatePayee(ctx *gin.Context) {
+	var req CreatePayeeRequest
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, valid := server.AccountNumberValidator(ctx, util.NormalizeAccountNumber(req.AccountNumber), req.Currency)
+	if !valid {
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if server.screener != nil {
+		holder, err := server.store.GetUser(ctx, account.Owner)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		if !server.screenName(ctx, screeningSubjectPayee, account.AccountNumber, holder.FullName, authPayload.Username) {
+			return
+		}
+	}
+
+	payee, err := server.store.CreatePayee(ctx, Anuskh.CreatePayeeParams{
+		Owner:         authPayload.Username,
+		AccountID:     account.ID,
+		AccountNumber: account.AccountNumber,
+		Nickname:      req.Nickname,
+		Currency:      account.Currency,
+	})
+	if err != nil {
+		switch Anuskh.ErrorCode(err) {
+		case Anuskh.ForeignKeyViolation, Anuskh.UniqueViolation:
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	resp := server.newPayeeResponse(payee)
+	setAuditSnapshot(ctx, nil, resp)
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type ListPayeeRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+func (server *Server) ListPayee(ctx *gin.Context) {
+	var req ListPayeeRequest
+
+	err := ctx.ShouldBindQuery(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	payees, err := server.store.ListPayees(ctx, Anuskh.ListPayeesParams{
+		Owner:  authPayload.Username,
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	resp := make([]PayeeResponse, len(payees))
+	for i, payee := range payees {
+		resp[i] = server.newPayeeResponse(payee)
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+type DeletePayeeRequest struct {
+	ID int64 `uri:"id" binding:"required,min=1"`
+}
+
+func (server *Server) DeletePayee(ctx *gin.Context) {
+	var req DeletePayeeRequest
+
+	err := ctx.ShouldBindUri(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	rows, err := server.store.DeletePayee(ctx, Anuskh.DeletePayeeParams{
+		ID:    req.ID,
+		Owner: authPayload.Username,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if rows == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows)) // Don't leak other users' payees
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{})
+}
+
+type VerifyPayeeRequest struct {
+	AccountNumber string `json:"account_number" binding:"required,account_number"`
+	Name          string `json:"name" binding:"required,max=200"`
+}
+
+type VerifyPayeeResponse struct {
+	Result util.NameMatch `json:"result"`
+}
+
+// VerifyPayee is confirmation of payee: it tells the caller whether an account
+// is held under the name they expect before they save it or send money to it.
+// Only the result comes back, never the holder's name, even on a close match,
+// and the route is rate limited so names can't be guessed one request at a
+// time.
+func (server *Server) VerifyPayee(ctx *gin.Context) {
+	var req VerifyPayeeRequest
+
+	err := ctx.ShouldBindJSON(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	account, err := server.store.GetAccountByNumber(ctx, util.NormalizeAccountNumber(req.AccountNumber))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	holder, err := server.store.GetUser(ctx, account.Owner)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, VerifyPayeeResponse{Result: util.MatchName(req.Name, holder.FullName)})
+}
+
+// payeeAccount resolves a transfer's payee_id to the payee and the account it
+// points at. It writes the error response itself; the cooling-off limit is
+// left to TransferTx, which applies it however the account was given.
+func (server *Server) payeeAccount(ctx *gin.Context, payeeID int64, currency string) (*Anuskh.Payee, Anuskh.Account, bool) {
+	payee, err := server.store.GetPayee(ctx, payeeID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return nil, Anuskh.Account{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return nil, Anuskh.Account{}, false
+	}
+
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if payee.Owner != authPayload.Username {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows)) // Don't leak other users' payees
+		return nil, Anuskh.Account{}, false
+	}
+
+	account, valid := server.AccountValidator(ctx, payee.AccountID, currency)
+	return &payee, account, valid
+}
@@ -0,0 +1,410 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	mockDB "github.com/nilesh0729/Transactly/internal/db/Mock"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func randomPayee(owner string, account Anuskh.Account) Anuskh.Payee {
+	return Anuskh.Payee{
+		ID:            util.RandomInt(1, 1000),
+		Owner:         owner,
+		AccountID:     account.ID,
+		AccountNumber: account.AccountNumber,
+		Nickname:      util.RandomOwner(),
+		Currency:      account.Currency,
+		CreatedAt:     time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+func TestCreatePayeeAPI(t *testing.T) {
+	_, user := RandomUser(t)
+	_, other := RandomUser(t)
+	account := randomAccount(other.Username)
+	account.Currency = util.EUR
+	var err error
+	account.AccountNumber, err = util.GenerateAccountNumber()
+	require.NoError(t, err)
+	payee := randomPayee(user.Username, account)
+	// check digits one off the right ones; mod 97, 00 and 01 can be right too
+	checkDigits, err := strconv.Atoi(account.AccountNumber[2:4])
+	require.NoError(t, err)
+	if checkDigits > 2 {
+		checkDigits--
+	} else {
+		checkDigits++
+	}
+	wrongCheckDigits := fmt.Sprintf("%02d", checkDigits)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockDB.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"account_number": account.AccountNumber, "nickname": payee.Nickname, "currency": util.EUR},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(account.AccountNumber)).Times(1).Return(account, nil)
+				store.EXPECT().
+					CreatePayee(gomock.Any(), gomock.Eq(Anuskh.CreatePayeeParams{
+						Owner:         user.Username,
+						AccountID:     account.ID,
+						AccountNumber: account.AccountNumber,
+						Nickname:      payee.Nickname,
+						Currency:      util.EUR,
+					})).
+					Times(1).
+					Return(payee, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				data, err := io.ReadAll(recorder.Body)
+				require.NoError(t, err)
+				var got PayeeResponse
+				require.NoError(t, json.Unmarshal(data, &got))
+				require.Equal(t, payee.ID, got.ID)
+				require.Equal(t, account.AccountNumber, got.AccountNumber)
+				require.Equal(t, payee.CreatedAt.Add(time.Hour), got.CoolingOffEndsAt)
+			},
+		},
+		{
+			name: "InvalidAccountNumber",
+			body: gin.H{"account_number": "TX" + wrongCheckDigits + account.AccountNumber[4:], "nickname": payee.Nickname, "currency": util.EUR},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "AccountNotFound",
+			body: gin.H{"account_number": account.AccountNumber, "nickname": payee.Nickname, "currency": util.EUR},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Any()).Times(1).Return(Anuskh.Account{}, sql.ErrNoRows)
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+				requireBodyError(t, recorder, errAccountNumberNotFound)
+			},
+		},
+		{
+			name: "CurrencyMismatch",
+			body: gin.H{"account_number": account.AccountNumber, "nickname": payee.Nickname, "currency": util.USD},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Any()).Times(1).Return(account, nil)
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				// the same answer as an unknown number
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+				requireBodyError(t, recorder, errAccountNumberNotFound)
+			},
+		},
+		{
+			name: "AlreadySaved",
+			body: gin.H{"account_number": account.AccountNumber, "nickname": payee.Nickname, "currency": util.EUR},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Any()).Times(1).Return(account, nil)
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(1).Return(Anuskh.Payee{}, &pq.Error{Code: Anuskh.UniqueViolation})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPost, "/payees", bytes.NewReader(data))
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestDeletePayeeAPI(t *testing.T) {
+	_, user := RandomUser(t)
+
+	for _, rows := range []int64{1, 0} {
+		t.Run(fmt.Sprintf("Rows%d", rows), func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			store.EXPECT().
+				DeletePayee(gomock.Any(), gomock.Eq(Anuskh.DeletePayeeParams{ID: 7, Owner: user.Username})).
+				Times(1).
+				Return(rows, nil)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodDelete, "/payees/7", nil)
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			if rows == 1 {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			} else {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestTransferToPayeeAPI(t *testing.T) {
+	_, user1 := RandomUser(t)
+	_, user2 := RandomUser(t)
+	_, user3 := RandomUser(t)
+
+	from := randomAccount(user1.Username)
+	to := randomAccount(user2.Username)
+	to.ID = from.ID + 1
+	from.Currency = util.INR
+	to.Currency = util.INR
+
+	payee := randomPayee(user1.Username, to)
+	othersPayee := randomPayee(user3.Username, to)
+
+	testCases := []struct {
+		name          string
+		payee         Anuskh.Payee
+		amount        int64
+		txErr         error
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+		transfers     int
+	}{
+		{
+			name:      "Ok",
+			payee:     payee,
+			amount:    100,
+			transfers: 1,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "CoolingOff",
+			payee:     payee,
+			amount:    101,
+			txErr:     fmt.Errorf("%w: 100 INR remains", Anuskh.ErrPayeeCoolingOff),
+			transfers: 1,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:   "OtherUsersPayee",
+			payee:  othersPayee,
+			amount: 10,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			// the cooling-off limit is left to TransferTx
+			arg := Anuskh.TransferTxParams{
+				FromAccountID: from.ID,
+				ToAccountID:   to.ID,
+				Amount:        tc.amount,
+				CoolingOff:    Anuskh.CoolingOff{Period: time.Hour, Limit: 100},
+			}
+			store := mockDB.NewMockStore(ctrl)
+			store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(from.ID)).Times(1).Return(from, nil)
+			store.EXPECT().GetPayee(gomock.Any(), gomock.Eq(tc.payee.ID)).Times(1).Return(tc.payee, nil)
+			store.EXPECT().GetAccounts(gomock.Any(), gomock.Eq(to.ID)).Times(tc.transfers).Return(to, nil)
+			store.EXPECT().
+				TransferTx(gomock.Any(), gomock.Eq(arg)).
+				Times(tc.transfers).
+				Return(Anuskh.TransferTxResult{}, tc.txErr)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{
+				"from_account_id": from.ID,
+				"payee_id":        tc.payee.ID,
+				"amount":          tc.amount,
+				"currency":        util.INR,
+			})
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPost, "/transfers", bytes.NewReader(data))
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestVerifyPayeeAPI(t *testing.T) {
+	_, caller := RandomUser(t)
+	_, holder := RandomUser(t)
+	holder.FullName = "John Robert Smith"
+	account := randomAccount(holder.Username)
+	var err error
+	account.AccountNumber, err = util.GenerateAccountNumber()
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockDB.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Match",
+			body: gin.H{"account_number": account.AccountNumber, "name": "john robert smith"},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(account.AccountNumber)).Times(1).Return(account, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(holder.Username)).Times(1).Return(holder, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.JSONEq(t, `{"result":"match"}`, recorder.Body.String())
+			},
+		},
+		{
+			name: "CloseMatch",
+			body: gin.H{"account_number": account.AccountNumber, "name": "J R Smith"},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(account.AccountNumber)).Times(1).Return(account, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(holder.Username)).Times(1).Return(holder, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.JSONEq(t, `{"result":"close_match"}`, recorder.Body.String())
+			},
+		},
+		{
+			name: "NoMatch",
+			body: gin.H{"account_number": account.AccountNumber, "name": "Jane Doe"},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(account.AccountNumber)).Times(1).Return(account, nil)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(holder.Username)).Times(1).Return(holder, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.JSONEq(t, `{"result":"no_match"}`, recorder.Body.String())
+				require.NotContains(t, recorder.Body.String(), "Smith")
+			},
+		},
+		{
+			name: "AccountNotFound",
+			body: gin.H{"account_number": account.AccountNumber, "name": "John Smith"},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Any()).Times(1).Return(Anuskh.Account{}, sql.ErrNoRows)
+				store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "MissingName",
+			body: gin.H{"account_number": account.AccountNumber},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPost, "/payees/verify", bytes.NewReader(data))
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, caller.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+
+	t.Run("RateLimited", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		store := mockDB.NewMockStore(ctrl)
+		store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Any()).Times(3).Return(account, nil)
+		store.EXPECT().GetUser(gomock.Any(), gomock.Any()).Times(3).Return(holder, nil)
+
+		server := newTestServer(t, store)
+		codes := make([]int, 4)
+		for i := range codes {
+			data, err := json.Marshal(gin.H{"account_number": account.AccountNumber, "name": util.RandomOwner()})
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPost, "/payees/verify", bytes.NewReader(data))
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, caller.Username, time.Minute)
+
+			recorder := httptest.NewRecorder()
+			server.router.ServeHTTP(recorder, request)
+			codes[i] = recorder.Code
+		}
+		require.Equal(t, []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusTooManyRequests}, codes)
+	})
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/nilesh0729/Transactly/internal/ratelimit"
+	"github.com/nilesh0729/Transactly/internal/token"
+)
+
+var errRateLimited = errors.New("too many requests, slow down")
+
+// rateLimitMiddleware takes a token from the caller's bucket for the named
+// policy. Callers are the authenticated user when there is one, otherwise
+// the client IP.
+func rateLimitMiddleware(limiter ratelimit.Limiter, name string, policy ratelimit.Policy) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if !policy.Enabled() {
+			ctx.Next()
+			return
+		}
+
+		result, err := limiter.Take(ctx, name+":"+rateLimitKey(ctx), policy)
+		if err != nil {
+			// a limiter outage shouldn't take the whole API down with it
+			slog.ErrorContext(ctx.Request.Context(), "cannot apply rate limit", "policy", name, "error", err)
+			ctx.Next()
+			return
+		}
+
+		ctx.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", policy.Burst, ceilSeconds(policy.Period)))
+		ctx.Header("RateLimit-Limit", strconv.Itoa(result.Limit))
+		ctx.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		ctx.Header("RateLimit-Reset", strconv.Itoa(ceilSeconds(result.ResetAfter)))
+
+		if !result.Allowed {
+			ctx.Header("Retry-After", strconv.Itoa(ceilSeconds(result.RetryAfter)))
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, errorResponse(errRateLimited))
+			return
+		}
+		ctx.Next()
+	}
+}
+
+func rateLimitKey(ctx *gin.Context) string {
+	if payload, ok := ctx.Get(authorizationPayloadKey); ok {
+		return "user:" + payload.(*token.Payload).Username
+	}
+	return "ip:" + ctx.ClientIP()
+}
+
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockDB "github.com/nilesh0729/Transactly/internal/db/Mock"
+	"github.com/nilesh0729/Transactly/internal/ratelimit"
+	"github.com/nilesh0729/Transactly/internal/token"
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	limiter := ratelimit.NewMemoryLimiter()
+	policy := ratelimit.Policy{Burst: 2, Period: time.Minute}
+
+	router := gin.New()
+	router.GET("/public", rateLimitMiddleware(limiter, "public", policy), func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+	router.GET("/private", func(ctx *gin.Context) {
+		ctx.Set(authorizationPayloadKey, &token.Payload{Username: ctx.GetHeader("X-User")})
+	}, rateLimitMiddleware(limiter, "api", policy), func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	send := func(path string, user string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, path, nil)
+		require.NoError(t, err)
+		request.Header.Set("X-User", user)
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	recorder := send("/public", "")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "2", recorder.Header().Get("RateLimit-Limit"))
+	require.Equal(t, "1", recorder.Header().Get("RateLimit-Remaining"))
+	require.Equal(t, "2;w=60", recorder.Header().Get("RateLimit-Policy"))
+	require.Empty(t, recorder.Header().Get("Retry-After"))
+
+	require.Equal(t, http.StatusOK, send("/public", "").Code)
+
+	recorder = send("/public", "")
+	require.Equal(t, http.StatusTooManyRequests, recorder.Code)
+	require.Equal(t, "0", recorder.Header().Get("RateLimit-Remaining"))
+	require.Equal(t, "30", recorder.Header().Get("Retry-After"))
+
+	// authenticated callers are limited per user, not per IP
+	require.Equal(t, http.StatusOK, send("/private", "alice").Code)
+	require.Equal(t, http.StatusOK, send("/private", "alice").Code)
+	require.Equal(t, http.StatusTooManyRequests, send("/private", "alice").Code)
+	require.Equal(t, http.StatusOK, send("/private", "bob").Code)
+}
+
+func TestRateLimitDisabled(t *testing.T) {
+	router := gin.New()
+	router.GET("/", rateLimitMiddleware(ratelimit.NewMemoryLimiter(), "public", ratelimit.Policy{}), func(ctx *gin.Context) {
+		ctx.Status(http.StatusOK)
+	})
+
+	for i := 0; i < 10; i++ {
+		recorder := httptest.NewRecorder()
+		request, err := http.NewRequest(http.MethodGet, "/", nil)
+		require.NoError(t, err)
+		router.ServeHTTP(recorder, request)
+		require.Equal(t, http.StatusOK, recorder.Code)
+		require.Empty(t, recorder.Header().Get("RateLimit-Limit"))
+	}
+}
+
+func TestNewServerRateLimitConfig(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	store := mockDB.NewMockStore(ctrl)
+
+	config := util.Config{
+		TokenSymmetricKey: util.RandomString(32),
+		RateLimitBackend:  "redis",
+		Currencies:        util.DefaultCurrencies,
+	}
+	_, err := NewServer(store, config)
+	require.Error(t, err)
+
+	config.RateLimitBackend = "postgres"
+	config.RateLimitTransfers = "ten/1m"
+	_, err = NewServer(store, config)
+	require.Error(t, err)
+
+	config.RateLimitTransfers = "10/1m"
+	_, err = NewServer(store, config)
+	require.NoError(t, err)
+}
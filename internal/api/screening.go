@@ -0,0 +1,92 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/metrics"
+)
+
+const (
+	screeningSubjectUser  = "user"
+	screeningSubjectPayee = "payee"
+)
+
+// errScreeningMatch is all the caller learns: telling them which list entry
+// they matched would tip them off.
+var errScreeningMatch = errors.New("cannot proceed, please contact support")
+
+// screenName checks name against the watchlist and records the result. It
+// writes the response and returns false when the request must stop there.
+// Without a watchlist every name passes unrecorded.
+func (server *Server) screenName(ctx *gin.Context, subjectType string, subject string, name string, requestedBy string) bool {
+	if server.screener == nil {
+		return true
+	}
+
+	result := server.screener.Screen(name)
+	arg := Anuskh.CreateScreeningResultParams{
+		SubjectType: subjectType,
+		Subject:     subject,
+		Name:        name,
+		RequestedBy: requestedBy,
+		ListVersion: result.ListVersion,
+		Matched:     result.Matched(),
+		Score:       result.Score,
+	}
+	if result.Match != nil {
+		arg.EntryID = result.Match.Entry.ID
+		arg.EntryName = result.Match.MatchedName
+	}
+
+	// an unrecorded check is no good to compliance, so it fails the request
+	record, err := server.store.CreateScreeningResult(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return false
+	}
+	metrics.Screenings.WithLabelValues(subjectType, strconv.FormatBool(record.Matched)).Inc()
+
+	if record.Matched {
+		setAuditSnapshot(ctx, nil, record)
+		ctx.JSON(http.StatusForbidden, errorResponse(errScreeningMatch))
+		return false
+	}
+	return true
+}
+
+type ListScreeningResultRequest struct {
+	Matched  *bool `form:"matched"` // both by default
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=100"`
+}
+
+// ListScreeningResult pages through watchlist checks, newest first.
+func (server *Server) ListScreeningResult(ctx *gin.Context) {
+	var req ListScreeningResultRequest
+
+	err := ctx.ShouldBindQuery(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := Anuskh.ListScreeningResultsParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+	if req.Matched != nil {
+		arg.Matched = sql.NullBool{Bool: *req.Matched, Valid: true}
+	}
+
+	results, err := server.store.ListScreeningResults(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.JSON(http.StatusOK, results)
+}
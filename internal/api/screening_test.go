@@ -0,0 +1,297 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	mockDB "github.com/nilesh0729/Transactly/internal/db/Mock"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/screening"
+	"github.com/nilesh0729/Transactly/internal/token"
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+const sanctionedName = "Ivan Petrovich Drago"
+
+func newTestScreener(t *testing.T) *screening.Screener {
+	path := filepath.Join(t.TempDir(), "watchlist.csv")
+	require.NoError(t, os.WriteFile(path, []byte("id,name,aliases,program\n7,"+sanctionedName+",Ivan Drago,SDGT\n"), 0o600))
+
+	screener, err := screening.NewScreener(path, 0.9)
+	require.NoError(t, err)
+	return screener
+}
+
+// recordScreening stands in for the store saving a screening result.
+func recordScreening(ctx context.Context, arg Anuskh.CreateScreeningResultParams) (Anuskh.ScreeningResult, error) {
+	return Anuskh.ScreeningResult{
+		ID:          1,
+		SubjectType: arg.SubjectType,
+		Subject:     arg.Subject,
+		Name:        arg.Name,
+		RequestedBy: arg.RequestedBy,
+		ListVersion: arg.ListVersion,
+		Matched:     arg.Matched,
+		Score:       arg.Score,
+		EntryID:     arg.EntryID,
+		EntryName:   arg.EntryName,
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+func TestCreateUserScreening(t *testing.T) {
+	password, user := RandomUser(t)
+
+	testCases := []struct {
+		name          string
+		fullName      string
+		buildStubs    func(store *mockDB.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "Clear",
+			fullName: user.FullName,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					CreateScreeningResult(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg Anuskh.CreateScreeningResultParams) (Anuskh.ScreeningResult, error) {
+						require.Equal(t, screeningSubjectUser, arg.SubjectType)
+						require.Equal(t, user.Username, arg.Subject)
+						require.Equal(t, user.FullName, arg.Name)
+						require.False(t, arg.Matched)
+						require.Empty(t, arg.EntryID)
+						require.NotEmpty(t, arg.ListVersion)
+						return recordScreening(ctx, arg)
+					})
+				store.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(1).Return(user, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:     "Matched",
+			fullName: "DRAGO, Ivan",
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					CreateScreeningResult(gomock.Any(), gomock.Any()).
+					Times(1).
+					DoAndReturn(func(ctx context.Context, arg Anuskh.CreateScreeningResultParams) (Anuskh.ScreeningResult, error) {
+						require.True(t, arg.Matched)
+						require.Equal(t, "7", arg.EntryID)
+						require.GreaterOrEqual(t, arg.Score, 0.9)
+						return recordScreening(ctx, arg)
+					})
+				store.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				// the caller mustn't learn what they matched
+				require.NotContains(t, recorder.Body.String(), sanctionedName)
+			},
+		},
+		{
+			name:     "NotRecorded",
+			fullName: user.FullName,
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().CreateScreeningResult(gomock.Any(), gomock.Any()).Times(1).Return(Anuskh.ScreeningResult{}, errors.New("connection reset"))
+				store.EXPECT().CreateUser(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			server.screener = newTestScreener(t)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{
+				"username":  user.Username,
+				"password":  password,
+				"full_name": tc.fullName,
+				"email":     user.Email,
+			})
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPost, "/user", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestCreatePayeeScreening(t *testing.T) {
+	_, user := RandomUser(t)
+	_, holder := RandomUser(t)
+	account := randomAccount(holder.Username)
+	account.Currency = util.EUR
+	var err error
+	account.AccountNumber, err = util.GenerateAccountNumber()
+	require.NoError(t, err)
+
+	for _, sanctioned := range []bool{false, true} {
+		name := "Clear"
+		if sanctioned {
+			name = "Matched"
+		}
+		t.Run(name, func(t *testing.T) {
+			holder := holder
+			if sanctioned {
+				holder.FullName = "Mr. Ivan Drago"
+			}
+
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			store.EXPECT().GetAccountByNumber(gomock.Any(), gomock.Eq(account.AccountNumber)).Times(1).Return(account, nil)
+			store.EXPECT().GetUser(gomock.Any(), gomock.Eq(holder.Username)).Times(1).Return(holder, nil)
+			store.EXPECT().
+				CreateScreeningResult(gomock.Any(), gomock.Any()).
+				Times(1).
+				DoAndReturn(func(ctx context.Context, arg Anuskh.CreateScreeningResultParams) (Anuskh.ScreeningResult, error) {
+					require.Equal(t, screeningSubjectPayee, arg.SubjectType)
+					require.Equal(t, account.AccountNumber, arg.Subject)
+					require.Equal(t, holder.FullName, arg.Name)
+					require.Equal(t, user.Username, arg.RequestedBy)
+					require.Equal(t, sanctioned, arg.Matched)
+					return recordScreening(ctx, arg)
+				})
+			if sanctioned {
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(0)
+			} else {
+				store.EXPECT().CreatePayee(gomock.Any(), gomock.Any()).Times(1).Return(randomPayee(user.Username, account), nil)
+			}
+
+			server := newTestServer(t, store)
+			server.screener = newTestScreener(t)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{"account_number": account.AccountNumber, "nickname": "landlord", "currency": util.EUR})
+			require.NoError(t, err)
+			request, err := http.NewRequest(http.MethodPost, "/payees", bytes.NewReader(data))
+			require.NoError(t, err)
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, user.Username, time.Minute)
+
+			server.router.ServeHTTP(recorder, request)
+			if sanctioned {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			} else {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			}
+		})
+	}
+}
+
+func TestListScreeningResultAPI(t *testing.T) {
+	_, auditor := RandomUser(t)
+	auditor.Role = util.AuditorRole
+	_, depositor := RandomUser(t)
+
+	testCases := []struct {
+		name          string
+		query         string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockDB.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:  "OK",
+			query: "page_id=2&page_size=5",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, auditor.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(auditor.Username)).Times(1).Return(auditor, nil)
+				store.EXPECT().
+					ListScreeningResults(gomock.Any(), gomock.Eq(Anuskh.ListScreeningResultsParams{Limit: 5, Offset: 5})).
+					Times(1).
+					Return([]Anuskh.ScreeningResult{{ID: 3}}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				var got []Anuskh.ScreeningResult
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got, 1)
+			},
+		},
+		{
+			name:  "MatchesOnly",
+			query: "matched=true&page_id=1&page_size=5",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, auditor.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(auditor.Username)).Times(1).Return(auditor, nil)
+				store.EXPECT().
+					ListScreeningResults(gomock.Any(), gomock.Eq(Anuskh.ListScreeningResultsParams{
+						Limit:   5,
+						Matched: sql.NullBool{Bool: true, Valid: true},
+					})).
+					Times(1).
+					Return([]Anuskh.ScreeningResult{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "NotAnAuditor",
+			query: "page_id=1&page_size=5",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, depositor.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().GetUser(gomock.Any(), gomock.Eq(depositor.Username)).Times(1).Return(depositor, nil)
+				store.EXPECT().ListScreeningResults(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/screening-results?"+tc.query, nil)
+			require.NoError(t, err)
+			tc.setupAuth(t, request, server.tokenMaker)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
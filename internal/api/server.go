@@ -1,22 +1,44 @@
 package api
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
+	"github.com/nilesh0729/Transactly/internal/blob"
 	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/interest"
+	"github.com/nilesh0729/Transactly/internal/metrics"
+	"github.com/nilesh0729/Transactly/internal/ratelimit"
+	"github.com/nilesh0729/Transactly/internal/risk"
+	"github.com/nilesh0729/Transactly/internal/screening"
 	"github.com/nilesh0729/Transactly/internal/token"
 	"github.com/nilesh0729/Transactly/internal/util"
 )
 
 type Server struct {
-	config     util.Config
-	store      Anuskh.Store
-	tokenMaker token.Maker
-	router     *gin.Engine
+	config         util.Config
+	store          Anuskh.Store
+	tokenMaker     token.Maker
+	limiter        ratelimit.Limiter
+	bucketIdle     time.Duration // longest rate limit period, for pruning shared buckets
+	transferLimits util.TransferLimits
+	fees           util.FeeSchedule
+	coolingOff     Anuskh.CoolingOff
+	interestRates  util.InterestRates
+	currencies     *util.CurrencyRegistry
+	interest       *interest.Job // nil when no account earns interest
+	riskEngine     *risk.Engine
+	screener       *screening.Screener // nil when no watchlist is configured
+	blobs          blob.Store
+	router         *gin.Engine
 }
 
 func NewServer(store Anuskh.Store, config util.Config) (*Server, error) {
@@ -24,50 +46,277 @@ func NewServer(store Anuskh.Store, config util.Config) (*Server, error) {
 	if err != nil {
 		return nil, fmt.Errorf("cannot create Token maker : %w", err)
 	}
+	var limiter ratelimit.Limiter
+	switch config.RateLimitBackend {
+	case "", "memory":
+		limiter = ratelimit.NewMemoryLimiter()
+	case "postgres":
+		limiter = ratelimit.NewPostgresLimiter(store)
+	default:
+		return nil, fmt.Errorf("unknown rate limit backend : %s", config.RateLimitBackend)
+	}
+	var blobs blob.Store
+	switch config.BlobBackend {
+	case "", "memory":
+		blobs = blob.NewMemoryStore()
+	case "file":
+		blobs, err = blob.NewFileStore(config.BlobDir)
+		if err != nil {
+			return nil, fmt.Errorf("cannot open blob store : %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown blob backend : %s", config.BlobBackend)
+	}
+	transferLimits, err := util.ParseTransferLimits(config.TransferLimits)
+	if err != nil {
+		return nil, err
+	}
+	fees, err := util.ParseFeeSchedule(config.FeeSchedule)
+	if err != nil {
+		return nil, err
+	}
+	currencies, err := util.NewCurrencyRegistry(config.Currencies)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CURRENCIES : %w", err)
+	}
+	interestRates, err := util.ParseInterestRates(config.InterestRates)
+	if err != nil {
+		return nil, err
+	}
+	fraudRules, err := risk.RulesByName(config.FraudRules)
+	if err != nil {
+		return nil, err
+	}
+
 	server := &Server{
-		config:     config,
-		store:      store,
-		tokenMaker: tokenMaker,
+		config:         config,
+		store:          store,
+		tokenMaker:     tokenMaker,
+		limiter:        limiter,
+		transferLimits: transferLimits,
+		fees:           fees,
+		coolingOff: Anuskh.CoolingOff{
+			Period:       config.PayeeCoolingOff,
+			Limit:        config.PayeeCoolingLimit,
+			UnsavedLimit: config.UnsavedPayeeLimit,
+		},
+		interestRates: interestRates,
+		currencies:    currencies,
+		riskEngine:    risk.NewEngine(store, fraudRules...),
+		blobs:         blobs,
+	}
+	if interestRates != nil {
+		if config.InterestRunPeriod <= 0 {
+			return nil, fmt.Errorf("INTEREST_RUN_PERIOD must be positive, got %s", config.InterestRunPeriod)
+		}
+		server.interest, err = interest.NewJob(store, interestRates)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if config.WatchlistPath != "" {
+		server.screener, err = screening.NewScreener(config.WatchlistPath, config.ScreeningMatchScore)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load watchlist : %w", err)
+		}
 	}
 
+	validatorCurrencies.Store(currencies)
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		v.RegisterValidation("currency", validCurrency)
+		v.RegisterValidation("iso_currency", validISOCurrency)
+		v.RegisterValidation("account_type", validAccountType)
+		v.RegisterValidation("account_number", validAccountNumber)
 	}
 
-	server.SetupRouter()
+	if err := server.SetupRouter(); err != nil {
+		return nil, err
+	}
 
 	return server, nil
 }
 
-func (server *Server) SetupRouter() {
-	router := gin.Default()
+func (server *Server) SetupRouter() error {
+	router := gin.New()
+	router.Use(requestIDMiddleware(), recoveryMiddleware())
+	// Let ctx.Value reach the request context so traces follow handlers into the store.
+	router.ContextWithFallback = true
+	// Client IPs feed API key allowlists, so only trust forwarding headers from known proxies.
+	if err := router.SetTrustedProxies(server.config.TrustedProxies); err != nil {
+		return fmt.Errorf("invalid trusted proxies : %w", err)
+	}
 
 	config := cors.DefaultConfig()
 	config.AllowAllOrigins = true // For development only
 	config.AllowHeaders = []string{"Origin", "Content-Length", "Content-Type", "Authorization"}
 	router.Use(cors.New(config))
+	router.Use(tracingMiddleware())
+	router.Use(metricsMiddleware())
+	router.Use(accessLogMiddleware())
+	router.Use(auditMiddleware(server.store))
 
-	router.POST("/user", server.CreateUser)
+	publicPolicy, err := ratelimit.ParsePolicy(server.config.RateLimitPublic)
+	if err != nil {
+		return err
+	}
+	apiPolicy, err := ratelimit.ParsePolicy(server.config.RateLimitAPI)
+	if err != nil {
+		return err
+	}
+	transfersPolicy, err := ratelimit.ParsePolicy(server.config.RateLimitTransfers)
+	if err != nil {
+		return err
+	}
+	payeeVerifyPolicy, err := ratelimit.ParsePolicy(server.config.RateLimitPayeeCheck)
+	if err != nil {
+		return err
+	}
+	server.bucketIdle = max(publicPolicy.Period, apiPolicy.Period, transfersPolicy.Period, payeeVerifyPolicy.Period)
+	publicLimit := rateLimitMiddleware(server.limiter, "public", publicPolicy)
 
-	router.POST("/user/login", server.LoginUser)
+	router.GET("/metrics", metricsAllowlist(server.config.MetricsAllowedIPs), gin.WrapH(metrics.Handler()))
+	router.GET("/healthz", server.Healthz)
+	router.GET("/readyz", server.Readyz)
 
-	authRoutes := router.Group("/").Use(authMiddleware(server.tokenMaker))
+	router.GET("/currencies", publicLimit, server.ListCurrency)
 
-	authRoutes.POST("/accounts", server.CreateAccount)
+	router.POST("/user", publicLimit, server.CreateUser)
 
-	authRoutes.GET("/accounts/:id", server.GetAccount)
+	router.POST("/user/login", publicLimit, server.LoginUser)
 
-	authRoutes.GET("/accounts", server.ListAccount)
+	router.POST("/oauth/token", publicLimit, server.OauthToken)
+	router.POST("/oauth/introspect", publicLimit, server.IntrospectOauthToken)
+	router.POST("/oauth/revoke", publicLimit, server.RevokeOauthToken)
 
-	authRoutes.POST("/transfers", server.CreateTransfer)
-	authRoutes.GET("/transfers", server.ListTransfer)
-	authRoutes.GET("/accounts/:id/entries", server.ListEntry)
+	authRoutes := router.Group("/").Use(
+		authMiddleware(server.tokenMaker, server.store),
+		rateLimitMiddleware(server.limiter, "api", apiPolicy),
+	)
 
-	server.router = router
+	authRoutes.POST("/accounts", requireScope(scopeAccountsWrite), server.CreateAccount)
+
+	authRoutes.GET("/accounts/:id", requireScope(scopeAccountsRead), server.GetAccount)
 
+	authRoutes.GET("/accounts", requireScope(scopeAccountsRead), server.ListAccount)
+	authRoutes.POST("/accounts/:id/close", requireScope(scopeAccountsWrite), server.CloseAccount)
+	authRoutes.POST("/accounts/:id/reopen", requireScope(scopeAccountsWrite), server.ReopenAccount)
+
+	authRoutes.POST("/transfers", requireScope(scopeTransfersWrite), rateLimitMiddleware(server.limiter, "transfers", transfersPolicy), server.CreateTransfer)
+	authRoutes.GET("/transfers", requireScope(scopeTransfersRead), server.ListTransfer)
+	// quotes look accounts up like payee checks do, so they share the limit
+	authRoutes.GET("/transfers/quote", requireScope(scopeTransfersRead), rateLimitMiddleware(server.limiter, "payee_verify", payeeVerifyPolicy), server.QuoteTransfer)
+	authRoutes.GET("/limits", requireScope(scopeTransfersRead), server.GetLimits)
+	authRoutes.GET("/accounts/:id/entries", requireScope(scopeAccountsRead), server.ListEntry)
+	authRoutes.GET("/accounts/:id/interest", requireScope(scopeAccountsRead), server.ListInterest)
+
+	authRoutes.POST("/payees", requireScope(scopeUserSession), server.CreatePayee)
+	authRoutes.POST("/payees/verify", requireScope(scopeTransfersWrite), rateLimitMiddleware(server.limiter, "payee_verify", payeeVerifyPolicy), server.VerifyPayee)
+	authRoutes.GET("/payees", requireScope(scopeTransfersRead), server.ListPayee)
+	authRoutes.DELETE("/payees/:id", requireScope(scopeUserSession), server.DeletePayee)
+
+	authRoutes.GET("/kyc", requireScope(scopeUserSession), server.GetKyc)
+	authRoutes.POST("/kyc/documents", requireScope(scopeUserSession), server.UploadKycDocument)
+	authRoutes.POST("/kyc/submit", requireScope(scopeUserSession), server.SubmitKyc)
+
+	authRoutes.POST("/api-keys", requireScope(scopeUserSession), server.CreateApiKey)
+	authRoutes.GET("/api-keys", requireScope(scopeUserSession), server.ListApiKey)
+	authRoutes.DELETE("/api-keys/:id", requireScope(scopeUserSession), server.DeleteApiKey)
+
+	authRoutes.POST("/oauth/clients", requireScope(scopeUserSession), server.RegisterOauthClient)
+	authRoutes.GET("/oauth/authorize", requireScope(scopeUserSession), server.GetOauthAuthorize)
+	authRoutes.POST("/oauth/authorize", requireScope(scopeUserSession), server.ApproveOauthAuthorize)
+
+	authRoutes.GET("/audit-events", requireScope(scopeUserSession), requireRole(server.store, util.AuditorRole, util.AdminRole), server.ListAuditEvent)
+	authRoutes.GET("/screening-results", requireScope(scopeUserSession), requireRole(server.store, util.AuditorRole, util.AdminRole), server.ListScreeningResult)
+
+	requireAdmin := requireRole(server.store, util.AdminRole)
+	authRoutes.POST("/admin/accounts/:id/freeze", requireScope(scopeUserSession), requireAdmin, server.FreezeAccount)
+	authRoutes.POST("/admin/accounts/:id/unfreeze", requireScope(scopeUserSession), requireAdmin, server.UnfreezeAccount)
+	authRoutes.GET("/admin/held-transfers", requireScope(scopeUserSession), requireAdmin, server.ListHeldTransfer)
+	authRoutes.POST("/admin/held-transfers/:id/approve", requireScope(scopeUserSession), requireAdmin, server.ApproveHeldTransfer)
+	authRoutes.POST("/admin/held-transfers/:id/reject", requireScope(scopeUserSession), requireAdmin, server.RejectHeldTransfer)
+	authRoutes.GET("/admin/currencies", requireScope(scopeUserSession), requireAdmin, server.ListAdminCurrency)
+	authRoutes.POST("/admin/currencies/:code/enable", requireScope(scopeUserSession), requireAdmin, server.EnableCurrency)
+	authRoutes.POST("/admin/currencies/:code/disable", requireScope(scopeUserSession), requireAdmin, server.DisableCurrency)
+	authRoutes.GET("/admin/kyc", requireScope(scopeUserSession), requireAdmin, server.ListKycReview)
+	authRoutes.GET("/admin/kyc/:username", requireScope(scopeUserSession), requireAdmin, server.GetUserKyc)
+	authRoutes.GET("/admin/kyc/:username/documents/:id", requireScope(scopeUserSession), requireAdmin, server.DownloadKycDocument)
+	authRoutes.POST("/admin/kyc/:username/approve", requireScope(scopeUserSession), requireAdmin, server.ApproveKyc)
+	authRoutes.POST("/admin/kyc/:username/reject", requireScope(scopeUserSession), requireAdmin, server.RejectKyc)
+
+	server.router = router
+	return nil
 }
-func (server *Server) Start(address string) error {
-	return server.router.Run(address)
+
+// Start serves HTTP on address until ctx is cancelled, then stops accepting
+// connections and waits up to ShutdownTimeout for in-flight requests.
+func (server *Server) Start(ctx context.Context, address string) error {
+	httpServer := &http.Server{
+		Addr:              address,
+		Handler:           server.router,
+		ReadHeaderTimeout: server.config.HTTPReadTimeout,
+		ReadTimeout:       server.config.HTTPReadTimeout,
+		WriteTimeout:      server.config.HTTPWriteTimeout,
+		IdleTimeout:       server.config.HTTPIdleTimeout,
+	}
+
+	// switches stored by admins apply before the first request
+	if err := server.refreshCurrencies(ctx); err != nil {
+		return fmt.Errorf("cannot load currency settings : %w", err)
+	}
+
+	// The background jobs use the store, so Start only returns once they
+	// have stopped and main can close it. They stop with ctx, or when
+	// serving fails.
+	var jobs sync.WaitGroup
+	defer jobs.Wait()
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+	runJob := func(job func(ctx context.Context)) {
+		jobs.Add(1)
+		go func() {
+			defer jobs.Done()
+			job(jobsCtx)
+		}()
+	}
+
+	if server.config.CurrencyRefreshPeriod > 0 {
+		runJob(func(ctx context.Context) { server.watchCurrencies(ctx, server.config.CurrencyRefreshPeriod) })
+	}
+	if server.screener != nil && server.config.WatchlistReload > 0 {
+		runJob(func(ctx context.Context) { server.screener.Watch(ctx, server.config.WatchlistReload) })
+	}
+	if server.interest != nil {
+		runJob(func(ctx context.Context) { server.interest.Run(ctx, server.config.InterestRunPeriod) })
+	}
+	if server.config.AuditSequencePeriod > 0 {
+		runJob(func(ctx context.Context) {
+			Anuskh.RunAuditSequencer(ctx, server.store, server.config.AuditSequencePeriod)
+		})
+	}
+	if server.config.RateLimitBackend == "postgres" && server.bucketIdle > 0 {
+		runJob(func(ctx context.Context) { ratelimit.RunBucketPruner(ctx, server.store, server.bucketIdle) })
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down server", "timeout", server.config.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), server.config.ShutdownTimeout)
+	defer cancel()
+
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("cannot drain requests : %w", err)
+	}
+	return nil
 }
 
 func errorResponse(err error) gin.H {
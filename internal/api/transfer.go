@@ -1,22 +1,38 @@
 package api
 
 import (
-	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/metrics"
+	"github.com/nilesh0729/Transactly/internal/risk"
 	"github.com/nilesh0729/Transactly/internal/token"
+	"github.com/nilesh0729/Transactly/internal/util"
 )
 
+// TransferTxResponse adds the transfer's amount and fee in its currency to the
+// result.
+type TransferTxResponse struct {
+	Anuskh.TransferTxResult
+	AmountMoney util.Money `json:"amount_money"`
+	FeeMoney    util.Money `json:"fee_money"`
+}
+
+// TransferRequest names the destination by exactly one of to_account_id,
+// to_account_number, whose check digits catch typos before any lookup, or the
+// payee_id of a saved payee. A quote takes the same fields as query parameters.
 type TransferRequest struct {
-	FromAccountId int64  `json:"from_account_id" binding:"required,min=1"`
-	ToAccountId   int64  `json:"to_account_id" binding:"required,min=1"`
-	Amount        int64  `json:"amount" binding:"required,gt=0"` //gt == greater than(used in case the amount would be less than 1 but still greater than 0, like Rs0.45)
-	Currency      string `json:"currency" binding:"required,currency"`
+	FromAccountId   int64  `json:"from_account_id" form:"from_account_id" binding:"required,min=1"`
+	ToAccountId     int64  `json:"to_account_id" form:"to_account_id" binding:"required_without_all=ToAccountNumber PayeeID,excluded_with=ToAccountNumber PayeeID,omitempty,min=1"`
+	ToAccountNumber string `json:"to_account_number" form:"to_account_number" binding:"excluded_with=PayeeID,omitempty,account_number"`
+	PayeeID         int64  `json:"payee_id" form:"payee_id" binding:"omitempty,min=1"`
+	Amount          int64  `json:"amount" form:"amount" binding:"required,gt=0"` //gt == greater than(used in case the amount would be less than 1 but still greater than 0, like Rs0.45)
+	Currency        string `json:"currency" form:"currency" binding:"required,iso_currency"`
 }
 
 func (server *Server) CreateTransfer(ctx *gin.Context) {
@@ -29,39 +45,147 @@ func (server *Server) CreateTransfer(ctx *gin.Context) {
 		return
 	}
 
-	account, valid := server.AccountValidator(ctx, req.FromAccountId, req.Currency)
+	account, toAccount, payee, valid := server.transferAccounts(ctx, req)
 	if !valid {
 		return
 	}
 
 	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
-	if account.Owner != authPayload.Username {
-		err := errors.New("transfer Account doesn't belong to Authenticated User")
-		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+
+	arg := Anuskh.TransferTxParams{
+		FromAccountID: req.FromAccountId,
+		ToAccountID:   toAccount.ID,
+		Amount:        req.Amount,
+		Limits:        server.transferLimits,
+		CoolingOff:    server.coolingOff,
+		Fees:          server.fees,
+	}
+
+	decision, err := server.riskEngine.Evaluate(ctx, risk.Transfer{
+		Sender: authPayload.Username,
+		From:   account,
+		To:     toAccount,
+		Amount: req.Amount,
+		Payee:  payee,
+		At:     time.Now(),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	metrics.FraudDecisions.WithLabelValues(string(decision.Outcome)).Inc()
+	switch decision.Outcome {
+	case risk.Block:
+		// The audit trail keeps the reasons; the sender only learns it was refused.
+		setAuditSnapshot(ctx, nil, decision)
+		ctx.JSON(http.StatusForbidden, errorResponse(errors.New("transfer refused by fraud checks")))
+		return
+	case risk.Review:
+		// a transfer over the limits is refused now, not held for an
+		// approval that could never go through
+		if err := server.store.CheckTransferTx(ctx, arg); err != nil {
+			ctx.JSON(transferErrorStatus(err), errorResponse(err))
+			return
+		}
+		server.holdTransfer(ctx, account, toAccount, req.Amount, decision)
 		return
 	}
 
-	_, valid = server.AccountValidator(ctx, req.ToAccountId, req.Currency)
-	if !valid {
+	Result, err := server.store.TransferTx(auditContext(ctx), arg)
+	if err != nil {
+		ctx.JSON(transferErrorStatus(err), errorResponse(err))
 		return
 	}
+	metrics.Transfers.WithLabelValues(req.Currency).Inc()
+	metrics.TransferVolume.WithLabelValues(req.Currency).Add(float64(req.Amount))
+	metrics.TransferFees.WithLabelValues(req.Currency).Add(float64(Result.Transfer.Fee))
+	ctx.JSON(http.StatusOK, TransferTxResponse{
+		TransferTxResult: Result,
+		AmountMoney:      util.NewMoney(Result.Transfer.Amount, req.Currency),
+		FeeMoney:         util.NewMoney(Result.Transfer.Fee, req.Currency),
+	})
+}
 
-	arg := Anuskh.TransferTxParams{
-		FromAccountID: req.FromAccountId,
-		ToAccountID:   req.ToAccountId,
-		Amount:        req.Amount,
+// transferErrorStatus is the status for a transfer TransferTx refused.
+func transferErrorStatus(err error) int {
+	if errors.Is(err, Anuskh.ErrAccountNotActive) || errors.Is(err, Anuskh.ErrTransferLimitExceeded) ||
+		errors.Is(err, Anuskh.ErrPayeeCoolingOff) {
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+// transferAccounts looks up both ends of the transfer in req, making sure the
+// caller owns the account it comes from.
+func (server *Server) transferAccounts(ctx *gin.Context, req TransferRequest) (from Anuskh.Account, to Anuskh.Account, payee *Anuskh.Payee, valid bool) {
+	from, valid = server.AccountValidator(ctx, req.FromAccountId, req.Currency)
+	if !valid {
+		return from, to, nil, false
 	}
 
-	Result, err := server.store.TransferTx(ctx, arg)
+	authPayload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+	if from.Owner != authPayload.Username {
+		err := errors.New("transfer Account doesn't belong to Authenticated User")
+		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
+		return from, to, nil, false
+	}
+
+	switch {
+	case req.PayeeID != 0:
+		payee, to, valid = server.payeeAccount(ctx, req.PayeeID, req.Currency)
+	case req.ToAccountNumber != "":
+		to, valid = server.AccountNumberValidator(ctx, util.NormalizeAccountNumber(req.ToAccountNumber), req.Currency)
+	default:
+		to, valid = server.AccountValidator(ctx, req.ToAccountId, req.Currency)
+	}
+	return from, to, payee, valid
+}
+
+// holdTransfer puts a transfer the fraud rules flagged in the review queue and
+// answers 202, as it will only be made once an admin approves it.
+func (server *Server) holdTransfer(ctx *gin.Context, from Anuskh.Account, to Anuskh.Account, amount int64, decision risk.Decision) {
+	held, err := server.store.CreateHeldTransfer(ctx, Anuskh.CreateHeldTransferParams{
+		RequestedBy:   from.Owner,
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        amount,
+		Currency:      from.Currency,
+		Reasons:       decision.Reasons(),
+	})
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, Result)
+
+	setAuditSnapshot(ctx, nil, newHeldTransferResponse(held, true))
+	ctx.JSON(http.StatusAccepted, newHeldTransferResponse(held, false))
 }
 
 func (Server *Server) AccountValidator(ctx *gin.Context, accountID int64, currency string) (Anuskh.Account, bool) {
-	account, err := Server.store.GetAccounts(context.Background(), accountID)
+	account, err := Server.store.GetAccounts(ctx, accountID)
+	return Server.checkAccount(ctx, account, err, currency)
+}
+
+// errAccountNumberNotFound answers both unknown account numbers and ones in
+// another currency, so lookups can't tell which numbers exist or what they
+// hold.
+var errAccountNumberNotFound = errors.New("no account with this number in this currency")
+
+func (Server *Server) AccountNumberValidator(ctx *gin.Context, accountNumber string, currency string) (Anuskh.Account, bool) {
+	account, err := Server.store.GetAccountByNumber(ctx, accountNumber)
+	if err == nil && account.Currency != currency {
+		err = sql.ErrNoRows
+	}
+	if err == sql.ErrNoRows {
+		ctx.JSON(http.StatusNotFound, errorResponse(errAccountNumberNotFound))
+		return account, false
+	}
+	return Server.checkAccount(ctx, account, err, currency)
+}
+
+// checkAccount writes the error response for a failed account lookup or a
+// currency mismatch.
+func (Server *Server) checkAccount(ctx *gin.Context, account Anuskh.Account, err error, currency string) (Anuskh.Account, bool) {
 	if err != nil {
 		if err == sql.ErrNoRows {
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
@@ -71,7 +195,7 @@ func (Server *Server) AccountValidator(ctx *gin.Context, accountID int64, curren
 		return account, false
 	}
 	if account.Currency != currency {
-		err := fmt.Errorf("account %d's currency is mismatched : %s vs %s", accountID, currency, account.Currency)
+		err := fmt.Errorf("account currency doesn't match %s", currency)
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return account, false
 	}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,11 +28,25 @@ func TestTransfersAPI(t *testing.T) {
 	account2 := randomAccount(user2.Username)
 	account3 := randomAccount(user3.Username)
 	amount := int64(10)
+	coolingOff := Anuskh.CoolingOff{Period: time.Hour, Limit: 100}
 
 	account1.Currency = util.INR
 	account2.Currency = util.INR
 	account3.Currency = util.USD
 
+	var err error
+	account2.AccountNumber, err = util.GenerateAccountNumber()
+	require.NoError(t, err)
+	account3.AccountNumber, err = util.GenerateAccountNumber()
+	require.NoError(t, err)
+	// how people copy it from a statement
+	typedNumber := strings.ToLower(account2.AccountNumber[:4]) + " " + account2.AccountNumber[4:12] + " " + account2.AccountNumber[12:]
+	mistyped := []byte(account2.AccountNumber)
+	mistyped[10], mistyped[11] = mistyped[11], mistyped[10]
+	if mistyped[10] == mistyped[11] {
+		mistyped[10] = '0' + (mistyped[10]-'0'+1)%10
+	}
+
 	testcases := []struct {
 		name          string
 		body          gin.H
@@ -65,6 +80,7 @@ func TestTransfersAPI(t *testing.T) {
 					FromAccountID: account1.ID,
 					ToAccountID:   account2.ID,
 					Amount:        amount,
+					CoolingOff:    coolingOff,
 				}
 				store.EXPECT().
 					TransferTx(gomock.Any(), gomock.Eq(arg)).
@@ -75,6 +91,167 @@ func TestTransfersAPI(t *testing.T) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
+		{
+			name: "OkByAccountNumber",
+			body: gin.H{
+				"from_account_id":   account1.ID,
+				"to_account_number": typedNumber,
+				"amount":            amount,
+				"currency":          util.INR,
+			},
+			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					GetAccounts(gomock.Any(), gomock.Eq(account1.ID)).
+					Times(1).
+					Return(account1, nil)
+
+				store.EXPECT().
+					GetAccountByNumber(gomock.Any(), gomock.Eq(account2.AccountNumber)).
+					Times(1).
+					Return(account2, nil)
+
+				arg := Anuskh.TransferTxParams{
+					FromAccountID: account1.ID,
+					ToAccountID:   account2.ID,
+					Amount:        amount,
+					CoolingOff:    coolingOff,
+				}
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "MistypedAccountNumber",
+			body: gin.H{
+				"from_account_id":   account1.ID,
+				"to_account_number": string(mistyped),
+				"amount":            amount,
+				"currency":          util.INR,
+			},
+			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					GetAccounts(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					GetAccountByNumber(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "AccountIdAndNumber",
+			body: gin.H{
+				"from_account_id":   account1.ID,
+				"to_account_id":     account2.ID,
+				"to_account_number": account2.AccountNumber,
+				"amount":            amount,
+				"currency":          util.INR,
+			},
+			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NoDestination",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"amount":          amount,
+				"currency":        util.INR,
+			},
+			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "AccountNumberNotFound",
+			body: gin.H{
+				"from_account_id":   account1.ID,
+				"to_account_number": account2.AccountNumber,
+				"amount":            amount,
+				"currency":          util.INR,
+			},
+			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					GetAccounts(gomock.Any(), gomock.Eq(account1.ID)).
+					Times(1).
+					Return(account1, nil)
+				store.EXPECT().
+					GetAccountByNumber(gomock.Any(), gomock.Eq(account2.AccountNumber)).
+					Times(1).
+					Return(Anuskh.Account{}, sql.ErrNoRows)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+				requireBodyError(t, recorder, errAccountNumberNotFound)
+			},
+		},
+		{
+			name: "AccountNumberCurrencyMismatched",
+			body: gin.H{
+				"from_account_id":   account1.ID,
+				"to_account_number": account3.AccountNumber,
+				"amount":            amount,
+				"currency":          util.INR,
+			},
+			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					GetAccounts(gomock.Any(), gomock.Eq(account1.ID)).
+					Times(1).
+					Return(account1, nil)
+				store.EXPECT().
+					GetAccountByNumber(gomock.Any(), gomock.Eq(account3.AccountNumber)).
+					Times(1).
+					Return(account3, nil)
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				// indistinguishable from an unknown number
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+				requireBodyError(t, recorder, errAccountNumberNotFound)
+			},
+		},
 		{
 			name: "FromAccountNotFound",
 			body: gin.H{
@@ -100,6 +277,7 @@ func TestTransfersAPI(t *testing.T) {
 					FromAccountID: account1.ID,
 					ToAccountID:   account2.ID,
 					Amount:        amount,
+					CoolingOff:    coolingOff,
 				}
 				store.EXPECT().
 					TransferTx(gomock.Any(), gomock.Eq(arg)).
@@ -213,6 +391,37 @@ func TestTransfersAPI(t *testing.T) {
 			},
 		},
 
+		{
+			name: "TransferLimitExceeded",
+			body: gin.H{
+				"from_account_id": account1.ID,
+				"to_account_id":   account2.ID,
+				"amount":          amount,
+				"currency":        util.INR,
+			},
+			setAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, user1.Username, time.Minute)
+			},
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().
+					GetAccounts(gomock.Any(), gomock.Eq(account1.ID)).
+					Times(1).
+					Return(account1, nil)
+
+				store.EXPECT().
+					GetAccounts(gomock.Any(), gomock.Eq(account2.ID)).
+					Times(1).
+					Return(account2, nil)
+
+				store.EXPECT().
+					TransferTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(Anuskh.TransferTxResult{}, Anuskh.ErrTransferLimitExceeded)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
 		{
 			name: "InvalidCurrency",
 			body: gin.H{
@@ -237,6 +446,7 @@ func TestTransfersAPI(t *testing.T) {
 					FromAccountID: account1.ID,
 					ToAccountID:   account2.ID,
 					Amount:        amount,
+					CoolingOff:    coolingOff,
 				}
 				store.EXPECT().
 					TransferTx(gomock.Any(), gomock.Eq(arg)).
@@ -303,6 +513,7 @@ func TestTransfersAPI(t *testing.T) {
 					FromAccountID: account1.ID,
 					ToAccountID:   account2.ID,
 					Amount:        amount,
+					CoolingOff:    coolingOff,
 				}
 				store.EXPECT().
 					TransferTx(gomock.Any(), gomock.Eq(arg)).
@@ -340,6 +551,7 @@ func TestTransfersAPI(t *testing.T) {
 					FromAccountID: account1.ID,
 					ToAccountID:   account2.ID,
 					Amount:        amount,
+					CoolingOff:    coolingOff,
 				}
 				store.EXPECT().
 					TransferTx(gomock.Any(), gomock.Eq(arg)).
@@ -385,3 +597,9 @@ func TestTransfersAPI(t *testing.T) {
 	}
 
 }
+
+func requireBodyError(t *testing.T, recorder *httptest.ResponseRecorder, expected error) {
+	var body gin.H
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	require.Equal(t, gin.H{"error": expected.Error()}, body)
+}
@@ -6,8 +6,8 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/lib/pq"
 	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/metrics"
 	"github.com/nilesh0729/Transactly/internal/util"
 )
 
@@ -22,6 +22,9 @@ type UserResponse struct {
 	Username          string    `json:"username"`
 	FullName          string    `json:"full_name"`
 	Email             string    `json:"email"`
+	Role              string    `json:"role"`
+	Tier              string    `json:"tier"`       // picks the transfer limits that apply
+	KycStatus         string    `json:"kyc_status"` // unverified users get reduced limits
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
 }
@@ -31,6 +34,9 @@ func newUserResponse(user Anuskh.User) UserResponse {
 		Username:          user.Username,
 		FullName:          user.FullName,
 		Email:             user.Email,
+		Role:              user.Role,
+		Tier:              user.Tier,
+		KycStatus:         user.KycStatus,
 		PasswordChangedAt: user.PasswordChangedAt,
 		CreatedAt:         user.CreatedAt,
 	}
@@ -43,6 +49,11 @@ func (server *Server) CreateUser(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+	setAuditActor(ctx, req.Username)
+
+	if !server.screenName(ctx, screeningSubjectUser, req.Username, req.FullName, req.Username) {
+		return
+	}
 
 	hashedPassword, err := util.HashedPassword(req.Password)
 	if err != nil {
@@ -58,18 +69,17 @@ func (server *Server) CreateUser(ctx *gin.Context) {
 
 	user, err := server.store.CreateUser(ctx, arg)
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code.Name() {
-			case "unique_violation":
-				ctx.JSON(http.StatusForbidden, errorResponse(err))
-				return
-			}
+		switch Anuskh.ErrorCode(err) {
+		case Anuskh.UniqueViolation:
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+			return
 		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
 	resp := newUserResponse(user)
+	setAuditSnapshot(ctx, nil, resp)
 	ctx.JSON(http.StatusOK, resp)
 }
 
@@ -91,9 +101,11 @@ func (server *Server) LoginUser(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
+	setAuditActor(ctx, req.Username)
 	user, err := server.store.GetUser(ctx, req.Username)
 	if err != nil {
 		if err == sql.ErrNoRows {
+			metrics.FailedLogins.WithLabelValues("unknown_user").Inc()
 			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
@@ -102,6 +114,7 @@ func (server *Server) LoginUser(ctx *gin.Context) {
 	}
 	err = util.CheckPassword(req.Password, user.HashedPassword)
 	if err != nil {
+		metrics.FailedLogins.WithLabelValues("wrong_password").Inc()
 		ctx.JSON(http.StatusUnauthorized, errorResponse(err))
 		return
 	}
@@ -1,13 +1,48 @@
 package api
 
 import (
+	"sync/atomic"
+
 	"github.com/go-playground/validator/v10"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
 	"github.com/nilesh0729/Transactly/internal/util"
 )
 
+// validatorCurrencies is the registry validCurrency checks. Validators are
+// registered with gin for the whole process, and cache their functions per
+// struct, so NewServer swaps the registry in here instead.
+var validatorCurrencies atomic.Pointer[util.CurrencyRegistry]
+
+// validCurrency accepts the currencies enabled right now, so switching one
+// off stops new accounts, and payees, in it.
 var validCurrency validator.Func = func(fl validator.FieldLevel) bool {
 	if currency, ok := fl.Field().Interface().(string); ok {
-		return util.IsSupportedCurrency(currency)
+		return validatorCurrencies.Load().Enabled(currency)
+	}
+	return false
+}
+
+// validISOCurrency accepts any ISO 4217 currency, enabled or not, for
+// requests against existing accounts; handlers check it is the account's.
+var validISOCurrency validator.Func = func(fl validator.FieldLevel) bool {
+	if currency, ok := fl.Field().Interface().(string); ok {
+		return util.IsISOCurrency(currency)
+	}
+	return false
+}
+
+var validAccountType validator.Func = func(fl validator.FieldLevel) bool {
+	if accountType, ok := fl.Field().Interface().(string); ok {
+		return accountType == Anuskh.AccountChecking || accountType == Anuskh.AccountSavings
+	}
+	return false
+}
+
+// validAccountNumber accepts numbers typed with spaces or in lower case;
+// handlers normalize them before use.
+var validAccountNumber validator.Func = func(fl validator.FieldLevel) bool {
+	if number, ok := fl.Field().Interface().(string); ok {
+		return util.IsValidAccountNumber(util.NormalizeAccountNumber(number))
 	}
 	return false
 }
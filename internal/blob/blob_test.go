@@ -0,0 +1,49 @@
+package blob
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStores(t *testing.T) {
+	fileStore, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	stores := map[string]Store{
+		"File":   fileStore,
+		"Memory": NewMemoryStore(),
+	}
+	for name, store := range stores {
+		t.Run(name, func(t *testing.T) { testStore(t, store) })
+	}
+}
+
+func testStore(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	_, err := store.Open(ctx, "kyc/alice/1")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.Put(ctx, "kyc/alice/1", strings.NewReader("first")))
+	require.NoError(t, store.Put(ctx, "kyc/alice/1", strings.NewReader("second")))
+
+	r, err := store.Open(ctx, "kyc/alice/1")
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.Equal(t, "second", string(data))
+
+	require.NoError(t, store.Delete(ctx, "kyc/alice/1"))
+	require.ErrorIs(t, store.Delete(ctx, "kyc/alice/1"), ErrNotFound)
+	_, err = store.Open(ctx, "kyc/alice/1")
+	require.ErrorIs(t, err, ErrNotFound)
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "kyc/../../secret", "kyc//1", `kyc\1`} {
+		require.ErrorIs(t, store.Put(ctx, key, strings.NewReader("x")), ErrInvalidKey, key)
+	}
+}
@@ -2,9 +2,12 @@
 INSERT INTO accounts (
   owner,
   balance,
-  currency
+  currency,
+  nickname,
+  account_type,
+  account_number
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6
 )
 RETURNING *;
 
@@ -13,6 +16,11 @@ SELECT * FROM accounts
 WHERE id = $1 
 LIMIT 1;
 
+-- name: GetAccountByNumber :one
+SELECT * FROM accounts
+WHERE account_number = $1
+LIMIT 1;
+
 -- name: GetAccountsForUpdate :one
 SELECT * FROM accounts
 WHERE id = $1 
@@ -22,10 +30,19 @@ FOR NO KEY UPDATE;
 -- name: ListAccounts :many
 SELECT * FROM accounts
 WHERE owner = $1
-ORDER BY id
+  AND (sqlc.narg(account_type)::varchar IS NULL OR account_type = sqlc.narg(account_type))
+ORDER BY
+  CASE WHEN sqlc.arg(sort_by)::varchar = 'nickname' THEN nickname END,
+  CASE WHEN sqlc.arg(sort_by)::varchar = 'balance' THEN balance END,
+  CASE WHEN sqlc.arg(sort_by)::varchar = 'created_at' THEN created_at END,
+  id
 LIMIT $2
 OFFSET $3;
 
+-- name: CountAccounts :one
+SELECT count(*) FROM accounts
+WHERE owner = $1;
+
 -- name: UpdateAccounts :one
 UPDATE accounts
 set balance = $2
@@ -40,4 +57,19 @@ RETURNING *;
 
 -- name: DeleteAccounts :exec
 DELETE FROM accounts
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET status = $2, status_reason = $3
+WHERE id = $1
+RETURNING *;
+
+-- name: ListInterestBearingAccounts :many
+SELECT * FROM accounts
+WHERE account_type = sqlc.arg(account_type)
+  AND currency = sqlc.arg(currency)
+  AND status <> 'closed'
+  AND id > sqlc.arg(after_id)
+ORDER BY id
+LIMIT sqlc.arg(page_size);
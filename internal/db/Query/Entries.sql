@@ -26,4 +26,17 @@ WHERE id = $1;
 
 -- name: DeleteEntries :exec
 DELETE FROM entries
-WHERE account_id = $1;
\ No newline at end of file
+WHERE account_id = $1;
+
+-- name: GetLatestEntry :one
+SELECT * FROM entries
+WHERE account_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT 1;
+
+-- name: GetAccountBalanceAt :one
+SELECT (a.balance - COALESCE(SUM(e.amount), 0))::bigint AS balance
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id AND e.created_at >= sqlc.arg(at)
+WHERE a.id = sqlc.arg(account_id)
+GROUP BY a.balance;
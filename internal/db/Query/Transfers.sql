@@ -2,9 +2,11 @@
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount
+  amount,
+  fee,
+  fee_account_id
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5
 )
 RETURNING *;
 
@@ -29,4 +31,48 @@ WHERE id = $1;
 
 -- name: DeleteTransfers :exec
 DELETE FROM transfers
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1;
+
+-- name: SumTransfers :one
+SELECT
+  COALESCE(SUM(t.amount) FILTER (WHERE t.created_at >= sqlc.arg(day_start)), 0)::bigint AS daily_amount,
+  COALESCE(SUM(t.amount) FILTER (WHERE t.created_at >= sqlc.arg(month_start)), 0)::bigint AS monthly_amount,
+  COUNT(*) FILTER (WHERE t.created_at >= sqlc.arg(hour_start)) AS hourly_count
+FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = sqlc.arg(owner)
+  AND a.currency = sqlc.arg(currency)
+  AND t.created_at >= LEAST(sqlc.arg(month_start), sqlc.arg(hour_start));
+
+-- name: SumTransfersTo :one
+SELECT COALESCE(SUM(t.amount), 0)::bigint FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = sqlc.arg(owner)
+  AND t.to_account_id = sqlc.arg(to_account_id)
+  AND t.created_at >= sqlc.arg(since);
+
+-- name: GetTransferActivity :one
+SELECT
+  COUNT(*) AS transfers,
+  COUNT(DISTINCT t.to_account_id) AS recipients,
+  COUNT(*) FILTER (WHERE t.to_account_id = sqlc.arg(to_account_id)) AS to_account_transfers
+FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = sqlc.arg(owner)
+  AND t.created_at >= sqlc.arg(since);
+
+-- name: CountTransfersInRange :one
+SELECT COUNT(*) FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = sqlc.arg(owner)
+  AND t.created_at >= sqlc.arg(since)
+  AND t.amount >= sqlc.arg(min_amount)
+  AND t.amount < sqlc.arg(max_amount)
+  AND t.amount % sqlc.arg(round_to)::bigint = 0
+  AND a.currency = sqlc.arg(currency);
+
+-- name: CountTransfersTo :one
+SELECT COUNT(*) FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = sqlc.arg(owner)
+  AND t.to_account_id = sqlc.arg(to_account_id);
@@ -0,0 +1,63 @@
+-- name: QueueAuditEvent :exec
+INSERT INTO queued_audit_events (
+  actor,
+  action,
+  resource,
+  ip,
+  request_id,
+  status,
+  before,
+  after,
+  created_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+);
+
+-- name: ListQueuedAuditEvents :many
+SELECT * FROM queued_audit_events
+ORDER BY id
+LIMIT $1
+FOR UPDATE;
+
+-- name: DeleteQueuedAuditEvents :execrows
+DELETE FROM queued_audit_events
+WHERE id = ANY(sqlc.arg(ids)::bigint[]);
+
+-- name: LockAuditChain :exec
+SELECT pg_advisory_xact_lock(7253001);
+
+-- name: GetLastAuditEventHash :one
+SELECT hash FROM audit_events
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: CreateAuditEvent :one
+INSERT INTO audit_events (
+  actor,
+  action,
+  resource,
+  ip,
+  request_id,
+  status,
+  before,
+  after,
+  prev_hash,
+  hash,
+  created_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11
+)
+RETURNING *;
+
+-- name: ListAuditEvents :many
+SELECT * FROM audit_events
+WHERE id > sqlc.arg(after_id)
+  AND (sqlc.narg(actor)::varchar IS NULL OR actor = sqlc.narg(actor))
+ORDER BY id
+LIMIT sqlc.arg(page_size);
+
+-- name: GetAuditEventBefore :one
+SELECT * FROM audit_events
+WHERE id < $1
+ORDER BY id DESC
+LIMIT 1;
@@ -0,0 +1,15 @@
+-- name: ListCurrencySettings :many
+SELECT * FROM currency_settings
+ORDER BY code;
+
+-- name: SetCurrencyEnabled :one
+INSERT INTO currency_settings (
+  code,
+  enabled
+) VALUES (
+  $1, $2
+)
+ON CONFLICT (code) DO UPDATE
+SET enabled = EXCLUDED.enabled,
+    updated_at = now()
+RETURNING *;
@@ -0,0 +1,40 @@
+-- name: CreateHeldTransfer :one
+INSERT INTO held_transfers (
+  requested_by,
+  from_account_id,
+  to_account_id,
+  amount,
+  currency,
+  reasons
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetHeldTransfer :one
+SELECT * FROM held_transfers
+WHERE id = $1
+LIMIT 1;
+
+-- name: GetHeldTransferForUpdate :one
+SELECT * FROM held_transfers
+WHERE id = $1
+LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: ListHeldTransfers :many
+SELECT * FROM held_transfers
+WHERE status = $1
+ORDER BY id
+LIMIT $2
+OFFSET $3;
+
+-- name: ReviewHeldTransfer :one
+UPDATE held_transfers
+SET status = $2,
+    reviewed_by = $3,
+    review_note = $4,
+    transfer_id = $5,
+    reviewed_at = now()
+WHERE id = $1
+RETURNING *;
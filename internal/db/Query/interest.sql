@@ -0,0 +1,70 @@
+-- name: CreateInterestAccrual :one
+INSERT INTO interest_accruals (
+  account_id,
+  accrual_date,
+  balance,
+  rate_bps,
+  amount_micros
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetLatestInterestAccrual :one
+SELECT * FROM interest_accruals
+WHERE account_id = $1
+ORDER BY accrual_date DESC
+LIMIT 1;
+
+-- name: ListInterestAccruals :many
+SELECT * FROM interest_accruals
+WHERE account_id = $1
+ORDER BY accrual_date DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: ListUnpostedInterestAccruals :many
+SELECT * FROM interest_accruals
+WHERE account_id = sqlc.arg(account_id)
+  AND posting_id IS NULL
+  AND accrual_date < sqlc.arg(before)
+ORDER BY accrual_date
+FOR UPDATE;
+
+-- name: ListAccountsWithUnpostedInterest :many
+SELECT DISTINCT account_id FROM interest_accruals
+WHERE posting_id IS NULL
+  AND accrual_date < sqlc.arg(before)
+ORDER BY account_id;
+
+-- name: SetInterestAccrualsPosted :execrows
+UPDATE interest_accruals
+SET posting_id = sqlc.arg(posting_id)
+WHERE account_id = sqlc.arg(account_id)
+  AND posting_id IS NULL
+  AND accrual_date < sqlc.arg(before);
+
+-- name: CreateInterestPosting :one
+INSERT INTO interest_postings (
+  account_id,
+  funding_account_id,
+  period,
+  amount,
+  carry_micros
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetLatestInterestPosting :one
+SELECT * FROM interest_postings
+WHERE account_id = $1
+ORDER BY period DESC
+LIMIT 1;
+
+-- name: ListInterestPostings :many
+SELECT * FROM interest_postings
+WHERE account_id = $1
+ORDER BY period DESC
+LIMIT $2
+OFFSET $3;
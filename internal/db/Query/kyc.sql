@@ -0,0 +1,26 @@
+-- name: CreateKycDocument :one
+INSERT INTO kyc_documents (
+  owner,
+  kind,
+  content_type,
+  size_bytes,
+  sha256,
+  blob_key
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetKycDocument :one
+SELECT * FROM kyc_documents
+WHERE id = $1
+LIMIT 1;
+
+-- name: ListKycDocuments :many
+SELECT * FROM kyc_documents
+WHERE owner = $1
+ORDER BY id;
+
+-- name: CountKycDocuments :one
+SELECT count(*) FROM kyc_documents
+WHERE owner = $1;
@@ -0,0 +1,75 @@
+-- name: CreateOauthClient :one
+INSERT INTO oauth_clients (
+  id,
+  owner,
+  name,
+  hashed_secret,
+  redirect_uris,
+  scopes
+) VALUES (
+  $1, $2, $3, $4, $5, $6
+)
+RETURNING *;
+
+-- name: GetOauthClient :one
+SELECT * FROM oauth_clients
+WHERE id = $1
+LIMIT 1;
+
+-- name: CreateOauthAuthorizationCode :one
+INSERT INTO oauth_authorization_codes (
+  code_hash,
+  client_id,
+  username,
+  redirect_uri,
+  scopes,
+  code_challenge,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7
+)
+RETURNING *;
+
+-- name: ConsumeOauthAuthorizationCode :one
+DELETE FROM oauth_authorization_codes
+WHERE code_hash = $1
+RETURNING *;
+
+-- name: UpsertOauthConsent :one
+INSERT INTO oauth_consents (
+  username,
+  client_id,
+  scopes
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (username, client_id)
+DO UPDATE SET scopes = EXCLUDED.scopes, created_at = now()
+RETURNING *;
+
+-- name: GetOauthConsent :one
+SELECT * FROM oauth_consents
+WHERE username = $1 AND client_id = $2
+LIMIT 1;
+
+-- name: CreateOauthToken :one
+INSERT INTO oauth_tokens (
+  id,
+  client_id,
+  username,
+  scopes,
+  expires_at
+) VALUES (
+  $1, $2, $3, $4, $5
+)
+RETURNING *;
+
+-- name: GetOauthToken :one
+SELECT * FROM oauth_tokens
+WHERE id = $1
+LIMIT 1;
+
+-- name: RevokeOauthToken :exec
+UPDATE oauth_tokens
+SET revoked_at = now()
+WHERE id = $1 AND revoked_at IS NULL;
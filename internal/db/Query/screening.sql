@@ -0,0 +1,22 @@
+-- name: CreateScreeningResult :one
+INSERT INTO screening_results (
+  subject_type,
+  subject,
+  name,
+  requested_by,
+  list_version,
+  matched,
+  score,
+  entry_id,
+  entry_name
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+RETURNING *;
+
+-- name: ListScreeningResults :many
+SELECT * FROM screening_results
+WHERE sqlc.narg(matched)::boolean IS NULL OR matched = sqlc.narg(matched)
+ORDER BY id DESC
+LIMIT $1
+OFFSET $2;
@@ -14,3 +14,26 @@ SELECT * FROM "user"
 WHERE username = $1
 LIMIT 1;
 
+
+-- name: GetUserForUpdate :one
+SELECT * FROM "user"
+WHERE username = $1
+LIMIT 1
+FOR NO KEY UPDATE;
+
+-- name: UpdateUserKyc :one
+UPDATE "user"
+SET
+  kyc_status = $2,
+  kyc_note = $3,
+  kyc_reviewed_by = $4,
+  kyc_updated_at = now()
+WHERE username = $1
+RETURNING *;
+
+-- name: ListUsersByKycStatus :many
+SELECT * FROM "user"
+WHERE kyc_status = $1
+ORDER BY kyc_updated_at, username
+LIMIT $2
+OFFSET $3;
@@ -7,13 +7,14 @@ package Anuskh
 
 import (
 	"context"
+	"database/sql"
 )
 
 const addBalance = `-- name: AddBalance :one
 UPDATE accounts
 set balance = balance + $2
 WHERE id = $1
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, status, status_reason, nickname, account_type, account_number
 `
 
 type AddBalanceParams struct {
@@ -30,29 +31,59 @@ func (q *Queries) AddBalance(ctx context.Context, arg AddBalanceParams) (Account
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.StatusReason,
+		&i.Nickname,
+		&i.AccountType,
+		&i.AccountNumber,
 	)
 	return i, err
 }
 
+const countAccounts = `-- name: CountAccounts :one
+SELECT count(*) FROM accounts
+WHERE owner = $1
+`
+
+func (q *Queries) CountAccounts(ctx context.Context, owner string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countAccounts, owner)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createAccounts = `-- name: CreateAccounts :one
 INSERT INTO accounts (
   owner,
   balance,
-  currency
+  currency,
+  nickname,
+  account_type,
+  account_number
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5, $6
 )
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, status, status_reason, nickname, account_type, account_number
 `
 
 type CreateAccountsParams struct {
-	Owner    string `json:"owner"`
-	Balance  int64  `json:"balance"`
-	Currency string `json:"currency"`
+	Owner         string `json:"owner"`
+	Balance       int64  `json:"balance"`
+	Currency      string `json:"currency"`
+	Nickname      string `json:"nickname"`
+	AccountType   string `json:"account_type"`
+	AccountNumber string `json:"account_number"`
 }
 
 func (q *Queries) CreateAccounts(ctx context.Context, arg CreateAccountsParams) (Account, error) {
-	row := q.db.QueryRowContext(ctx, createAccounts, arg.Owner, arg.Balance, arg.Currency)
+	row := q.db.QueryRowContext(ctx, createAccounts,
+		arg.Owner,
+		arg.Balance,
+		arg.Currency,
+		arg.Nickname,
+		arg.AccountType,
+		arg.AccountNumber,
+	)
 	var i Account
 	err := row.Scan(
 		&i.ID,
@@ -60,6 +91,11 @@ func (q *Queries) CreateAccounts(ctx context.Context, arg CreateAccountsParams)
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.StatusReason,
+		&i.Nickname,
+		&i.AccountType,
+		&i.AccountNumber,
 	)
 	return i, err
 }
@@ -74,8 +110,32 @@ func (q *Queries) DeleteAccounts(ctx context.Context, id int64) error {
 	return err
 }
 
+const getAccountByNumber = `-- name: GetAccountByNumber :one
+SELECT id, owner, balance, currency, created_at, status, status_reason, nickname, account_type, account_number FROM accounts
+WHERE account_number = $1
+LIMIT 1
+`
+
+func (q *Queries) GetAccountByNumber(ctx context.Context, accountNumber string) (Account, error) {
+	row := q.db.QueryRowContext(ctx, getAccountByNumber, accountNumber)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Status,
+		&i.StatusReason,
+		&i.Nickname,
+		&i.AccountType,
+		&i.AccountNumber,
+	)
+	return i, err
+}
+
 const getAccounts = `-- name: GetAccounts :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, status, status_reason, nickname, account_type, account_number FROM accounts
 WHERE id = $1 
 LIMIT 1
 `
@@ -89,12 +149,17 @@ func (q *Queries) GetAccounts(ctx context.Context, id int64) (Account, error) {
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.StatusReason,
+		&i.Nickname,
+		&i.AccountType,
+		&i.AccountNumber,
 	)
 	return i, err
 }
 
 const getAccountsForUpdate = `-- name: GetAccountsForUpdate :one
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, status, status_reason, nickname, account_type, account_number FROM accounts
 WHERE id = $1 
 LIMIT 1
 FOR NO KEY UPDATE
@@ -109,26 +174,100 @@ func (q *Queries) GetAccountsForUpdate(ctx context.Context, id int64) (Account,
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.StatusReason,
+		&i.Nickname,
+		&i.AccountType,
+		&i.AccountNumber,
 	)
 	return i, err
 }
 
 const listAccounts = `-- name: ListAccounts :many
-SELECT id, owner, balance, currency, created_at FROM accounts
+SELECT id, owner, balance, currency, created_at, status, status_reason, nickname, account_type, account_number FROM accounts
 WHERE owner = $1
-ORDER BY id
+  AND ($4::varchar IS NULL OR account_type = $4)
+ORDER BY
+  CASE WHEN $5::varchar = 'nickname' THEN nickname END,
+  CASE WHEN $5::varchar = 'balance' THEN balance END,
+  CASE WHEN $5::varchar = 'created_at' THEN created_at END,
+  id
 LIMIT $2
 OFFSET $3
 `
 
 type ListAccountsParams struct {
-	Owner  string `json:"owner"`
-	Limit  int32  `json:"limit"`
-	Offset int32  `json:"offset"`
+	Owner       string         `json:"owner"`
+	Limit       int32          `json:"limit"`
+	Offset      int32          `json:"offset"`
+	AccountType sql.NullString `json:"account_type"`
+	SortBy      string         `json:"sort_by"`
 }
 
 func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error) {
-	rows, err := q.db.QueryContext(ctx, listAccounts, arg.Owner, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listAccounts,
+		arg.Owner,
+		arg.Limit,
+		arg.Offset,
+		arg.AccountType,
+		arg.SortBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Account{}
+	for rows.Next() {
+		var i Account
+		if err := rows.Scan(
+			&i.ID,
+			&i.Owner,
+			&i.Balance,
+			&i.Currency,
+			&i.CreatedAt,
+			&i.Status,
+			&i.StatusReason,
+			&i.Nickname,
+			&i.AccountType,
+			&i.AccountNumber,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listInterestBearingAccounts = `-- name: ListInterestBearingAccounts :many
+SELECT id, owner, balance, currency, created_at, status, status_reason, nickname, account_type, account_number FROM accounts
+WHERE account_type = $1
+  AND currency = $2
+  AND status <> 'closed'
+  AND id > $3
+ORDER BY id
+LIMIT $4
+`
+
+type ListInterestBearingAccountsParams struct {
+	AccountType string `json:"account_type"`
+	Currency    string `json:"currency"`
+	AfterID     int64  `json:"after_id"`
+	PageSize    int32  `json:"page_size"`
+}
+
+func (q *Queries) ListInterestBearingAccounts(ctx context.Context, arg ListInterestBearingAccountsParams) ([]Account, error) {
+	rows, err := q.db.QueryContext(ctx, listInterestBearingAccounts,
+		arg.AccountType,
+		arg.Currency,
+		arg.AfterID,
+		arg.PageSize,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -142,6 +281,11 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 			&i.Balance,
 			&i.Currency,
 			&i.CreatedAt,
+			&i.Status,
+			&i.StatusReason,
+			&i.Nickname,
+			&i.AccountType,
+			&i.AccountNumber,
 		); err != nil {
 			return nil, err
 		}
@@ -156,11 +300,42 @@ func (q *Queries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]A
 	return items, nil
 }
 
+const updateAccountStatus = `-- name: UpdateAccountStatus :one
+UPDATE accounts
+SET status = $2, status_reason = $3
+WHERE id = $1
+RETURNING id, owner, balance, currency, created_at, status, status_reason, nickname, account_type, account_number
+`
+
+type UpdateAccountStatusParams struct {
+	ID           int64  `json:"id"`
+	Status       string `json:"status"`
+	StatusReason string `json:"status_reason"`
+}
+
+func (q *Queries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	row := q.db.QueryRowContext(ctx, updateAccountStatus, arg.ID, arg.Status, arg.StatusReason)
+	var i Account
+	err := row.Scan(
+		&i.ID,
+		&i.Owner,
+		&i.Balance,
+		&i.Currency,
+		&i.CreatedAt,
+		&i.Status,
+		&i.StatusReason,
+		&i.Nickname,
+		&i.AccountType,
+		&i.AccountNumber,
+	)
+	return i, err
+}
+
 const updateAccounts = `-- name: UpdateAccounts :one
 UPDATE accounts
 set balance = $2
 WHERE id = $1
-RETURNING id, owner, balance, currency, created_at
+RETURNING id, owner, balance, currency, created_at, status, status_reason, nickname, account_type, account_number
 `
 
 type UpdateAccountsParams struct {
@@ -177,6 +352,11 @@ func (q *Queries) UpdateAccounts(ctx context.Context, arg UpdateAccountsParams)
 		&i.Balance,
 		&i.Currency,
 		&i.CreatedAt,
+		&i.Status,
+		&i.StatusReason,
+		&i.Nickname,
+		&i.AccountType,
+		&i.AccountNumber,
 	)
 	return i, err
 }
@@ -3,6 +3,7 @@ package Anuskh
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"testing"
 	"time"
 
@@ -13,9 +14,11 @@ import (
 func CreateRandomAccount(t *testing.T) Account {
 	user := CreateRandomUser(t)
 	arg := CreateAccountsParams{
-		Owner:    user.Username,
-		Currency: util.RandomCurrency(),
-		Balance:  util.RandomBalance(),
+		Owner:         user.Username,
+		Currency:      util.RandomCurrency(),
+		Balance:       util.RandomBalance(),
+		AccountType:   AccountChecking,
+		AccountNumber: randomAccountNumber(t),
 	}
 
 	Account, err := testQueries.CreateAccounts(context.Background(), arg)
@@ -33,6 +36,12 @@ func CreateRandomAccount(t *testing.T) Account {
 	return Account
 }
 
+func randomAccountNumber(t *testing.T) string {
+	number, err := util.GenerateAccountNumber()
+	require.NoError(t, err)
+	return number
+}
+
 func TestCreateAccount(t *testing.T) {
 	CreateRandomAccount(t)
 }
@@ -111,3 +120,53 @@ func TestListAccounts(t *testing.T) {
 		require.Equal(t, lastAccount.Owner, account.Owner)
 	}
 }
+
+func TestWithAccountNumber(t *testing.T) {
+	var numbers []string
+	taken := uniqueViolation("accounts_account_number_idx")
+	account, err := withAccountNumber(CreateAccountTxParams{}, func(arg CreateAccountTxParams) (Account, error) {
+		numbers = append(numbers, arg.AccountNumber)
+		if len(numbers) == 1 {
+			return Account{}, taken
+		}
+		return Account{AccountNumber: arg.AccountNumber}, nil
+	})
+	require.NoError(t, err)
+	require.Len(t, numbers, 2)
+	require.NotEqual(t, numbers[0], numbers[1])
+	require.Equal(t, numbers[1], account.AccountNumber)
+
+	attempts := 0
+	_, err = withAccountNumber(CreateAccountTxParams{}, func(arg CreateAccountTxParams) (Account, error) {
+		attempts++
+		return Account{}, taken
+	})
+	require.ErrorIs(t, err, taken)
+	require.Equal(t, accountNumberAttempts, attempts)
+
+	// other violations aren't retried
+	attempts = 0
+	_, err = withAccountNumber(CreateAccountTxParams{}, func(arg CreateAccountTxParams) (Account, error) {
+		attempts++
+		return Account{}, uniqueViolation("accounts_owner_nickname_idx")
+	})
+	requireErrorCode(t, err, UniqueViolation)
+	require.Equal(t, 1, attempts)
+}
+
+func TestAccountMarshalJSON(t *testing.T) {
+	account := Account{ID: 1, Owner: "alice", Balance: 1234, Currency: util.USD}
+
+	data, err := json.Marshal(account)
+	require.NoError(t, err)
+
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(data, &fields))
+	require.JSONEq(t, `1234`, string(fields["balance"]))
+	require.JSONEq(t, `{"amount":"12.34","minor_units":1234,"currency":"USD"}`, string(fields["balance_money"]))
+
+	// the generated fields still decode as before
+	var decoded Account
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, account, decoded)
+}
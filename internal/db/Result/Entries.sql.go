@@ -7,6 +7,7 @@ package Anuskh
 
 import (
 	"context"
+	"time"
 )
 
 const createEntries = `-- name: CreateEntries :one
@@ -46,6 +47,26 @@ func (q *Queries) DeleteEntries(ctx context.Context, accountID int64) error {
 	return err
 }
 
+const getAccountBalanceAt = `-- name: GetAccountBalanceAt :one
+SELECT (a.balance - COALESCE(SUM(e.amount), 0))::bigint AS balance
+FROM accounts a
+LEFT JOIN entries e ON e.account_id = a.id AND e.created_at >= $1
+WHERE a.id = $2
+GROUP BY a.balance
+`
+
+type GetAccountBalanceAtParams struct {
+	At        time.Time `json:"at"`
+	AccountID int64     `json:"account_id"`
+}
+
+func (q *Queries) GetAccountBalanceAt(ctx context.Context, arg GetAccountBalanceAtParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getAccountBalanceAt, arg.At, arg.AccountID)
+	var balance int64
+	err := row.Scan(&balance)
+	return balance, err
+}
+
 const getEntries = `-- name: GetEntries :one
 SELECT id, account_id, amount, created_at FROM entries
 WHERE id = $1 
@@ -64,6 +85,25 @@ func (q *Queries) GetEntries(ctx context.Context, id int64) (Entry, error) {
 	return i, err
 }
 
+const getLatestEntry = `-- name: GetLatestEntry :one
+SELECT id, account_id, amount, created_at FROM entries
+WHERE account_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLatestEntry(ctx context.Context, accountID int64) (Entry, error) {
+	row := q.db.QueryRowContext(ctx, getLatestEntry, accountID)
+	var i Entry
+	err := row.Scan(
+		&i.ID,
+		&i.AccountID,
+		&i.Amount,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
 const listEntries = `-- name: ListEntries :many
 SELECT id, account_id, amount, created_at FROM entries
 WHERE account_id = $1
@@ -4,39 +4,77 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nilesh0729/Transactly/internal/metrics"
+	"github.com/nilesh0729/Transactly/internal/tracing"
+	"github.com/nilesh0729/Transactly/internal/util"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type Store interface{
 	TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error)
+	AppendAuditEvent(ctx context.Context, arg AppendAuditEventParams) error
+	SequenceAuditEvents(ctx context.Context, limit int32) ([]AuditEvent, error)
+	ChangeAccountStatus(ctx context.Context, arg ChangeAccountStatusParams) (Account, error)
+	CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (Account, error)
+	ReviewHeldTransferTx(ctx context.Context, arg ReviewHeldTransferTxParams) (ReviewHeldTransferTxResult, error)
+	CheckTransferTx(ctx context.Context, arg TransferTxParams) error
+	ChangeKycStatus(ctx context.Context, arg ChangeKycStatusParams) (User, error)
+	PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error)
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (version int64, dirty bool, err error)
 	Querier
 }
 type RealStore struct {
 	*Queries
-	db *sql.DB
+	db                *sql.DB
+	transferIsolation sql.IsolationLevel
+	retryPolicy       RetryPolicy
+}
+
+func NewTxConn(db *sql.DB, opts ...StoreOption) Store {
+	return newRealStore(db, opts...)
 }
 
-func NewTxConn(db *sql.DB) Store {
-	return &RealStore{
-		db:      db,
-		Queries: New(db),
+func newRealStore(db *sql.DB, opts ...StoreOption) *RealStore {
+	store := &RealStore{
+		db:          db,
+		Queries:     New(traceDB(db)),
+		retryPolicy: DefaultRetryPolicy,
+	}
+	for _, opt := range opts {
+		opt(store)
 	}
+	return store
 }
 
-func (store *RealStore) execTx(ctx context.Context, fn func(*Queries) error) error {
-	tx, err := store.db.BeginTx(ctx, nil)
+func (store *RealStore) execTx(ctx context.Context, opts *sql.TxOptions, fn func(*Queries) error) error {
+	ctx, span := tracing.Tracer().Start(ctx, "execTx")
+	defer span.End()
+
+	tx, err := store.db.BeginTx(ctx, opts)
 	if err != nil {
+		recordSpanError(span, err)
 		return err
 	}
-	q := New(tx)
+	q := New(traceDB(tx))
 	err = fn(q)
 
 	if err != nil {
+		slog.WarnContext(ctx, "rolling back transaction", "error", err)
+		span.SetAttributes(attribute.String("db.rollback_reason", err.Error()))
+		recordSpanError(span, err)
 		if rbErr := tx.Rollback(); rbErr != nil {
 			return fmt.Errorf("rollback Err: %v, TxErr: %v", rbErr, err)
 		}
 		return err
 	}
-	return tx.Commit()
+	err = tx.Commit()
+	recordSpanError(span, err)
+	return err
 
 }
 
@@ -44,6 +82,9 @@ type TransferTxParams struct {
 	FromAccountID int64
 	ToAccountID   int64
 	Amount        int64
+	Limits        util.TransferLimits // checked against the sender's tier; nil skips the check
+	CoolingOff    CoolingOff          // zero Period skips the check
+	Fees          util.FeeSchedule    // charged on top of Amount; nil charges no fee
 }
 
 type TransferTxResult struct {
@@ -52,72 +93,156 @@ type TransferTxResult struct {
 	ToAccount   Account
 	FromEntry   Entry
 	ToEntry     Entry
+	Fee         *FeeLeg // only when a fee was charged
 }
 
 func (store *RealStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
+	ctx, span := tracing.Tracer().Start(ctx, "TransferTx", trace.WithAttributes(
+		attribute.Int64("transfer.from_account_id", arg.FromAccountID),
+		attribute.Int64("transfer.to_account_id", arg.ToAccountID),
+		attribute.Int64("transfer.amount", arg.Amount),
+	))
+	defer span.End()
+
+	start := time.Now()
 	var result TransferTxResult
-	err := store.execTx(ctx, func(q *Queries) error {
+	opts := &sql.TxOptions{Isolation: store.transferIsolation}
+	err := store.execTxWithRetry(ctx, opts, func(q *Queries) error {
 		var err error
-		result.Transfer, err = q.CreateTransfers(ctx, CreateTransfersParams(arg))
-		if err != nil {
-			return err
+		result, err = transferTx(ctx, q, arg)
+		return err
+	})
+
+	metrics.TransferTxDuration.Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.TransferTxRollbacks.Inc()
+		recordSpanError(span, err)
+	}
+	return result, err
+}
+
+// transferTx moves money between two accounts and records it. It runs against
+// any Querier so RealStore and MemoryStore share the same steps; the caller
+// provides the transaction.
+func transferTx(ctx context.Context, q Querier, arg TransferTxParams) (result TransferTxResult, err error) {
+	var fee, feeAccountID int64
+	ids := []int64{arg.FromAccountID, arg.ToAccountID}
+	if arg.Fees != nil {
+		if fee, feeAccountID, err = quoteTransferFee(ctx, q, arg); err != nil {
+			return result, err
 		}
-		result.FromEntry, err = q.CreateEntries(ctx, CreateEntriesParams{
+		if fee > 0 {
+			ids = append(ids, feeAccountID)
+		}
+	}
+	if err = lockActiveAccounts(ctx, q, ids...); err != nil {
+		return result, err
+	}
+	if err = checkTransfer(ctx, q, arg); err != nil {
+		return result, err
+	}
+
+	result.Transfer, err = q.CreateTransfers(ctx, CreateTransfersParams{
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		Fee:           fee,
+		FeeAccountID:  sql.NullInt64{Int64: feeAccountID, Valid: fee > 0},
+	})
+	if err != nil {
+		return result, err
+	}
+	result.FromEntry, err = q.CreateEntries(ctx, CreateEntriesParams{
+		AccountID: arg.FromAccountID,
+		Amount:    -arg.Amount,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result.ToEntry, err = q.CreateEntries(ctx, CreateEntriesParams{
+		AccountID: arg.ToAccountID,
+		Amount:    arg.Amount,
+	})
+
+	if err != nil {
+		return result, err
+	}
+
+	if fee > 0 {
+		result.Fee = &FeeLeg{}
+		result.Fee.FromEntry, err = q.CreateEntries(ctx, CreateEntriesParams{
 			AccountID: arg.FromAccountID,
-			Amount:    -arg.Amount,
+			Amount:    -fee,
 		})
 		if err != nil {
-			return err
+			return result, err
 		}
+		result.Fee.ToEntry, err = q.CreateEntries(ctx, CreateEntriesParams{
+			AccountID: feeAccountID,
+			Amount:    fee,
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+	//
+	//
+	//
+	//Update Account and Balance
 
-		result.ToEntry, err = q.CreateEntries(ctx, CreateEntriesParams{
-			AccountID: arg.ToAccountID,
-			Amount:    arg.Amount,
+	if arg.FromAccountID < arg.ToAccountID {
+		result.FromAccount, err = q.AddBalance(ctx, AddBalanceParams{
+			ID:      arg.FromAccountID,
+			Balance: -(arg.Amount + fee),
 		})
+		if err != nil {
+			return result, err
+		}
 
+		result.ToAccount, err = q.AddBalance(ctx, AddBalanceParams{
+			ID:      arg.ToAccountID,
+			Balance: +arg.Amount,
+		})
 		if err != nil {
-			return err
+			return result, err
 		}
-		//
-		//
-		//
-		//Update Account and Balance
-
-		if arg.FromAccountID < arg.ToAccountID {
-			result.FromAccount, err = q.AddBalance(ctx, AddBalanceParams{
-				ID:      arg.FromAccountID,
-				Balance: -arg.Amount,
-			})
-			if err != nil {
-				return err
-			}
-
-			result.ToAccount, err = q.AddBalance(ctx, AddBalanceParams{
-				ID:      arg.ToAccountID,
-				Balance: +arg.Amount,
-			})
-			if err != nil {
-				return err
-			}
-		} else {
-			result.ToAccount, err = q.AddBalance(ctx, AddBalanceParams{
-				ID:      arg.ToAccountID,
-				Balance: +arg.Amount,
-			})
-			if err != nil {
-				return err
-			}
-
-			result.FromAccount, err = q.AddBalance(ctx, AddBalanceParams{
-				ID:      arg.FromAccountID,
-				Balance: -arg.Amount,
-			})
-			if err != nil {
-				return err
-			}
+	} else {
+		result.ToAccount, err = q.AddBalance(ctx, AddBalanceParams{
+			ID:      arg.ToAccountID,
+			Balance: +arg.Amount,
+		})
+		if err != nil {
+			return result, err
 		}
-		return nil
-	})
 
+		result.FromAccount, err = q.AddBalance(ctx, AddBalanceParams{
+			ID:      arg.FromAccountID,
+			Balance: -(arg.Amount + fee),
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if fee > 0 {
+		if _, err = q.AddBalance(ctx, AddBalanceParams{ID: feeAccountID, Balance: fee}); err != nil {
+			return result, err
+		}
+	}
+
+	info := AuditInfoFromContext(ctx)
+	fromBefore, toBefore := result.FromAccount, result.ToAccount
+	fromBefore.Balance += arg.Amount + fee
+	toBefore.Balance -= arg.Amount
+	err = appendAuditEvent(ctx, q, AppendAuditEventParams{
+		Actor:     info.Actor,
+		Action:    "transfer.create",
+		Resource:  fmt.Sprintf("transfers/%d", result.Transfer.ID),
+		IP:        info.IP,
+		RequestID: info.RequestID,
+		Before:    map[string]Account{"from_account": fromBefore, "to_account": toBefore},
+		After:     result,
+	})
 	return result, err
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -191,3 +192,44 @@ func TestTransactionDeadlock(t *testing.T) {
 	require.Equal(t, account2.Balance, UpdatedAccount2.Balance)
 	
 }
+
+func TestTransferTxUnrelatedAccountsDontBlock(t *testing.T) {
+	store := newRealStore(TestDb)
+
+	account1 := CreateRandomAccount(t)
+	account2 := CreateRandomAccount(t)
+	account3 := CreateRandomAccount(t)
+	account4 := CreateRandomAccount(t)
+
+	// hold a transfer between account1 and account2 open while a transfer
+	// between account3 and account4 runs; with the audit chain sequenced
+	// after commit nothing is shared between them
+	err := store.execTx(context.Background(), nil, func(q *Queries) error {
+		_, err := transferTx(context.Background(), q, TransferTxParams{
+			FromAccountID: account1.ID,
+			ToAccountID:   account2.ID,
+			Amount:        10,
+		})
+		if err != nil {
+			return err
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			_, err := store.TransferTx(context.Background(), TransferTxParams{
+				FromAccountID: account3.ID,
+				ToAccountID:   account4.ID,
+				Amount:        10,
+			})
+			done <- err
+		}()
+
+		select {
+		case err := <-done:
+			return err
+		case <-time.After(5 * time.Second):
+			return fmt.Errorf("transfer between unrelated accounts blocked")
+		}
+	})
+	require.NoError(t, err)
+}
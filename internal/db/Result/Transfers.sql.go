@@ -7,27 +7,92 @@ package Anuskh
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
+const countTransfersInRange = `-- name: CountTransfersInRange :one
+SELECT COUNT(*) FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = $1
+  AND t.created_at >= $2
+  AND t.amount >= $3
+  AND t.amount < $4
+  AND t.amount % $5::bigint = 0
+  AND a.currency = $6
+`
+
+type CountTransfersInRangeParams struct {
+	Owner     string    `json:"owner"`
+	Since     time.Time `json:"since"`
+	MinAmount int64     `json:"min_amount"`
+	MaxAmount int64     `json:"max_amount"`
+	RoundTo   int64     `json:"round_to"`
+	Currency  string    `json:"currency"`
+}
+
+func (q *Queries) CountTransfersInRange(ctx context.Context, arg CountTransfersInRangeParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countTransfersInRange,
+		arg.Owner,
+		arg.Since,
+		arg.MinAmount,
+		arg.MaxAmount,
+		arg.RoundTo,
+		arg.Currency,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countTransfersTo = `-- name: CountTransfersTo :one
+SELECT COUNT(*) FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = $1
+  AND t.to_account_id = $2
+`
+
+type CountTransfersToParams struct {
+	Owner       string `json:"owner"`
+	ToAccountID int64  `json:"to_account_id"`
+}
+
+func (q *Queries) CountTransfersTo(ctx context.Context, arg CountTransfersToParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countTransfersTo, arg.Owner, arg.ToAccountID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createTransfers = `-- name: CreateTransfers :one
 INSERT INTO transfers (
   from_account_id,
   to_account_id,
-  amount
+  amount,
+  fee,
+  fee_account_id
 ) VALUES (
-  $1, $2, $3
+  $1, $2, $3, $4, $5
 )
-RETURNING id, from_account_id, to_account_id, amount, created_at
+RETURNING id, from_account_id, to_account_id, amount, created_at, fee, fee_account_id
 `
 
 type CreateTransfersParams struct {
-	FromAccountID int64 `json:"from_account_id"`
-	ToAccountID   int64 `json:"to_account_id"`
-	Amount        int64 `json:"amount"`
+	FromAccountID int64         `json:"from_account_id"`
+	ToAccountID   int64         `json:"to_account_id"`
+	Amount        int64         `json:"amount"`
+	Fee           int64         `json:"fee"`
+	FeeAccountID  sql.NullInt64 `json:"fee_account_id"`
 }
 
 func (q *Queries) CreateTransfers(ctx context.Context, arg CreateTransfersParams) (Transfer, error) {
-	row := q.db.QueryRowContext(ctx, createTransfers, arg.FromAccountID, arg.ToAccountID, arg.Amount)
+	row := q.db.QueryRowContext(ctx, createTransfers,
+		arg.FromAccountID,
+		arg.ToAccountID,
+		arg.Amount,
+		arg.Fee,
+		arg.FeeAccountID,
+	)
 	var i Transfer
 	err := row.Scan(
 		&i.ID,
@@ -35,6 +100,8 @@ func (q *Queries) CreateTransfers(ctx context.Context, arg CreateTransfersParams
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Fee,
+		&i.FeeAccountID,
 	)
 	return i, err
 }
@@ -49,8 +116,42 @@ func (q *Queries) DeleteTransfers(ctx context.Context, id int64) error {
 	return err
 }
 
+const getTransferActivity = `-- name: GetTransferActivity :one
+SELECT
+  COUNT(*) AS transfers,
+  COUNT(DISTINCT t.to_account_id) AS recipients,
+  COUNT(*) FILTER (WHERE t.to_account_id = $1) AS to_account_transfers
+FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = $2
+  AND t.created_at >= $3
+`
+
+type GetTransferActivityParams struct {
+	ToAccountID int64     `json:"to_account_id"`
+	Owner       string    `json:"owner"`
+	Since       time.Time `json:"since"`
+}
+
+type GetTransferActivityRow struct {
+	Transfers          int64 `json:"transfers"`
+	Recipients         int64 `json:"recipients"`
+	ToAccountTransfers int64 `json:"to_account_transfers"`
+}
+
+func (q *Queries) GetTransferActivity(ctx context.Context, arg GetTransferActivityParams) (GetTransferActivityRow, error) {
+	row := q.db.QueryRowContext(ctx, getTransferActivity, arg.ToAccountID, arg.Owner, arg.Since)
+	var i GetTransferActivityRow
+	err := row.Scan(
+		&i.Transfers,
+		&i.Recipients,
+		&i.ToAccountTransfers,
+	)
+	return i, err
+}
+
 const getTransfers = `-- name: GetTransfers :one
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, fee, fee_account_id FROM transfers
 WHERE id = $1 
 LIMIT 1
 `
@@ -64,12 +165,14 @@ func (q *Queries) GetTransfers(ctx context.Context, id int64) (Transfer, error)
 		&i.ToAccountID,
 		&i.Amount,
 		&i.CreatedAt,
+		&i.Fee,
+		&i.FeeAccountID,
 	)
 	return i, err
 }
 
 const listTransfers = `-- name: ListTransfers :many
-SELECT id, from_account_id, to_account_id, amount, created_at FROM transfers
+SELECT id, from_account_id, to_account_id, amount, created_at, fee, fee_account_id FROM transfers
 WHERE from_account_id = $3
    OR to_account_id = $4
 ORDER BY id
@@ -104,6 +207,8 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 			&i.ToAccountID,
 			&i.Amount,
 			&i.CreatedAt,
+			&i.Fee,
+			&i.FeeAccountID,
 		); err != nil {
 			return nil, err
 		}
@@ -118,6 +223,70 @@ func (q *Queries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([
 	return items, nil
 }
 
+const sumTransfers = `-- name: SumTransfers :one
+SELECT
+  COALESCE(SUM(t.amount) FILTER (WHERE t.created_at >= $1), 0)::bigint AS daily_amount,
+  COALESCE(SUM(t.amount) FILTER (WHERE t.created_at >= $2), 0)::bigint AS monthly_amount,
+  COUNT(*) FILTER (WHERE t.created_at >= $3) AS hourly_count
+FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = $4
+  AND a.currency = $5
+  AND t.created_at >= LEAST($2, $3)
+`
+
+type SumTransfersParams struct {
+	DayStart   time.Time `json:"day_start"`
+	MonthStart time.Time `json:"month_start"`
+	HourStart  time.Time `json:"hour_start"`
+	Owner      string    `json:"owner"`
+	Currency   string    `json:"currency"`
+}
+
+type SumTransfersRow struct {
+	DailyAmount   int64 `json:"daily_amount"`
+	MonthlyAmount int64 `json:"monthly_amount"`
+	HourlyCount   int64 `json:"hourly_count"`
+}
+
+func (q *Queries) SumTransfers(ctx context.Context, arg SumTransfersParams) (SumTransfersRow, error) {
+	row := q.db.QueryRowContext(ctx, sumTransfers,
+		arg.DayStart,
+		arg.MonthStart,
+		arg.HourStart,
+		arg.Owner,
+		arg.Currency,
+	)
+	var i SumTransfersRow
+	err := row.Scan(
+		&i.DailyAmount,
+		&i.MonthlyAmount,
+		&i.HourlyCount,
+	)
+	return i, err
+}
+
+const sumTransfersTo = `-- name: SumTransfersTo :one
+SELECT COALESCE(SUM(t.amount), 0)::bigint FROM transfers t
+JOIN accounts a ON a.id = t.from_account_id
+WHERE a.owner = $1
+  AND t.to_account_id = $2
+  AND t.created_at >= $3
+`
+
+type SumTransfersToParams struct {
+	Owner       string    `json:"owner"`
+	ToAccountID int64     `json:"to_account_id"`
+	Since       time.Time `json:"since"`
+}
+
+func (q *Queries) SumTransfersTo(ctx context.Context, arg SumTransfersToParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, sumTransfersTo, arg.Owner, arg.ToAccountID, arg.Since)
+	var sum int64
+	err := row.Scan(&sum)
+	return sum, err
+}
+
 const updateTransfers = `-- name: UpdateTransfers :exec
 UPDATE transfers
 set amount = $2
@@ -0,0 +1,98 @@
+package Anuskh
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/nilesh0729/Transactly/internal/util"
+)
+
+const (
+	AccountChecking = "checking"
+	AccountSavings  = "savings"
+)
+
+// accountNumberAttempts bounds the retries when a generated account number is
+// already taken, which with 10^16 of them should never take more than one.
+const accountNumberAttempts = 3
+
+var ErrAccountLimitReached = errors.New("account limit reached")
+
+// CreateAccountTxParams takes no AccountNumber: CreateAccountTx generates one.
+type CreateAccountTxParams struct {
+	CreateAccountsParams
+	MaxAccounts           int64 // accounts the owner may hold, closed ones included; 0 means no limit
+	MaxUnverifiedAccounts int64 // the same until the owner's KYC is verified
+}
+
+func (store *RealStore) CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (Account, error) {
+	return withAccountNumber(arg, func(arg CreateAccountTxParams) (Account, error) {
+		var account Account
+		err := store.execTx(ctx, nil, func(q *Queries) error {
+			var err error
+			account, err = createAccountTx(ctx, q, arg)
+			return err
+		})
+		return account, err
+	})
+}
+
+// withAccountNumber gives arg a fresh account number for each call to create,
+// trying again when the number is taken. Each attempt is its own transaction,
+// as Postgres won't run anything else in one that hit the unique violation.
+func withAccountNumber(arg CreateAccountTxParams, create func(arg CreateAccountTxParams) (Account, error)) (Account, error) {
+	for attempt := 1; ; attempt++ {
+		number, err := util.GenerateAccountNumber()
+		if err != nil {
+			return Account{}, err
+		}
+		arg.AccountNumber = number
+
+		account, err := create(arg)
+		if attempt < accountNumberAttempts && ErrorConstraint(err) == "accounts_account_number_idx" {
+			continue
+		}
+		return account, err
+	}
+}
+
+// createAccountTx locks the owner's row so two concurrent creates can't both
+// pass the count and push the owner over the limit.
+func createAccountTx(ctx context.Context, q Querier, arg CreateAccountTxParams) (Account, error) {
+	if arg.MaxAccounts > 0 || arg.MaxUnverifiedAccounts > 0 {
+		user, err := q.GetUserForUpdate(ctx, arg.Owner)
+		if err != nil {
+			return Account{}, err
+		}
+		limit, hint := arg.MaxAccounts, ""
+		if user.KycStatus != KycVerified && arg.MaxUnverifiedAccounts > 0 && (limit == 0 || arg.MaxUnverifiedAccounts < limit) {
+			limit, hint = arg.MaxUnverifiedAccounts, ", verify your identity to open more"
+		}
+
+		count, err := q.CountAccounts(ctx, arg.Owner)
+		if err != nil {
+			return Account{}, err
+		}
+		if limit > 0 && count >= limit {
+			return Account{}, fmt.Errorf("%w: %s already holds %d accounts%s", ErrAccountLimitReached, arg.Owner, count, hint)
+		}
+	}
+	return q.CreateAccounts(ctx, arg.CreateAccountsParams)
+}
+
+// BalanceMoney returns the account's balance in its currency.
+func (account Account) BalanceMoney() util.Money {
+	return util.NewMoney(account.Balance, account.Currency)
+}
+
+// MarshalJSON adds balance_money, the balance in major units alongside the
+// minor ones, to the generated fields.
+func (account Account) MarshalJSON() ([]byte, error) {
+	type plain Account
+	return json.Marshal(struct {
+		plain
+		BalanceMoney util.Money `json:"balance_money"`
+	}{plain(account), account.BalanceMoney()})
+}
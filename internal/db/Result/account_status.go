@@ -0,0 +1,84 @@
+package Anuskh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+const (
+	AccountActive = "active"
+	AccountFrozen = "frozen" // by an admin, no money moves in or out until unfrozen
+	AccountClosed = "closed" // by the owner, once empty
+)
+
+var (
+	ErrAccountNotActive        = errors.New("account is not active")
+	ErrInvalidStatusTransition = errors.New("invalid account status transition")
+	ErrAccountNotEmpty         = errors.New("account balance must be zero to close it")
+)
+
+// accountTransitions lists the statuses each status can move to. A frozen
+// account has to be unfrozen before it can be closed.
+var accountTransitions = map[string][]string{
+	AccountActive: {AccountFrozen, AccountClosed},
+	AccountFrozen: {AccountActive},
+	AccountClosed: {AccountActive},
+}
+
+type ChangeAccountStatusParams struct {
+	AccountID int64
+	From      string // the status the caller expects the account to be in
+	To        string
+	Reason    string
+}
+
+func (store *RealStore) ChangeAccountStatus(ctx context.Context, arg ChangeAccountStatusParams) (Account, error) {
+	var account Account
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		var err error
+		account, err = changeAccountStatus(ctx, q, arg)
+		return err
+	})
+	return account, err
+}
+
+// changeAccountStatus locks the account so the transition and the balance
+// check can't race a transfer or another change. Naming the expected status
+// keeps, say, an unfreeze from reopening an account that was closed meanwhile.
+func changeAccountStatus(ctx context.Context, q Querier, arg ChangeAccountStatusParams) (Account, error) {
+	account, err := q.GetAccountsForUpdate(ctx, arg.AccountID)
+	if err != nil {
+		return Account{}, err
+	}
+	if account.Status != arg.From || !slices.Contains(accountTransitions[arg.From], arg.To) {
+		return Account{}, fmt.Errorf("%w: account %d is %s, cannot move it from %s to %s",
+			ErrInvalidStatusTransition, account.ID, account.Status, arg.From, arg.To)
+	}
+	if arg.To == AccountClosed && account.Balance != 0 {
+		return Account{}, ErrAccountNotEmpty
+	}
+	return q.UpdateAccountStatus(ctx, UpdateAccountStatusParams{
+		ID:           arg.AccountID,
+		Status:       arg.To,
+		StatusReason: arg.Reason,
+	})
+}
+
+// lockActiveAccounts locks both sides of a transfer, lowest id first like the
+// balance updates that follow, and refuses any that isn't active.
+func lockActiveAccounts(ctx context.Context, q Querier, ids ...int64) error {
+	ids = slices.Clone(ids)
+	slices.Sort(ids)
+	for _, id := range slices.Compact(ids) {
+		account, err := q.GetAccountsForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if account.Status != AccountActive {
+			return fmt.Errorf("%w: account %d is %s", ErrAccountNotActive, id, account.Status)
+		}
+	}
+	return nil
+}
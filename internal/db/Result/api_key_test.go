@@ -0,0 +1,62 @@
+package Anuskh
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func CreateRandomApiKey(t *testing.T) ApiKey {
+	user := CreateRandomUser(t)
+	_, prefix, secret, err := util.GenerateAPIKey()
+	require.NoError(t, err)
+
+	arg := CreateApiKeyParams{
+		Owner:        user.Username,
+		Name:         util.RandomOwner(),
+		Prefix:       prefix,
+		HashedSecret: util.HashSecret(secret),
+		Scopes:       []string{"accounts:read"},
+		AllowedIps:   []string{"10.0.0.0/8"},
+	}
+
+	key, err := testQueries.CreateApiKey(context.Background(), arg)
+	require.NoError(t, err)
+	require.NotEmpty(t, key)
+
+	require.Equal(t, arg.Owner, key.Owner)
+	require.Equal(t, arg.Prefix, key.Prefix)
+	require.Equal(t, arg.HashedSecret, key.HashedSecret)
+	require.Equal(t, arg.Scopes, key.Scopes)
+	require.Equal(t, arg.AllowedIps, key.AllowedIps)
+	require.False(t, key.ExpiresAt.Valid)
+	require.NotZero(t, key.CreatedAt)
+
+	return key
+}
+
+func TestCreateApiKey(t *testing.T) {
+	CreateRandomApiKey(t)
+}
+
+func TestGetApiKeyByPrefix(t *testing.T) {
+	key := CreateRandomApiKey(t)
+
+	key2, err := testQueries.GetApiKeyByPrefix(context.Background(), key.Prefix)
+	require.NoError(t, err)
+	require.Equal(t, key, key2)
+}
+
+func TestDeleteApiKey(t *testing.T) {
+	key := CreateRandomApiKey(t)
+
+	rows, err := testQueries.DeleteApiKey(context.Background(), DeleteApiKeyParams{ID: key.ID, Owner: util.RandomOwner()})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	rows, err = testQueries.DeleteApiKey(context.Background(), DeleteApiKeyParams{ID: key.ID, Owner: key.Owner})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+}
@@ -0,0 +1,224 @@
+package Anuskh
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type auditInfoKey struct{}
+
+// AuditInfo describes who is behind a change. It travels in the context so
+// TransferTx can attribute the ledger events it writes.
+type AuditInfo struct {
+	Actor     string
+	IP        string
+	RequestID string
+}
+
+func WithAuditInfo(ctx context.Context, info AuditInfo) context.Context {
+	return context.WithValue(ctx, auditInfoKey{}, info)
+}
+
+func AuditInfoFromContext(ctx context.Context) AuditInfo {
+	info, _ := ctx.Value(auditInfoKey{}).(AuditInfo)
+	if info.Actor == "" {
+		info.Actor = "system"
+	}
+	return info
+}
+
+type AppendAuditEventParams struct {
+	Actor     string
+	Action    string
+	Resource  string
+	IP        string
+	RequestID string
+	Status    int32
+	Before    any
+	After     any
+}
+
+// AppendAuditEvent queues an event for the hash chain. It commits on its own;
+// inside a transaction use appendAuditEvent so the event is kept or dropped
+// with the change it records.
+func (store *RealStore) AppendAuditEvent(ctx context.Context, arg AppendAuditEventParams) error {
+	return appendAuditEvent(ctx, store.Queries, arg)
+}
+
+// appendAuditEvent queues the event without touching the chain, so writers
+// never wait on each other for it. SequenceAuditEvents links it in after the
+// surrounding transaction commits.
+func appendAuditEvent(ctx context.Context, q Querier, arg AppendAuditEventParams) error {
+	before, err := json.Marshal(arg.Before)
+	if err != nil {
+		return fmt.Errorf("cannot encode audit snapshot: %w", err)
+	}
+	after, err := json.Marshal(arg.After)
+	if err != nil {
+		return fmt.Errorf("cannot encode audit snapshot: %w", err)
+	}
+
+	return q.QueueAuditEvent(ctx, QueueAuditEventParams{
+		Actor:     arg.Actor,
+		Action:    arg.Action,
+		Resource:  arg.Resource,
+		Ip:        arg.IP,
+		RequestID: arg.RequestID,
+		Status:    arg.Status,
+		Before:    before,
+		After:     after,
+		CreatedAt: time.Now().UTC().Truncate(time.Microsecond), // postgres keeps microseconds
+	})
+}
+
+func (store *RealStore) SequenceAuditEvents(ctx context.Context, limit int32) ([]AuditEvent, error) {
+	var events []AuditEvent
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		var err error
+		events, err = sequenceAuditEvents(ctx, q, limit)
+		return err
+	})
+	return events, err
+}
+
+// sequenceAuditEvents moves up to limit queued events, oldest first, onto the
+// end of the hash chain. Only sequencers take the advisory lock, and only for
+// this short transaction, so two of them can't claim the same predecessor.
+func sequenceAuditEvents(ctx context.Context, q Querier, limit int32) ([]AuditEvent, error) {
+	if err := q.LockAuditChain(ctx); err != nil {
+		return nil, err
+	}
+
+	queued, err := q.ListQueuedAuditEvents(ctx, limit)
+	if err != nil || len(queued) == 0 {
+		return nil, err
+	}
+	prevHash, err := q.GetLastAuditEventHash(ctx)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+
+	events := make([]AuditEvent, 0, len(queued))
+	ids := make([]int64, 0, len(queued))
+	for _, item := range queued {
+		event := AuditEvent{
+			Actor:     item.Actor,
+			Action:    item.Action,
+			Resource:  item.Resource,
+			Ip:        item.Ip,
+			RequestID: item.RequestID,
+			Status:    item.Status,
+			Before:    item.Before,
+			After:     item.After,
+			PrevHash:  prevHash,
+			CreatedAt: item.CreatedAt.UTC(),
+		}
+		event.Hash = AuditEventHash(event)
+
+		event, err = q.CreateAuditEvent(ctx, CreateAuditEventParams{
+			Actor:     event.Actor,
+			Action:    event.Action,
+			Resource:  event.Resource,
+			Ip:        event.Ip,
+			RequestID: event.RequestID,
+			Status:    event.Status,
+			Before:    event.Before,
+			After:     event.After,
+			PrevHash:  event.PrevHash,
+			Hash:      event.Hash,
+			CreatedAt: event.CreatedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+		ids = append(ids, item.ID)
+		prevHash = event.Hash
+	}
+
+	if _, err := q.DeleteQueuedAuditEvents(ctx, ids); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// auditSequenceBatch is how many queued events one sequencing transaction
+// takes.
+const auditSequenceBatch = 500
+
+// RunAuditSequencer chains the queued audit events every period until ctx is
+// done, draining the queue each time.
+func RunAuditSequencer(ctx context.Context, store Store, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for {
+			events, err := store.SequenceAuditEvents(ctx, auditSequenceBatch)
+			if err != nil {
+				slog.ErrorContext(ctx, "audit sequencing failed", "error", err)
+				break
+			}
+			if len(events) < auditSequenceBatch {
+				break
+			}
+		}
+	}
+}
+
+// AuditEventHash computes the chained hash of an event from its content and its
+// predecessor's hash. The ID is left out since it is assigned on insert.
+func AuditEventHash(event AuditEvent) string {
+	fields := []string{
+		event.PrevHash,
+		event.Actor,
+		event.Action,
+		event.Resource,
+		event.Ip,
+		event.RequestID,
+		strconv.Itoa(int(event.Status)),
+		string(event.Before),
+		string(event.After),
+		event.CreatedAt.UTC().Format(time.RFC3339Nano),
+	}
+	h := sha256.New()
+	for _, f := range fields {
+		// length-prefix every field so values can't bleed into each other
+		fmt.Fprintf(h, "%d:%s;", len(f), f)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// VerifyAuditChain checks that every event's hash matches its content and that
+// consecutive events are linked. prevHash is the hash of the event preceding
+// the first one, or "" when starting from the beginning of the log.
+func VerifyAuditChain(events []AuditEvent, prevHash string) error {
+	var problems []string
+	for _, event := range events {
+		if event.PrevHash != prevHash {
+			problems = append(problems, fmt.Sprintf("event %d does not link to its predecessor", event.ID))
+		}
+		if AuditEventHash(event) != event.Hash {
+			problems = append(problems, fmt.Sprintf("event %d was modified", event.ID))
+		}
+		prevHash = event.Hash
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("audit chain is broken: %s", strings.Join(problems, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package Anuskh
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func CreateRandomAuditEvent(t *testing.T) AuditEvent {
+	store := NewTxConn(TestDb)
+
+	arg := AppendAuditEventParams{
+		Actor:     util.RandomOwner(),
+		Action:    "POST /accounts",
+		Resource:  "/accounts",
+		IP:        "127.0.0.1",
+		RequestID: util.RandomString(12),
+		Status:    200,
+		After:     map[string]int64{"balance": util.RandomBalance()},
+	}
+
+	require.NoError(t, store.AppendAuditEvent(context.Background(), arg))
+	events, err := store.SequenceAuditEvents(context.Background(), 100)
+	require.NoError(t, err)
+	require.NotEmpty(t, events)
+	event := events[len(events)-1]
+
+	require.Equal(t, arg.Actor, event.Actor)
+	require.Equal(t, arg.Action, event.Action)
+	require.Equal(t, arg.Resource, event.Resource)
+	require.Equal(t, arg.Status, event.Status)
+	require.Equal(t, AuditEventHash(event), event.Hash)
+	require.NotZero(t, event.CreatedAt)
+
+	return event
+}
+
+func TestAppendAuditEvent(t *testing.T) {
+	event1 := CreateRandomAuditEvent(t)
+	event2 := CreateRandomAuditEvent(t)
+
+	require.Equal(t, event1.Hash, event2.PrevHash)
+}
+
+func TestListAuditEvents(t *testing.T) {
+	first := CreateRandomAuditEvent(t)
+	for i := 0; i < 4; i++ {
+		CreateRandomAuditEvent(t)
+	}
+
+	previous, err := testQueries.GetAuditEventBefore(context.Background(), first.ID)
+	require.NoError(t, err)
+
+	events, err := testQueries.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		AfterID:  previous.ID,
+		PageSize: 5,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 5)
+	require.Equal(t, first.ID, events[0].ID)
+	require.NoError(t, VerifyAuditChain(events, previous.Hash))
+
+	events[2].Actor = "someone-else"
+	require.Error(t, VerifyAuditChain(events, previous.Hash))
+}
+
+func TestAuditEventsAppendOnly(t *testing.T) {
+	event := CreateRandomAuditEvent(t)
+
+	_, err := TestDb.ExecContext(context.Background(), `UPDATE audit_events SET actor = 'mallory' WHERE id = $1`, event.ID)
+	require.Error(t, err)
+
+	_, err = TestDb.ExecContext(context.Background(), `DELETE FROM audit_events WHERE id = $1`, event.ID)
+	require.Error(t, err)
+}
@@ -0,0 +1,75 @@
+package Anuskh
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nilesh0729/Transactly/internal/util"
+)
+
+var ErrPayeeCoolingOff = errors.New("recipient is still cooling off")
+
+// CoolingOff holds what a sender can move to a newly saved payee to a reduced
+// limit until the payee has been saved for Period. Accounts that aren't saved
+// as a payee at all are only capped when UnsavedLimit is set.
+type CoolingOff struct {
+	Period       time.Duration
+	Limit        int64 // in cents, see util.ScaleCents; sent to the account within Period, counting this transfer
+	UnsavedLimit int64 // the same for accounts that aren't saved payees, 0 for no cap
+}
+
+// checkCoolingOff refuses a transfer that would take what the sender has sent
+// to the destination over the last Period past the cooling-off limit. Like
+// checkTransferLimits it locks the sender's user row first, so concurrent
+// transfers to the same account can't each fit under the limit on their own.
+func checkCoolingOff(ctx context.Context, q Querier, arg TransferTxParams) error {
+	from, err := q.GetAccounts(ctx, arg.FromAccountID)
+	if err != nil {
+		return err
+	}
+	to, err := q.GetAccounts(ctx, arg.ToAccountID)
+	if err != nil {
+		return err
+	}
+	if to.Owner == from.Owner {
+		return nil
+	}
+	if _, err = q.GetUserForUpdate(ctx, from.Owner); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	cents := arg.CoolingOff.Limit
+	payee, err := q.GetPayeeByAccount(ctx, GetPayeeByAccountParams{Owner: from.Owner, AccountID: to.ID})
+	switch {
+	case err == nil:
+		if !now.Before(payee.CreatedAt.Add(arg.CoolingOff.Period)) {
+			return nil
+		}
+	case err == sql.ErrNoRows:
+		if arg.CoolingOff.UnsavedLimit <= 0 {
+			return nil
+		}
+		cents = arg.CoolingOff.UnsavedLimit
+	default:
+		return err
+	}
+
+	sent, err := q.SumTransfersTo(ctx, SumTransfersToParams{
+		Owner:       from.Owner,
+		ToAccountID: to.ID,
+		Since:       now.Add(-arg.CoolingOff.Period),
+	})
+	if err != nil {
+		return err
+	}
+	limit := util.ScaleCents(cents, from.Currency)
+	if sent+arg.Amount > limit {
+		return fmt.Errorf("%w: %d %s of the %d that can be sent to this account within %s remains",
+			ErrPayeeCoolingOff, max(limit-sent, 0), from.Currency, limit, arg.CoolingOff.Period)
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package Anuskh
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorCode(t *testing.T) {
+	require.Equal(t, UniqueViolation, ErrorCode(&pq.Error{Code: UniqueViolation}))
+	require.Equal(t, ForeignKeyViolation, ErrorCode(&pgconn.PgError{Code: ForeignKeyViolation}))
+	require.Equal(t, serializationFailure, ErrorCode(fmt.Errorf("transfer: %w", &pgconn.PgError{Code: serializationFailure})))
+	require.Empty(t, ErrorCode(errors.New("boom")))
+	require.Empty(t, ErrorCode(nil))
+}
+
+func TestErrorConstraint(t *testing.T) {
+	require.Equal(t, "accounts_account_number_idx", ErrorConstraint(&pq.Error{Code: UniqueViolation, Constraint: "accounts_account_number_idx"}))
+	require.Equal(t, "accounts_owner_fkey", ErrorConstraint(fmt.Errorf("create: %w", &pgconn.PgError{Code: ForeignKeyViolation, ConstraintName: "accounts_owner_fkey"})))
+	require.Empty(t, ErrorConstraint(errors.New("boom")))
+}
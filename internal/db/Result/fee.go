@@ -0,0 +1,56 @@
+package Anuskh
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nilesh0729/Transactly/internal/util"
+)
+
+// FeeLeg moves the fee charged on a transfer from the sender to the house fee
+// account. The transfer itself records the fee and the account.
+type FeeLeg struct {
+	FromEntry Entry
+	ToEntry   Entry
+}
+
+// TransferFee is the fee on sending amount from one account to another and
+// the account it is credited to. Moving money between a user's own accounts,
+// or to and from the fee account itself, is free.
+func TransferFee(fees util.FeeSchedule, from Account, to Account, amount int64) (fee int64, accountID int64) {
+	if from.Owner == to.Owner {
+		return 0, 0
+	}
+	fee, accountID = fees.Quote(from.Currency, amount)
+	if accountID == from.ID || accountID == to.ID {
+		return 0, 0
+	}
+	return fee, accountID
+}
+
+// quoteTransferFee reads both accounts of a transfer to work out its fee. It
+// runs before they are locked, so the fee account can be locked in order with
+// them; owners and currencies never change.
+func quoteTransferFee(ctx context.Context, q Querier, arg TransferTxParams) (fee int64, accountID int64, err error) {
+	from, err := q.GetAccounts(ctx, arg.FromAccountID)
+	if err != nil {
+		return 0, 0, err
+	}
+	to, err := q.GetAccounts(ctx, arg.ToAccountID)
+	if err != nil {
+		return 0, 0, err
+	}
+	fee, accountID = TransferFee(arg.Fees, from, to, arg.Amount)
+	if fee == 0 {
+		return 0, 0, nil
+	}
+
+	feeAccount, err := q.GetAccounts(ctx, accountID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("fee account %d: %w", accountID, err)
+	}
+	if feeAccount.Currency != from.Currency {
+		return 0, 0, fmt.Errorf("fee account %d holds %s, not %s", accountID, feeAccount.Currency, from.Currency)
+	}
+	return fee, accountID, nil
+}
@@ -0,0 +1,17 @@
+package Anuskh
+
+import (
+	"context"
+)
+
+// Ping checks that the database is reachable.
+func (store *RealStore) Ping(ctx context.Context) error {
+	return store.db.PingContext(ctx)
+}
+
+// MigrationVersion reads the schema_migrations table kept by golang-migrate.
+func (store *RealStore) MigrationVersion(ctx context.Context) (version int64, dirty bool, err error) {
+	row := store.db.QueryRowContext(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`)
+	err = row.Scan(&version, &dirty)
+	return
+}
@@ -0,0 +1,88 @@
+package Anuskh
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/nilesh0729/Transactly/internal/util"
+)
+
+const (
+	HeldTransferPending  = "pending"
+	HeldTransferApproved = "approved"
+	HeldTransferRejected = "rejected"
+)
+
+var ErrHeldTransferReviewed = errors.New("held transfer was already reviewed")
+
+type ReviewHeldTransferTxParams struct {
+	ID         int64
+	Approve    bool
+	Reviewer   string
+	Note       string
+	Limits     util.TransferLimits // applied to an approved transfer as to any other
+	CoolingOff CoolingOff          // checked again, as payments may have been made since it was held
+	Fees       util.FeeSchedule    // charged on an approved transfer as on any other
+}
+
+type ReviewHeldTransferTxResult struct {
+	HeldTransfer HeldTransfer
+	Transfer     *TransferTxResult // only when approved
+}
+
+func (store *RealStore) ReviewHeldTransferTx(ctx context.Context, arg ReviewHeldTransferTxParams) (ReviewHeldTransferTxResult, error) {
+	var result ReviewHeldTransferTxResult
+	opts := &sql.TxOptions{Isolation: store.transferIsolation}
+	err := store.execTxWithRetry(ctx, opts, func(q *Queries) error {
+		var err error
+		result, err = reviewHeldTransferTx(ctx, q, arg)
+		return err
+	})
+	return result, err
+}
+
+// reviewHeldTransferTx approves or rejects a transfer the fraud rules held.
+// Approving makes the transfer in the same transaction, so a transfer that
+// can no longer go through, say because an account was frozen meanwhile,
+// stays pending rather than being marked approved.
+func reviewHeldTransferTx(ctx context.Context, q Querier, arg ReviewHeldTransferTxParams) (ReviewHeldTransferTxResult, error) {
+	var result ReviewHeldTransferTxResult
+
+	held, err := q.GetHeldTransferForUpdate(ctx, arg.ID)
+	if err != nil {
+		return result, err
+	}
+	if held.Status != HeldTransferPending {
+		return result, fmt.Errorf("%w: held transfer %d is %s", ErrHeldTransferReviewed, held.ID, held.Status)
+	}
+
+	status := HeldTransferRejected
+	var transferID sql.NullInt64
+	if arg.Approve {
+		transfer, err := transferTx(ctx, q, TransferTxParams{
+			FromAccountID: held.FromAccountID,
+			ToAccountID:   held.ToAccountID,
+			Amount:        held.Amount,
+			Limits:        arg.Limits,
+			CoolingOff:    arg.CoolingOff,
+			Fees:          arg.Fees,
+		})
+		if err != nil {
+			return result, err
+		}
+		result.Transfer = &transfer
+		status = HeldTransferApproved
+		transferID = sql.NullInt64{Int64: transfer.Transfer.ID, Valid: true}
+	}
+
+	result.HeldTransfer, err = q.ReviewHeldTransfer(ctx, ReviewHeldTransferParams{
+		ID:         held.ID,
+		Status:     status,
+		ReviewedBy: arg.Reviewer,
+		ReviewNote: arg.Note,
+		TransferID: transferID,
+	})
+	return result, err
+}
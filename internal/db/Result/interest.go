@@ -0,0 +1,181 @@
+package Anuskh
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/nilesh0729/Transactly/internal/util"
+)
+
+var ErrNoInterestAccrued = errors.New("no interest accrued")
+
+// PostInterestTxParams posts the interest AccountID accrued before the end of
+// the month starting at Period.
+type PostInterestTxParams struct {
+	AccountID        int64
+	FundingAccountID int64
+	Period           time.Time
+}
+
+type PostInterestTxResult struct {
+	Posting        InterestPosting
+	Account        Account
+	FundingAccount Account
+	Entry          Entry // zero, like FundingEntry, when under a minor unit was due
+	FundingEntry   Entry
+}
+
+func (store *RealStore) PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error) {
+	var result PostInterestTxResult
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		var err error
+		result, err = postInterestTx(ctx, q, arg)
+		return err
+	})
+	return result, err
+}
+
+// postInterestTx credits the whole minor units of the account's unposted
+// accruals, plus what the last posting carried over, from the funding
+// account. The micros left over carry into the next posting, so rounding
+// never loses interest. It locks both accounts, lowest id first like a
+// transfer, and the accruals, so the same days can't be posted twice.
+// Interest already earned is paid whatever the account's status; the funding
+// account has to be active.
+func postInterestTx(ctx context.Context, q Querier, arg PostInterestTxParams) (PostInterestTxResult, error) {
+	var result PostInterestTxResult
+	if arg.AccountID == arg.FundingAccountID {
+		return result, fmt.Errorf("account %d cannot fund its own interest", arg.AccountID)
+	}
+
+	ids := []int64{arg.AccountID, arg.FundingAccountID}
+	slices.Sort(ids)
+	for _, id := range ids {
+		account, err := q.GetAccountsForUpdate(ctx, id)
+		if err != nil {
+			return result, err
+		}
+		if id == arg.AccountID {
+			result.Account = account
+		} else {
+			result.FundingAccount = account
+		}
+	}
+	if result.FundingAccount.Status != AccountActive {
+		return result, fmt.Errorf("%w: funding account %d is %s", ErrAccountNotActive, arg.FundingAccountID, result.FundingAccount.Status)
+	}
+	if result.FundingAccount.Currency != result.Account.Currency {
+		return result, fmt.Errorf("funding account %d holds %s, not %s", arg.FundingAccountID, result.FundingAccount.Currency, result.Account.Currency)
+	}
+
+	period := util.MonthStart(arg.Period)
+	before := period.AddDate(0, 1, 0)
+	accruals, err := q.ListUnpostedInterestAccruals(ctx, ListUnpostedInterestAccrualsParams{
+		AccountID: arg.AccountID,
+		Before:    before,
+	})
+	if err != nil {
+		return result, err
+	}
+	if len(accruals) == 0 {
+		return result, fmt.Errorf("%w: account %d before %s", ErrNoInterestAccrued, arg.AccountID, before.Format(time.DateOnly))
+	}
+
+	var micros int64
+	last, err := q.GetLatestInterestPosting(ctx, arg.AccountID)
+	switch {
+	case err == nil:
+		micros = last.CarryMicros
+	case err != sql.ErrNoRows:
+		return result, err
+	}
+	for _, accrual := range accruals {
+		micros += accrual.AmountMicros
+	}
+	amount, carry := util.SplitMicros(micros)
+
+	result.Posting, err = q.CreateInterestPosting(ctx, CreateInterestPostingParams{
+		AccountID:        arg.AccountID,
+		FundingAccountID: arg.FundingAccountID,
+		Period:           period,
+		Amount:           amount,
+		CarryMicros:      carry,
+	})
+	if err != nil {
+		return result, err
+	}
+	_, err = q.SetInterestAccrualsPosted(ctx, SetInterestAccrualsPostedParams{
+		PostingID: sql.NullInt64{Int64: result.Posting.ID, Valid: true},
+		AccountID: arg.AccountID,
+		Before:    before,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	if amount > 0 {
+		result.FundingEntry, err = q.CreateEntries(ctx, CreateEntriesParams{
+			AccountID: arg.FundingAccountID,
+			Amount:    -amount,
+		})
+		if err != nil {
+			return result, err
+		}
+		result.Entry, err = q.CreateEntries(ctx, CreateEntriesParams{
+			AccountID: arg.AccountID,
+			Amount:    amount,
+		})
+		if err != nil {
+			return result, err
+		}
+		for _, id := range ids {
+			if id == arg.AccountID {
+				result.Account, err = q.AddBalance(ctx, AddBalanceParams{ID: id, Balance: amount})
+			} else {
+				result.FundingAccount, err = q.AddBalance(ctx, AddBalanceParams{ID: id, Balance: -amount})
+			}
+			if err != nil {
+				return result, err
+			}
+		}
+	}
+
+	info := AuditInfoFromContext(ctx)
+	err = appendAuditEvent(ctx, q, AppendAuditEventParams{
+		Actor:     info.Actor,
+		Action:    "interest.post",
+		Resource:  fmt.Sprintf("accounts/%d/interest/%s", arg.AccountID, period.Format("2006-01")),
+		IP:        info.IP,
+		RequestID: info.RequestID,
+		After:     result.Posting,
+	})
+	return result, err
+}
+
+// AccrueInterest records a day's interest for account at rate, on its balance
+// at the end of day in UTC.
+func AccrueInterest(ctx context.Context, q Querier, account Account, rate util.InterestRate, day time.Time) (InterestAccrual, error) {
+	day = day.UTC().Truncate(24 * time.Hour)
+	balance, err := q.GetAccountBalanceAt(ctx, GetAccountBalanceAtParams{
+		At:        day.AddDate(0, 0, 1),
+		AccountID: account.ID,
+	})
+	if err != nil {
+		return InterestAccrual{}, err
+	}
+	micros, err := util.DailyInterestMicros(balance, rate.Bps, day)
+	if err != nil {
+		return InterestAccrual{}, err
+	}
+	return q.CreateInterestAccrual(ctx, CreateInterestAccrualParams{
+		AccountID:    account.ID,
+		AccrualDate:  day,
+		Balance:      balance,
+		RateBps:      rate.Bps,
+		AmountMicros: micros,
+	})
+}
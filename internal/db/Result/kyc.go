@@ -0,0 +1,92 @@
+package Anuskh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/nilesh0729/Transactly/internal/util"
+)
+
+const (
+	KycUnverified = "unverified"
+	KycPending    = "pending" // documents submitted, waiting for an admin
+	KycVerified   = "verified"
+	KycRejected   = "rejected" // the user may upload more documents and submit again
+)
+
+const (
+	KycPassport       = "passport"
+	KycIDCard         = "id_card"
+	KycDrivingLicence = "driving_licence"
+	KycProofOfAddress = "proof_of_address"
+)
+
+var (
+	ErrInvalidKycTransition = errors.New("invalid kyc status transition")
+	ErrKycNoDocuments       = errors.New("upload at least one document before submitting")
+)
+
+// kycTransitions lists the statuses each status can move to. Users submit,
+// admins decide; a verified user stays verified.
+var kycTransitions = map[string][]string{
+	KycUnverified: {KycPending},
+	KycPending:    {KycVerified, KycRejected},
+	KycRejected:   {KycPending},
+}
+
+type ChangeKycStatusParams struct {
+	Username string
+	To       string
+	Reviewer string // empty when the user submits
+	Note     string
+}
+
+func (store *RealStore) ChangeKycStatus(ctx context.Context, arg ChangeKycStatusParams) (User, error) {
+	var user User
+	err := store.execTx(ctx, nil, func(q *Queries) error {
+		var err error
+		user, err = changeKycStatus(ctx, q, arg)
+		return err
+	})
+	return user, err
+}
+
+// changeKycStatus locks the user so a submission and a review, or two
+// reviews, can't both act on the same status.
+func changeKycStatus(ctx context.Context, q Querier, arg ChangeKycStatusParams) (User, error) {
+	user, err := q.GetUserForUpdate(ctx, arg.Username)
+	if err != nil {
+		return User{}, err
+	}
+	if !slices.Contains(kycTransitions[user.KycStatus], arg.To) {
+		return User{}, fmt.Errorf("%w: %s is %s, cannot move to %s",
+			ErrInvalidKycTransition, user.Username, user.KycStatus, arg.To)
+	}
+	if arg.To == KycPending {
+		count, err := q.CountKycDocuments(ctx, arg.Username)
+		if err != nil {
+			return User{}, err
+		}
+		if count == 0 {
+			return User{}, ErrKycNoDocuments
+		}
+	}
+	return q.UpdateUserKyc(ctx, UpdateUserKycParams{
+		Username:      arg.Username,
+		KycStatus:     arg.To,
+		KycNote:       arg.Note,
+		KycReviewedBy: arg.Reviewer,
+	})
+}
+
+// TransferLimitFor is the limit on user sending in currency. Until their KYC
+// is verified the unverified limit applies on top of their tier's.
+func TransferLimitFor(limits util.TransferLimits, user User, currency string) util.TransferLimit {
+	limit := limits.For(user.Tier, currency)
+	if user.KycStatus != KycVerified {
+		limit = limit.Stricter(limits.For(util.UnverifiedTier, currency))
+	}
+	return limit
+}
@@ -0,0 +1,1754 @@
+package Anuskh
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/nilesh0729/Transactly/internal/db/migration"
+	"github.com/nilesh0729/Transactly/internal/util"
+)
+
+// MemoryStore keeps everything in process memory. It enforces the same keys,
+// unique and foreign key constraints as the schema, and reports violations as
+// *pq.Error with the matching SQLSTATE, so callers can't tell it apart from
+// RealStore. Transactions run one at a time and write in place, keeping an
+// undo log that puts back the rows they touched if they fail. Like Postgres
+// sequences, IDs handed out by a failed transaction are not reused.
+type MemoryStore struct {
+	*memQueries
+	mu sync.Mutex
+}
+
+func NewMemoryStore() Store {
+	store := &MemoryStore{}
+	store.memQueries = &memQueries{data: newMemData(), lock: &store.mu}
+	return store
+}
+
+func (store *MemoryStore) execTx(ctx context.Context, fn func(q Querier) error) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	tx := &memQueries{data: store.data, lock: noopLocker{}, undo: &undoLog{}}
+	committed := false
+	defer func() {
+		if !committed {
+			tx.undo.rollback()
+		}
+	}()
+	if err := fn(tx); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+func (store *MemoryStore) TransferTx(ctx context.Context, arg TransferTxParams) (TransferTxResult, error) {
+	var result TransferTxResult
+	err := store.execTx(ctx, func(q Querier) error {
+		var err error
+		result, err = transferTx(ctx, q, arg)
+		return err
+	})
+	return result, err
+}
+
+func (store *MemoryStore) AppendAuditEvent(ctx context.Context, arg AppendAuditEventParams) error {
+	return appendAuditEvent(ctx, store.memQueries, arg)
+}
+
+func (store *MemoryStore) SequenceAuditEvents(ctx context.Context, limit int32) ([]AuditEvent, error) {
+	var events []AuditEvent
+	err := store.execTx(ctx, func(q Querier) error {
+		var err error
+		events, err = sequenceAuditEvents(ctx, q, limit)
+		return err
+	})
+	return events, err
+}
+
+func (store *MemoryStore) ChangeAccountStatus(ctx context.Context, arg ChangeAccountStatusParams) (Account, error) {
+	var account Account
+	err := store.execTx(ctx, func(q Querier) error {
+		var err error
+		account, err = changeAccountStatus(ctx, q, arg)
+		return err
+	})
+	return account, err
+}
+
+func (store *MemoryStore) CreateAccountTx(ctx context.Context, arg CreateAccountTxParams) (Account, error) {
+	return withAccountNumber(arg, func(arg CreateAccountTxParams) (Account, error) {
+		var account Account
+		err := store.execTx(ctx, func(q Querier) error {
+			var err error
+			account, err = createAccountTx(ctx, q, arg)
+			return err
+		})
+		return account, err
+	})
+}
+
+func (store *MemoryStore) ReviewHeldTransferTx(ctx context.Context, arg ReviewHeldTransferTxParams) (ReviewHeldTransferTxResult, error) {
+	var result ReviewHeldTransferTxResult
+	err := store.execTx(ctx, func(q Querier) error {
+		var err error
+		result, err = reviewHeldTransferTx(ctx, q, arg)
+		return err
+	})
+	return result, err
+}
+
+func (store *MemoryStore) CheckTransferTx(ctx context.Context, arg TransferTxParams) error {
+	return store.execTx(ctx, func(q Querier) error {
+		return checkTransfer(ctx, q, arg)
+	})
+}
+
+func (store *MemoryStore) ChangeKycStatus(ctx context.Context, arg ChangeKycStatusParams) (User, error) {
+	var user User
+	err := store.execTx(ctx, func(q Querier) error {
+		var err error
+		user, err = changeKycStatus(ctx, q, arg)
+		return err
+	})
+	return user, err
+}
+
+func (store *MemoryStore) PostInterestTx(ctx context.Context, arg PostInterestTxParams) (PostInterestTxResult, error) {
+	var result PostInterestTxResult
+	err := store.execTx(ctx, func(q Querier) error {
+		var err error
+		result, err = postInterestTx(ctx, q, arg)
+		return err
+	})
+	return result, err
+}
+
+func (store *MemoryStore) Ping(ctx context.Context) error {
+	return nil
+}
+
+// MigrationVersion reports the latest migration, since there is no schema to
+// fall behind.
+func (store *MemoryStore) MigrationVersion(ctx context.Context) (int64, bool, error) {
+	latest, err := migration.Latest()
+	return int64(latest), false, err
+}
+
+type noopLocker struct{}
+
+func (noopLocker) Lock()   {}
+func (noopLocker) Unlock() {}
+
+type consentKey struct {
+	username string
+	clientID string
+}
+
+type memData struct {
+	seq              map[string]int64
+	users            map[string]User
+	accounts         map[int64]Account
+	entries          map[int64]Entry
+	transfers        map[int64]Transfer
+	apiKeys          map[int64]ApiKey
+	payees           map[int64]Payee
+	heldTransfers    map[int64]HeldTransfer
+	screenings       map[int64]ScreeningResult
+	kycDocuments     map[int64]KycDocument
+	interestAccruals map[int64]InterestAccrual
+	interestPostings map[int64]InterestPosting
+	currencies       map[string]CurrencySetting
+	auditEvents      []AuditEvent       // ordered by id
+	auditQueue       []QueuedAuditEvent // ordered by id
+	oauthClients     map[string]OauthClient
+	oauthCodes       map[string]OauthAuthorizationCode
+	oauthConsents    map[consentKey]OauthConsent
+	oauthTokens      map[string]OauthToken
+	rateLimitBuckets map[string]RateLimitBucket
+}
+
+func newMemData() *memData {
+	return &memData{
+		seq:              map[string]int64{},
+		users:            map[string]User{},
+		accounts:         map[int64]Account{},
+		entries:          map[int64]Entry{},
+		transfers:        map[int64]Transfer{},
+		apiKeys:          map[int64]ApiKey{},
+		payees:           map[int64]Payee{},
+		heldTransfers:    map[int64]HeldTransfer{},
+		screenings:       map[int64]ScreeningResult{},
+		kycDocuments:     map[int64]KycDocument{},
+		interestAccruals: map[int64]InterestAccrual{},
+		interestPostings: map[int64]InterestPosting{},
+		currencies:       map[string]CurrencySetting{},
+		oauthClients:     map[string]OauthClient{},
+		oauthCodes:       map[string]OauthAuthorizationCode{},
+		oauthConsents:    map[consentKey]OauthConsent{},
+		oauthTokens:      map[string]OauthToken{},
+		rateLimitBuckets: map[string]RateLimitBucket{},
+	}
+}
+
+func (data *memData) nextID(table string) int64 {
+	data.seq[table]++
+	return data.seq[table]
+}
+
+// memQueries implements Querier over memData. lock is the store mutex for
+// standalone calls and a no-op inside a transaction, which already holds it.
+// undo is only set inside a transaction.
+type memQueries struct {
+	data *memData
+	lock sync.Locker
+	undo *undoLog
+}
+
+// undoLog records how to reverse each write a transaction makes, oldest
+// first. Rows are stored by value and their slices are never modified in
+// place, so keeping the old value is enough to put a row back.
+type undoLog []func()
+
+func (log *undoLog) rollback() {
+	for i := len(*log) - 1; i >= 0; i-- {
+		(*log)[i]()
+	}
+	*log = nil
+}
+
+func (q *memQueries) onRollback(fn func()) {
+	if q.undo != nil {
+		*q.undo = append(*q.undo, fn)
+	}
+}
+
+// setRow stores row under key in table.
+func setRow[K comparable, V any](q *memQueries, table map[K]V, key K, row V) {
+	old, existed := table[key]
+	q.onRollback(func() {
+		if existed {
+			table[key] = old
+		} else {
+			delete(table, key)
+		}
+	})
+	table[key] = row
+}
+
+// deleteRow removes key from table.
+func deleteRow[K comparable, V any](q *memQueries, table map[K]V, key K) {
+	old, existed := table[key]
+	if !existed {
+		return
+	}
+	q.onRollback(func() { table[key] = old })
+	delete(table, key)
+}
+
+// setSlice replaces one of the ordered tables.
+func setSlice[T any](q *memQueries, table *[]T, rows []T) {
+	old := *table
+	q.onRollback(func() { *table = old })
+	*table = rows
+}
+
+var _ Querier = (*memQueries)(nil)
+
+// now matches what Postgres stores in a timestamp column.
+func now() time.Time {
+	return time.Now().UTC().Truncate(time.Microsecond)
+}
+
+func uniqueViolation(constraint string) error {
+	return &pq.Error{
+		Code:       "23505",
+		Message:    fmt.Sprintf("duplicate key value violates unique constraint %q", constraint),
+		Constraint: constraint,
+	}
+}
+
+func foreignKeyViolation(table string, constraint string) error {
+	return &pq.Error{
+		Code:       "23503",
+		Message:    fmt.Sprintf("insert or update on table %q violates foreign key constraint %q", table, constraint),
+		Table:      table,
+		Constraint: constraint,
+	}
+}
+
+func notNullViolation(table string, column string) error {
+	return &pq.Error{
+		Code:    "23502",
+		Message: fmt.Sprintf("null value in column %q of relation %q violates not-null constraint", column, table),
+		Table:   table,
+		Column:  column,
+	}
+}
+
+func checkViolation(table string, constraint string) error {
+	return &pq.Error{
+		Code:       "23514",
+		Message:    fmt.Sprintf("new row for relation %q violates check constraint %q", table, constraint),
+		Table:      table,
+		Constraint: constraint,
+	}
+}
+
+// page applies LIMIT and OFFSET to rows already in order.
+func page[T any](rows []T, limit int32, offset int32) ([]T, error) {
+	if limit < 0 {
+		return nil, &pq.Error{Code: "2201W", Message: "LIMIT must not be negative"}
+	}
+	if offset < 0 {
+		return nil, &pq.Error{Code: "2201X", Message: "OFFSET must not be negative"}
+	}
+	if int(offset) >= len(rows) {
+		return []T{}, nil
+	}
+	rows = rows[offset:]
+	if int(limit) < len(rows) {
+		rows = rows[:limit]
+	}
+	return rows, nil
+}
+
+func sortedByID[T any](rows map[int64]T, keep func(T) bool) []T {
+	ids := make([]int64, 0, len(rows))
+	for id, row := range rows {
+		if keep(row) {
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+
+	result := make([]T, len(ids))
+	for i, id := range ids {
+		result[i] = rows[id]
+	}
+	return result
+}
+
+// accounts
+
+func (q *memQueries) CreateAccounts(ctx context.Context, arg CreateAccountsParams) (Account, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if _, ok := q.data.users[arg.Owner]; !ok {
+		return Account{}, foreignKeyViolation("accounts", "accounts_owner_fkey")
+	}
+	if !slices.Contains([]string{AccountChecking, AccountSavings}, arg.AccountType) {
+		return Account{}, checkViolation("accounts", "accounts_account_type_check")
+	}
+	for _, account := range q.data.accounts {
+		if arg.Nickname != "" && account.Owner == arg.Owner && account.Nickname == arg.Nickname {
+			return Account{}, uniqueViolation("accounts_owner_nickname_idx")
+		}
+		if account.AccountNumber == arg.AccountNumber {
+			return Account{}, uniqueViolation("accounts_account_number_idx")
+		}
+	}
+
+	account := Account{
+		ID:            q.data.nextID("accounts"),
+		Owner:         arg.Owner,
+		Balance:       arg.Balance,
+		Currency:      arg.Currency,
+		CreatedAt:     now(),
+		Status:        AccountActive,
+		Nickname:      arg.Nickname,
+		AccountType:   arg.AccountType,
+		AccountNumber: arg.AccountNumber,
+	}
+	setRow(q, q.data.accounts, account.ID, account)
+	return account, nil
+}
+
+func (q *memQueries) GetAccounts(ctx context.Context, id int64) (Account, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	account, ok := q.data.accounts[id]
+	if !ok {
+		return Account{}, sql.ErrNoRows
+	}
+	return account, nil
+}
+
+func (q *memQueries) GetAccountByNumber(ctx context.Context, accountNumber string) (Account, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for _, account := range q.data.accounts {
+		if account.AccountNumber == accountNumber {
+			return account, nil
+		}
+	}
+	return Account{}, sql.ErrNoRows
+}
+
+func (q *memQueries) GetAccountsForUpdate(ctx context.Context, id int64) (Account, error) {
+	return q.GetAccounts(ctx, id)
+}
+
+func (q *memQueries) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	accounts := sortedByID(q.data.accounts, func(account Account) bool {
+		return account.Owner == arg.Owner && (!arg.AccountType.Valid || account.AccountType == arg.AccountType.String)
+	})
+	// stable, so ties stay in id order like the trailing ORDER BY id
+	slices.SortStableFunc(accounts, func(a, b Account) int {
+		switch arg.SortBy {
+		case "nickname":
+			return strings.Compare(a.Nickname, b.Nickname)
+		case "balance":
+			return cmp.Compare(a.Balance, b.Balance)
+		case "created_at":
+			return a.CreatedAt.Compare(b.CreatedAt)
+		}
+		return 0
+	})
+	return page(accounts, arg.Limit, arg.Offset)
+}
+
+func (q *memQueries) ListInterestBearingAccounts(ctx context.Context, arg ListInterestBearingAccountsParams) ([]Account, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	accounts := sortedByID(q.data.accounts, func(account Account) bool {
+		return account.AccountType == arg.AccountType && account.Currency == arg.Currency &&
+			account.Status != AccountClosed && account.ID > arg.AfterID
+	})
+	return page(accounts, arg.PageSize, 0)
+}
+
+func (q *memQueries) CountAccounts(ctx context.Context, owner string) (int64, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	var count int64
+	for _, account := range q.data.accounts {
+		if account.Owner == owner {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (q *memQueries) UpdateAccounts(ctx context.Context, arg UpdateAccountsParams) (Account, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	account, ok := q.data.accounts[arg.ID]
+	if !ok {
+		return Account{}, sql.ErrNoRows
+	}
+	account.Balance = arg.Balance
+	setRow(q, q.data.accounts, arg.ID, account)
+	return account, nil
+}
+
+func (q *memQueries) AddBalance(ctx context.Context, arg AddBalanceParams) (Account, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	account, ok := q.data.accounts[arg.ID]
+	if !ok {
+		return Account{}, sql.ErrNoRows
+	}
+	account.Balance += arg.Balance
+	setRow(q, q.data.accounts, arg.ID, account)
+	return account, nil
+}
+
+func (q *memQueries) UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if !slices.Contains([]string{AccountActive, AccountFrozen, AccountClosed}, arg.Status) {
+		return Account{}, checkViolation("accounts", "accounts_status_check")
+	}
+	account, ok := q.data.accounts[arg.ID]
+	if !ok {
+		return Account{}, sql.ErrNoRows
+	}
+	account.Status = arg.Status
+	account.StatusReason = arg.StatusReason
+	setRow(q, q.data.accounts, arg.ID, account)
+	return account, nil
+}
+
+func (q *memQueries) DeleteAccounts(ctx context.Context, id int64) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for _, entry := range q.data.entries {
+		if entry.AccountID == id {
+			return foreignKeyViolation("entries", "entries_account_id_fkey")
+		}
+	}
+	for _, transfer := range q.data.transfers {
+		if transfer.FromAccountID == id {
+			return foreignKeyViolation("transfers", "transfers_from_account_id_fkey")
+		}
+		if transfer.ToAccountID == id {
+			return foreignKeyViolation("transfers", "transfers_to_account_id_fkey")
+		}
+	}
+	for _, held := range q.data.heldTransfers {
+		if held.FromAccountID == id {
+			return foreignKeyViolation("held_transfers", "held_transfers_from_account_id_fkey")
+		}
+		if held.ToAccountID == id {
+			return foreignKeyViolation("held_transfers", "held_transfers_to_account_id_fkey")
+		}
+	}
+	deleteRow(q, q.data.accounts, id)
+	for payeeID, payee := range q.data.payees {
+		if payee.AccountID == id { // ON DELETE CASCADE
+			deleteRow(q, q.data.payees, payeeID)
+		}
+	}
+	return nil
+}
+
+// entries
+
+func (q *memQueries) CreateEntries(ctx context.Context, arg CreateEntriesParams) (Entry, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if _, ok := q.data.accounts[arg.AccountID]; !ok {
+		return Entry{}, foreignKeyViolation("entries", "entries_account_id_fkey")
+	}
+
+	entry := Entry{
+		ID:        q.data.nextID("entries"),
+		AccountID: arg.AccountID,
+		Amount:    arg.Amount,
+		CreatedAt: now(),
+	}
+	setRow(q, q.data.entries, entry.ID, entry)
+	return entry, nil
+}
+
+func (q *memQueries) GetEntries(ctx context.Context, id int64) (Entry, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	entry, ok := q.data.entries[id]
+	if !ok {
+		return Entry{}, sql.ErrNoRows
+	}
+	return entry, nil
+}
+
+func (q *memQueries) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	entries := sortedByID(q.data.entries, func(entry Entry) bool {
+		return entry.AccountID == arg.AccountID
+	})
+	return page(entries, arg.Limit, arg.Offset)
+}
+
+func (q *memQueries) GetLatestEntry(ctx context.Context, accountID int64) (Entry, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	var latest Entry
+	for _, entry := range q.data.entries {
+		if entry.AccountID != accountID {
+			continue
+		}
+		if c := entry.CreatedAt.Compare(latest.CreatedAt); c > 0 || (c == 0 && entry.ID > latest.ID) {
+			latest = entry
+		}
+	}
+	if latest.ID == 0 {
+		return Entry{}, sql.ErrNoRows
+	}
+	return latest, nil
+}
+
+func (q *memQueries) GetAccountBalanceAt(ctx context.Context, arg GetAccountBalanceAtParams) (int64, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	account, ok := q.data.accounts[arg.AccountID]
+	if !ok {
+		return 0, sql.ErrNoRows
+	}
+	balance := account.Balance
+	for _, entry := range q.data.entries {
+		if entry.AccountID == arg.AccountID && !entry.CreatedAt.Before(arg.At) {
+			balance -= entry.Amount
+		}
+	}
+	return balance, nil
+}
+
+func (q *memQueries) UpdateEntries(ctx context.Context, arg UpdateEntriesParams) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if entry, ok := q.data.entries[arg.ID]; ok {
+		entry.Amount = arg.Amount
+		setRow(q, q.data.entries, arg.ID, entry)
+	}
+	return nil
+}
+
+func (q *memQueries) DeleteEntries(ctx context.Context, accountID int64) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for id, entry := range q.data.entries {
+		if entry.AccountID == accountID {
+			deleteRow(q, q.data.entries, id)
+		}
+	}
+	return nil
+}
+
+// transfers
+
+func (q *memQueries) CreateTransfers(ctx context.Context, arg CreateTransfersParams) (Transfer, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if _, ok := q.data.accounts[arg.FromAccountID]; !ok {
+		return Transfer{}, foreignKeyViolation("transfers", "transfers_from_account_id_fkey")
+	}
+	if _, ok := q.data.accounts[arg.ToAccountID]; !ok {
+		return Transfer{}, foreignKeyViolation("transfers", "transfers_to_account_id_fkey")
+	}
+	if _, ok := q.data.accounts[arg.FeeAccountID.Int64]; arg.FeeAccountID.Valid && !ok {
+		return Transfer{}, foreignKeyViolation("transfers", "transfers_fee_account_id_fkey")
+	}
+	if arg.Fee < 0 {
+		return Transfer{}, checkViolation("transfers", "transfers_fee_check")
+	}
+
+	transfer := Transfer{
+		ID:            q.data.nextID("transfers"),
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		CreatedAt:     now(),
+		Fee:           arg.Fee,
+		FeeAccountID:  arg.FeeAccountID,
+	}
+	setRow(q, q.data.transfers, transfer.ID, transfer)
+	return transfer, nil
+}
+
+func (q *memQueries) GetTransfers(ctx context.Context, id int64) (Transfer, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	transfer, ok := q.data.transfers[id]
+	if !ok {
+		return Transfer{}, sql.ErrNoRows
+	}
+	return transfer, nil
+}
+
+func (q *memQueries) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	transfers := sortedByID(q.data.transfers, func(transfer Transfer) bool {
+		return transfer.FromAccountID == arg.FromAccountID || transfer.ToAccountID == arg.ToAccountID
+	})
+	return page(transfers, arg.Limit, arg.Offset)
+}
+
+func (q *memQueries) SumTransfers(ctx context.Context, arg SumTransfersParams) (SumTransfersRow, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	var row SumTransfersRow
+	for _, transfer := range q.data.transfers {
+		from := q.data.accounts[transfer.FromAccountID]
+		if from.Owner != arg.Owner || from.Currency != arg.Currency {
+			continue
+		}
+		if !transfer.CreatedAt.Before(arg.DayStart) {
+			row.DailyAmount += transfer.Amount
+		}
+		if !transfer.CreatedAt.Before(arg.MonthStart) {
+			row.MonthlyAmount += transfer.Amount
+		}
+		if !transfer.CreatedAt.Before(arg.HourStart) {
+			row.HourlyCount++
+		}
+	}
+	return row, nil
+}
+
+func (q *memQueries) SumTransfersTo(ctx context.Context, arg SumTransfersToParams) (int64, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	var sum int64
+	for _, transfer := range q.data.transfers {
+		if q.data.accounts[transfer.FromAccountID].Owner == arg.Owner && transfer.ToAccountID == arg.ToAccountID &&
+			!transfer.CreatedAt.Before(arg.Since) {
+			sum += transfer.Amount
+		}
+	}
+	return sum, nil
+}
+
+func (q *memQueries) GetTransferActivity(ctx context.Context, arg GetTransferActivityParams) (GetTransferActivityRow, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	var row GetTransferActivityRow
+	recipients := map[int64]bool{}
+	for _, transfer := range q.data.transfers {
+		if q.data.accounts[transfer.FromAccountID].Owner != arg.Owner || transfer.CreatedAt.Before(arg.Since) {
+			continue
+		}
+		row.Transfers++
+		recipients[transfer.ToAccountID] = true
+		if transfer.ToAccountID == arg.ToAccountID {
+			row.ToAccountTransfers++
+		}
+	}
+	row.Recipients = int64(len(recipients))
+	return row, nil
+}
+
+func (q *memQueries) CountTransfersInRange(ctx context.Context, arg CountTransfersInRangeParams) (int64, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if arg.RoundTo == 0 {
+		return 0, &pq.Error{Code: "22012", Message: "division by zero"}
+	}
+	var count int64
+	for _, transfer := range q.data.transfers {
+		from := q.data.accounts[transfer.FromAccountID]
+		if from.Owner == arg.Owner && from.Currency == arg.Currency && !transfer.CreatedAt.Before(arg.Since) &&
+			transfer.Amount >= arg.MinAmount && transfer.Amount < arg.MaxAmount && transfer.Amount%arg.RoundTo == 0 {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (q *memQueries) CountTransfersTo(ctx context.Context, arg CountTransfersToParams) (int64, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	var count int64
+	for _, transfer := range q.data.transfers {
+		if q.data.accounts[transfer.FromAccountID].Owner == arg.Owner && transfer.ToAccountID == arg.ToAccountID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (q *memQueries) UpdateTransfers(ctx context.Context, arg UpdateTransfersParams) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if transfer, ok := q.data.transfers[arg.ID]; ok {
+		transfer.Amount = arg.Amount
+		setRow(q, q.data.transfers, arg.ID, transfer)
+	}
+	return nil
+}
+
+func (q *memQueries) DeleteTransfers(ctx context.Context, id int64) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for _, held := range q.data.heldTransfers {
+		if held.TransferID.Valid && held.TransferID.Int64 == id {
+			return foreignKeyViolation("held_transfers", "held_transfers_transfer_id_fkey")
+		}
+	}
+	deleteRow(q, q.data.transfers, id)
+	return nil
+}
+
+// users
+
+func (q *memQueries) CreateUser(ctx context.Context, arg CreateUserParams) (User, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if _, ok := q.data.users[arg.Username]; ok {
+		return User{}, uniqueViolation("user_pkey")
+	}
+	for _, user := range q.data.users {
+		if user.Email == arg.Email {
+			return User{}, uniqueViolation("user_email_key")
+		}
+	}
+
+	user := User{
+		Username:          arg.Username,
+		HashedPassword:    arg.HashedPassword,
+		FullName:          arg.FullName,
+		Email:             arg.Email,
+		PasswordChangedAt: time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedAt:         now(),
+		Role:              util.DepositorRole,
+		Tier:              util.StandardTier,
+		KycStatus:         KycUnverified,
+		KycUpdatedAt:      now(),
+	}
+	setRow(q, q.data.users, user.Username, user)
+	return user, nil
+}
+
+func (q *memQueries) GetUser(ctx context.Context, username string) (User, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	user, ok := q.data.users[username]
+	if !ok {
+		return User{}, sql.ErrNoRows
+	}
+	return user, nil
+}
+
+func (q *memQueries) GetUserForUpdate(ctx context.Context, username string) (User, error) {
+	return q.GetUser(ctx, username)
+}
+
+func (q *memQueries) UpdateUserKyc(ctx context.Context, arg UpdateUserKycParams) (User, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if !slices.Contains([]string{KycUnverified, KycPending, KycVerified, KycRejected}, arg.KycStatus) {
+		return User{}, checkViolation("user", "user_kyc_status_check")
+	}
+	user, ok := q.data.users[arg.Username]
+	if !ok {
+		return User{}, sql.ErrNoRows
+	}
+	user.KycStatus = arg.KycStatus
+	user.KycNote = arg.KycNote
+	user.KycReviewedBy = arg.KycReviewedBy
+	user.KycUpdatedAt = now()
+	setRow(q, q.data.users, arg.Username, user)
+	return user, nil
+}
+
+func (q *memQueries) ListUsersByKycStatus(ctx context.Context, arg ListUsersByKycStatusParams) ([]User, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	var users []User
+	for _, user := range q.data.users {
+		if user.KycStatus == arg.KycStatus {
+			users = append(users, user)
+		}
+	}
+	slices.SortFunc(users, func(a, b User) int {
+		return cmp.Or(a.KycUpdatedAt.Compare(b.KycUpdatedAt), strings.Compare(a.Username, b.Username))
+	})
+	return page(users, arg.Limit, arg.Offset)
+}
+
+// kyc documents
+
+func (q *memQueries) CreateKycDocument(ctx context.Context, arg CreateKycDocumentParams) (KycDocument, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if !slices.Contains([]string{KycPassport, KycIDCard, KycDrivingLicence, KycProofOfAddress}, arg.Kind) {
+		return KycDocument{}, checkViolation("kyc_documents", "kyc_documents_kind_check")
+	}
+	if _, ok := q.data.users[arg.Owner]; !ok {
+		return KycDocument{}, foreignKeyViolation("kyc_documents", "kyc_documents_owner_fkey")
+	}
+	for _, document := range q.data.kycDocuments {
+		if document.BlobKey == arg.BlobKey {
+			return KycDocument{}, uniqueViolation("kyc_documents_blob_key_key")
+		}
+	}
+
+	document := KycDocument{
+		ID:          q.data.nextID("kyc_documents"),
+		Owner:       arg.Owner,
+		Kind:        arg.Kind,
+		ContentType: arg.ContentType,
+		SizeBytes:   arg.SizeBytes,
+		Sha256:      arg.Sha256,
+		BlobKey:     arg.BlobKey,
+		CreatedAt:   now(),
+	}
+	setRow(q, q.data.kycDocuments, document.ID, document)
+	return document, nil
+}
+
+func (q *memQueries) GetKycDocument(ctx context.Context, id int64) (KycDocument, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	document, ok := q.data.kycDocuments[id]
+	if !ok {
+		return KycDocument{}, sql.ErrNoRows
+	}
+	return document, nil
+}
+
+func (q *memQueries) ListKycDocuments(ctx context.Context, owner string) ([]KycDocument, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return sortedByID(q.data.kycDocuments, func(document KycDocument) bool {
+		return document.Owner == owner
+	}), nil
+}
+
+func (q *memQueries) CountKycDocuments(ctx context.Context, owner string) (int64, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	var count int64
+	for _, document := range q.data.kycDocuments {
+		if document.Owner == owner {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// interest
+
+func (q *memQueries) CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (InterestAccrual, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if _, ok := q.data.accounts[arg.AccountID]; !ok {
+		return InterestAccrual{}, foreignKeyViolation("interest_accruals", "interest_accruals_account_id_fkey")
+	}
+	if arg.AmountMicros < 0 {
+		return InterestAccrual{}, checkViolation("interest_accruals", "interest_accruals_amount_micros_check")
+	}
+	for _, accrual := range q.data.interestAccruals {
+		if accrual.AccountID == arg.AccountID && accrual.AccrualDate.Equal(arg.AccrualDate) {
+			return InterestAccrual{}, uniqueViolation("interest_accruals_account_id_accrual_date_idx")
+		}
+	}
+
+	accrual := InterestAccrual{
+		ID:           q.data.nextID("interest_accruals"),
+		AccountID:    arg.AccountID,
+		AccrualDate:  arg.AccrualDate,
+		Balance:      arg.Balance,
+		RateBps:      arg.RateBps,
+		AmountMicros: arg.AmountMicros,
+		CreatedAt:    now(),
+	}
+	setRow(q, q.data.interestAccruals, accrual.ID, accrual)
+	return accrual, nil
+}
+
+func (q *memQueries) GetLatestInterestAccrual(ctx context.Context, accountID int64) (InterestAccrual, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	var latest InterestAccrual
+	for _, accrual := range q.data.interestAccruals {
+		if accrual.AccountID == accountID && (latest.ID == 0 || accrual.AccrualDate.After(latest.AccrualDate)) {
+			latest = accrual
+		}
+	}
+	if latest.ID == 0 {
+		return InterestAccrual{}, sql.ErrNoRows
+	}
+	return latest, nil
+}
+
+func (q *memQueries) ListInterestAccruals(ctx context.Context, arg ListInterestAccrualsParams) ([]InterestAccrual, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	accruals := sortedByID(q.data.interestAccruals, func(accrual InterestAccrual) bool {
+		return accrual.AccountID == arg.AccountID
+	})
+	slices.SortFunc(accruals, func(a, b InterestAccrual) int {
+		return b.AccrualDate.Compare(a.AccrualDate)
+	})
+	return page(accruals, arg.Limit, arg.Offset)
+}
+
+func (q *memQueries) ListUnpostedInterestAccruals(ctx context.Context, arg ListUnpostedInterestAccrualsParams) ([]InterestAccrual, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	accruals := sortedByID(q.data.interestAccruals, func(accrual InterestAccrual) bool {
+		return accrual.AccountID == arg.AccountID && !accrual.PostingID.Valid && accrual.AccrualDate.Before(arg.Before)
+	})
+	slices.SortFunc(accruals, func(a, b InterestAccrual) int {
+		return a.AccrualDate.Compare(b.AccrualDate)
+	})
+	return accruals, nil
+}
+
+func (q *memQueries) ListAccountsWithUnpostedInterest(ctx context.Context, before time.Time) ([]int64, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	ids := []int64{}
+	for _, accrual := range q.data.interestAccruals {
+		if !accrual.PostingID.Valid && accrual.AccrualDate.Before(before) && !slices.Contains(ids, accrual.AccountID) {
+			ids = append(ids, accrual.AccountID)
+		}
+	}
+	slices.Sort(ids)
+	return ids, nil
+}
+
+func (q *memQueries) SetInterestAccrualsPosted(ctx context.Context, arg SetInterestAccrualsPostedParams) (int64, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if _, ok := q.data.interestPostings[arg.PostingID.Int64]; arg.PostingID.Valid && !ok {
+		return 0, foreignKeyViolation("interest_accruals", "interest_accruals_posting_id_fkey")
+	}
+	var n int64
+	for id, accrual := range q.data.interestAccruals {
+		if accrual.AccountID == arg.AccountID && !accrual.PostingID.Valid && accrual.AccrualDate.Before(arg.Before) {
+			accrual.PostingID = arg.PostingID
+			setRow(q, q.data.interestAccruals, id, accrual)
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (q *memQueries) CreateInterestPosting(ctx context.Context, arg CreateInterestPostingParams) (InterestPosting, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if _, ok := q.data.accounts[arg.AccountID]; !ok {
+		return InterestPosting{}, foreignKeyViolation("interest_postings", "interest_postings_account_id_fkey")
+	}
+	if _, ok := q.data.accounts[arg.FundingAccountID]; !ok {
+		return InterestPosting{}, foreignKeyViolation("interest_postings", "interest_postings_funding_account_id_fkey")
+	}
+	if arg.Amount < 0 {
+		return InterestPosting{}, checkViolation("interest_postings", "interest_postings_amount_check")
+	}
+	for _, posting := range q.data.interestPostings {
+		if posting.AccountID == arg.AccountID && posting.Period.Equal(arg.Period) {
+			return InterestPosting{}, uniqueViolation("interest_postings_account_id_period_idx")
+		}
+	}
+
+	posting := InterestPosting{
+		ID:               q.data.nextID("interest_postings"),
+		AccountID:        arg.AccountID,
+		FundingAccountID: arg.FundingAccountID,
+		Period:           arg.Period,
+		Amount:           arg.Amount,
+		CarryMicros:      arg.CarryMicros,
+		CreatedAt:        now(),
+	}
+	setRow(q, q.data.interestPostings, posting.ID, posting)
+	return posting, nil
+}
+
+func (q *memQueries) GetLatestInterestPosting(ctx context.Context, accountID int64) (InterestPosting, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	var latest InterestPosting
+	for _, posting := range q.data.interestPostings {
+		if posting.AccountID == accountID && (latest.ID == 0 || posting.Period.After(latest.Period)) {
+			latest = posting
+		}
+	}
+	if latest.ID == 0 {
+		return InterestPosting{}, sql.ErrNoRows
+	}
+	return latest, nil
+}
+
+func (q *memQueries) ListInterestPostings(ctx context.Context, arg ListInterestPostingsParams) ([]InterestPosting, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	postings := sortedByID(q.data.interestPostings, func(posting InterestPosting) bool {
+		return posting.AccountID == arg.AccountID
+	})
+	slices.SortFunc(postings, func(a, b InterestPosting) int {
+		return b.Period.Compare(a.Period)
+	})
+	return page(postings, arg.Limit, arg.Offset)
+}
+
+// currencies
+
+func (q *memQueries) ListCurrencySettings(ctx context.Context) ([]CurrencySetting, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	settings := slices.Collect(maps.Values(q.data.currencies))
+	slices.SortFunc(settings, func(a, b CurrencySetting) int {
+		return strings.Compare(a.Code, b.Code)
+	})
+	return settings, nil
+}
+
+func (q *memQueries) SetCurrencyEnabled(ctx context.Context, arg SetCurrencyEnabledParams) (CurrencySetting, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	setting := CurrencySetting{
+		Code:      arg.Code,
+		Enabled:   arg.Enabled,
+		UpdatedAt: now(),
+	}
+	setRow(q, q.data.currencies, setting.Code, setting)
+	return setting, nil
+}
+
+// api keys
+
+func (q *memQueries) CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if arg.Scopes == nil {
+		return ApiKey{}, notNullViolation("api_keys", "scopes")
+	}
+	if arg.AllowedIps == nil {
+		return ApiKey{}, notNullViolation("api_keys", "allowed_ips")
+	}
+	for _, key := range q.data.apiKeys {
+		if key.Prefix == arg.Prefix {
+			return ApiKey{}, uniqueViolation("api_keys_prefix_key")
+		}
+	}
+	if _, ok := q.data.users[arg.Owner]; !ok {
+		return ApiKey{}, foreignKeyViolation("api_keys", "api_keys_owner_fkey")
+	}
+
+	key := ApiKey{
+		ID:           q.data.nextID("api_keys"),
+		Owner:        arg.Owner,
+		Name:         arg.Name,
+		Prefix:       arg.Prefix,
+		HashedSecret: arg.HashedSecret,
+		Scopes:       slices.Clone(arg.Scopes),
+		AllowedIps:   slices.Clone(arg.AllowedIps),
+		ExpiresAt:    arg.ExpiresAt,
+		CreatedAt:    now(),
+	}
+	setRow(q, q.data.apiKeys, key.ID, key)
+	return key, nil
+}
+
+func (q *memQueries) GetApiKeyByPrefix(ctx context.Context, prefix string) (ApiKey, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for _, key := range q.data.apiKeys {
+		if key.Prefix == prefix {
+			return key, nil
+		}
+	}
+	return ApiKey{}, sql.ErrNoRows
+}
+
+func (q *memQueries) ListApiKeys(ctx context.Context, arg ListApiKeysParams) ([]ApiKey, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	keys := sortedByID(q.data.apiKeys, func(key ApiKey) bool {
+		return key.Owner == arg.Owner
+	})
+	return page(keys, arg.Limit, arg.Offset)
+}
+
+func (q *memQueries) DeleteApiKey(ctx context.Context, arg DeleteApiKeyParams) (int64, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	key, ok := q.data.apiKeys[arg.ID]
+	if !ok || key.Owner != arg.Owner {
+		return 0, nil
+	}
+	deleteRow(q, q.data.apiKeys, arg.ID)
+	return 1, nil
+}
+
+// payees
+
+func (q *memQueries) CreatePayee(ctx context.Context, arg CreatePayeeParams) (Payee, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for _, payee := range q.data.payees {
+		if payee.Owner != arg.Owner {
+			continue
+		}
+		if payee.AccountID == arg.AccountID {
+			return Payee{}, uniqueViolation("payees_owner_account_id_key")
+		}
+		if payee.Nickname == arg.Nickname {
+			return Payee{}, uniqueViolation("payees_owner_nickname_key")
+		}
+	}
+	if _, ok := q.data.users[arg.Owner]; !ok {
+		return Payee{}, foreignKeyViolation("payees", "payees_owner_fkey")
+	}
+	if _, ok := q.data.accounts[arg.AccountID]; !ok {
+		return Payee{}, foreignKeyViolation("payees", "payees_account_id_fkey")
+	}
+
+	payee := Payee{
+		ID:            q.data.nextID("payees"),
+		Owner:         arg.Owner,
+		AccountID:     arg.AccountID,
+		AccountNumber: arg.AccountNumber,
+		Nickname:      arg.Nickname,
+		Currency:      arg.Currency,
+		CreatedAt:     now(),
+	}
+	setRow(q, q.data.payees, payee.ID, payee)
+	return payee, nil
+}
+
+func (q *memQueries) GetPayee(ctx context.Context, id int64) (Payee, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	payee, ok := q.data.payees[id]
+	if !ok {
+		return Payee{}, sql.ErrNoRows
+	}
+	return payee, nil
+}
+
+func (q *memQueries) GetPayeeByAccount(ctx context.Context, arg GetPayeeByAccountParams) (Payee, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	for _, payee := range q.data.payees {
+		if payee.Owner == arg.Owner && payee.AccountID == arg.AccountID {
+			return payee, nil
+		}
+	}
+	return Payee{}, sql.ErrNoRows
+}
+
+func (q *memQueries) ListPayees(ctx context.Context, arg ListPayeesParams) ([]Payee, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	payees := sortedByID(q.data.payees, func(payee Payee) bool {
+		return payee.Owner == arg.Owner
+	})
+	return page(payees, arg.Limit, arg.Offset)
+}
+
+func (q *memQueries) DeletePayee(ctx context.Context, arg DeletePayeeParams) (int64, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	payee, ok := q.data.payees[arg.ID]
+	if !ok || payee.Owner != arg.Owner {
+		return 0, nil
+	}
+	deleteRow(q, q.data.payees, arg.ID)
+	return 1, nil
+}
+
+// held transfers
+
+func (q *memQueries) CreateHeldTransfer(ctx context.Context, arg CreateHeldTransferParams) (HeldTransfer, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if arg.Reasons == nil {
+		return HeldTransfer{}, notNullViolation("held_transfers", "reasons")
+	}
+	if _, ok := q.data.users[arg.RequestedBy]; !ok {
+		return HeldTransfer{}, foreignKeyViolation("held_transfers", "held_transfers_requested_by_fkey")
+	}
+	if _, ok := q.data.accounts[arg.FromAccountID]; !ok {
+		return HeldTransfer{}, foreignKeyViolation("held_transfers", "held_transfers_from_account_id_fkey")
+	}
+	if _, ok := q.data.accounts[arg.ToAccountID]; !ok {
+		return HeldTransfer{}, foreignKeyViolation("held_transfers", "held_transfers_to_account_id_fkey")
+	}
+
+	held := HeldTransfer{
+		ID:            q.data.nextID("held_transfers"),
+		RequestedBy:   arg.RequestedBy,
+		FromAccountID: arg.FromAccountID,
+		ToAccountID:   arg.ToAccountID,
+		Amount:        arg.Amount,
+		Currency:      arg.Currency,
+		Reasons:       slices.Clone(arg.Reasons),
+		Status:        HeldTransferPending,
+		CreatedAt:     now(),
+	}
+	setRow(q, q.data.heldTransfers, held.ID, held)
+	return held, nil
+}
+
+func (q *memQueries) GetHeldTransfer(ctx context.Context, id int64) (HeldTransfer, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	held, ok := q.data.heldTransfers[id]
+	if !ok {
+		return HeldTransfer{}, sql.ErrNoRows
+	}
+	return held, nil
+}
+
+func (q *memQueries) GetHeldTransferForUpdate(ctx context.Context, id int64) (HeldTransfer, error) {
+	return q.GetHeldTransfer(ctx, id)
+}
+
+func (q *memQueries) ListHeldTransfers(ctx context.Context, arg ListHeldTransfersParams) ([]HeldTransfer, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	held := sortedByID(q.data.heldTransfers, func(held HeldTransfer) bool {
+		return held.Status == arg.Status
+	})
+	return page(held, arg.Limit, arg.Offset)
+}
+
+func (q *memQueries) ReviewHeldTransfer(ctx context.Context, arg ReviewHeldTransferParams) (HeldTransfer, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if !slices.Contains([]string{HeldTransferPending, HeldTransferApproved, HeldTransferRejected}, arg.Status) {
+		return HeldTransfer{}, checkViolation("held_transfers", "held_transfers_status_check")
+	}
+	if _, ok := q.data.transfers[arg.TransferID.Int64]; arg.TransferID.Valid && !ok {
+		return HeldTransfer{}, foreignKeyViolation("held_transfers", "held_transfers_transfer_id_fkey")
+	}
+	held, ok := q.data.heldTransfers[arg.ID]
+	if !ok {
+		return HeldTransfer{}, sql.ErrNoRows
+	}
+	held.Status = arg.Status
+	held.ReviewedBy = arg.ReviewedBy
+	held.ReviewNote = arg.ReviewNote
+	held.TransferID = arg.TransferID
+	held.ReviewedAt = sql.NullTime{Time: now(), Valid: true}
+	setRow(q, q.data.heldTransfers, arg.ID, held)
+	return held, nil
+}
+
+// screening results
+
+func (q *memQueries) CreateScreeningResult(ctx context.Context, arg CreateScreeningResultParams) (ScreeningResult, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if arg.SubjectType != "user" && arg.SubjectType != "payee" {
+		return ScreeningResult{}, checkViolation("screening_results", "screening_results_subject_type_check")
+	}
+
+	result := ScreeningResult{
+		ID:          q.data.nextID("screening_results"),
+		SubjectType: arg.SubjectType,
+		Subject:     arg.Subject,
+		Name:        arg.Name,
+		RequestedBy: arg.RequestedBy,
+		ListVersion: arg.ListVersion,
+		Matched:     arg.Matched,
+		Score:       arg.Score,
+		EntryID:     arg.EntryID,
+		EntryName:   arg.EntryName,
+		CreatedAt:   now(),
+	}
+	setRow(q, q.data.screenings, result.ID, result)
+	return result, nil
+}
+
+func (q *memQueries) ListScreeningResults(ctx context.Context, arg ListScreeningResultsParams) ([]ScreeningResult, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	results := sortedByID(q.data.screenings, func(result ScreeningResult) bool {
+		return !arg.Matched.Valid || result.Matched == arg.Matched.Bool
+	})
+	slices.Reverse(results)
+	return page(results, arg.Limit, arg.Offset)
+}
+
+// audit events
+
+// LockAuditChain has nothing to do: sequencing runs inside execTx, which
+// already holds the store lock.
+func (q *memQueries) LockAuditChain(ctx context.Context) error {
+	return nil
+}
+
+func (q *memQueries) QueueAuditEvent(ctx context.Context, arg QueueAuditEventParams) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if arg.Before == nil {
+		return notNullViolation("queued_audit_events", "before")
+	}
+	if arg.After == nil {
+		return notNullViolation("queued_audit_events", "after")
+	}
+	setSlice(q, &q.data.auditQueue, append(q.data.auditQueue, QueuedAuditEvent{
+		ID:        q.data.nextID("queued_audit_events"),
+		Actor:     arg.Actor,
+		Action:    arg.Action,
+		Resource:  arg.Resource,
+		Ip:        arg.Ip,
+		RequestID: arg.RequestID,
+		Status:    arg.Status,
+		Before:    slices.Clone(arg.Before),
+		After:     slices.Clone(arg.After),
+		CreatedAt: arg.CreatedAt,
+	}))
+	return nil
+}
+
+func (q *memQueries) ListQueuedAuditEvents(ctx context.Context, limit int32) ([]QueuedAuditEvent, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return page(slices.Clone(q.data.auditQueue), limit, 0)
+}
+
+func (q *memQueries) DeleteQueuedAuditEvents(ctx context.Context, ids []int64) (int64, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	before := len(q.data.auditQueue)
+	setSlice(q, &q.data.auditQueue, slices.DeleteFunc(slices.Clone(q.data.auditQueue), func(event QueuedAuditEvent) bool {
+		return slices.Contains(ids, event.ID)
+	}))
+	return int64(before - len(q.data.auditQueue)), nil
+}
+
+func (q *memQueries) GetLastAuditEventHash(ctx context.Context) (string, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if len(q.data.auditEvents) == 0 {
+		return "", sql.ErrNoRows
+	}
+	return q.data.auditEvents[len(q.data.auditEvents)-1].Hash, nil
+}
+
+func (q *memQueries) CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if arg.Before == nil {
+		return AuditEvent{}, notNullViolation("audit_events", "before")
+	}
+	if arg.After == nil {
+		return AuditEvent{}, notNullViolation("audit_events", "after")
+	}
+	for _, event := range q.data.auditEvents {
+		if event.Hash == arg.Hash {
+			return AuditEvent{}, uniqueViolation("audit_events_hash_key")
+		}
+	}
+
+	event := AuditEvent{
+		ID:        q.data.nextID("audit_events"),
+		Actor:     arg.Actor,
+		Action:    arg.Action,
+		Resource:  arg.Resource,
+		Ip:        arg.Ip,
+		RequestID: arg.RequestID,
+		Status:    arg.Status,
+		Before:    slices.Clone(arg.Before),
+		After:     slices.Clone(arg.After),
+		PrevHash:  arg.PrevHash,
+		Hash:      arg.Hash,
+		CreatedAt: arg.CreatedAt,
+	}
+	setSlice(q, &q.data.auditEvents, append(q.data.auditEvents, event))
+	return event, nil
+}
+
+func (q *memQueries) ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	events := []AuditEvent{}
+	for _, event := range q.data.auditEvents {
+		if event.ID <= arg.AfterID {
+			continue
+		}
+		if arg.Actor.Valid && event.Actor != arg.Actor.String {
+			continue
+		}
+		events = append(events, event)
+	}
+	return page(events, arg.PageSize, 0)
+}
+
+func (q *memQueries) GetAuditEventBefore(ctx context.Context, id int64) (AuditEvent, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	i := sort.Search(len(q.data.auditEvents), func(i int) bool {
+		return q.data.auditEvents[i].ID >= id
+	})
+	if i == 0 {
+		return AuditEvent{}, sql.ErrNoRows
+	}
+	return q.data.auditEvents[i-1], nil
+}
+
+// oauth
+
+func (q *memQueries) CreateOauthClient(ctx context.Context, arg CreateOauthClientParams) (OauthClient, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if arg.RedirectUris == nil {
+		return OauthClient{}, notNullViolation("oauth_clients", "redirect_uris")
+	}
+	if arg.Scopes == nil {
+		return OauthClient{}, notNullViolation("oauth_clients", "scopes")
+	}
+	if _, ok := q.data.oauthClients[arg.ID]; ok {
+		return OauthClient{}, uniqueViolation("oauth_clients_pkey")
+	}
+	if _, ok := q.data.users[arg.Owner]; !ok {
+		return OauthClient{}, foreignKeyViolation("oauth_clients", "oauth_clients_owner_fkey")
+	}
+
+	client := OauthClient{
+		ID:           arg.ID,
+		Owner:        arg.Owner,
+		Name:         arg.Name,
+		HashedSecret: arg.HashedSecret,
+		RedirectUris: slices.Clone(arg.RedirectUris),
+		Scopes:       slices.Clone(arg.Scopes),
+		CreatedAt:    now(),
+	}
+	setRow(q, q.data.oauthClients, client.ID, client)
+	return client, nil
+}
+
+func (q *memQueries) GetOauthClient(ctx context.Context, id string) (OauthClient, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	client, ok := q.data.oauthClients[id]
+	if !ok {
+		return OauthClient{}, sql.ErrNoRows
+	}
+	return client, nil
+}
+
+func (q *memQueries) CreateOauthAuthorizationCode(ctx context.Context, arg CreateOauthAuthorizationCodeParams) (OauthAuthorizationCode, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if arg.Scopes == nil {
+		return OauthAuthorizationCode{}, notNullViolation("oauth_authorization_codes", "scopes")
+	}
+	if _, ok := q.data.oauthCodes[arg.CodeHash]; ok {
+		return OauthAuthorizationCode{}, uniqueViolation("oauth_authorization_codes_pkey")
+	}
+	if _, ok := q.data.oauthClients[arg.ClientID]; !ok {
+		return OauthAuthorizationCode{}, foreignKeyViolation("oauth_authorization_codes", "oauth_authorization_codes_client_id_fkey")
+	}
+	if _, ok := q.data.users[arg.Username]; !ok {
+		return OauthAuthorizationCode{}, foreignKeyViolation("oauth_authorization_codes", "oauth_authorization_codes_username_fkey")
+	}
+
+	code := OauthAuthorizationCode{
+		CodeHash:      arg.CodeHash,
+		ClientID:      arg.ClientID,
+		Username:      arg.Username,
+		RedirectUri:   arg.RedirectUri,
+		Scopes:        slices.Clone(arg.Scopes),
+		CodeChallenge: arg.CodeChallenge,
+		ExpiresAt:     arg.ExpiresAt,
+		CreatedAt:     now(),
+	}
+	setRow(q, q.data.oauthCodes, code.CodeHash, code)
+	return code, nil
+}
+
+func (q *memQueries) ConsumeOauthAuthorizationCode(ctx context.Context, codeHash string) (OauthAuthorizationCode, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	code, ok := q.data.oauthCodes[codeHash]
+	if !ok {
+		return OauthAuthorizationCode{}, sql.ErrNoRows
+	}
+	deleteRow(q, q.data.oauthCodes, codeHash)
+	return code, nil
+}
+
+func (q *memQueries) UpsertOauthConsent(ctx context.Context, arg UpsertOauthConsentParams) (OauthConsent, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if arg.Scopes == nil {
+		return OauthConsent{}, notNullViolation("oauth_consents", "scopes")
+	}
+	if _, ok := q.data.users[arg.Username]; !ok {
+		return OauthConsent{}, foreignKeyViolation("oauth_consents", "oauth_consents_username_fkey")
+	}
+	if _, ok := q.data.oauthClients[arg.ClientID]; !ok {
+		return OauthConsent{}, foreignKeyViolation("oauth_consents", "oauth_consents_client_id_fkey")
+	}
+
+	consent := OauthConsent{
+		Username:  arg.Username,
+		ClientID:  arg.ClientID,
+		Scopes:    slices.Clone(arg.Scopes),
+		CreatedAt: now(),
+	}
+	setRow(q, q.data.oauthConsents, consentKey{arg.Username, arg.ClientID}, consent)
+	return consent, nil
+}
+
+func (q *memQueries) GetOauthConsent(ctx context.Context, arg GetOauthConsentParams) (OauthConsent, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	consent, ok := q.data.oauthConsents[consentKey{arg.Username, arg.ClientID}]
+	if !ok {
+		return OauthConsent{}, sql.ErrNoRows
+	}
+	return consent, nil
+}
+
+func (q *memQueries) CreateOauthToken(ctx context.Context, arg CreateOauthTokenParams) (OauthToken, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if arg.Scopes == nil {
+		return OauthToken{}, notNullViolation("oauth_tokens", "scopes")
+	}
+	if _, ok := q.data.oauthTokens[arg.ID]; ok {
+		return OauthToken{}, uniqueViolation("oauth_tokens_pkey")
+	}
+	if _, ok := q.data.oauthClients[arg.ClientID]; !ok {
+		return OauthToken{}, foreignKeyViolation("oauth_tokens", "oauth_tokens_client_id_fkey")
+	}
+	if _, ok := q.data.users[arg.Username]; !ok {
+		return OauthToken{}, foreignKeyViolation("oauth_tokens", "oauth_tokens_username_fkey")
+	}
+
+	token := OauthToken{
+		ID:        arg.ID,
+		ClientID:  arg.ClientID,
+		Username:  arg.Username,
+		Scopes:    slices.Clone(arg.Scopes),
+		ExpiresAt: arg.ExpiresAt,
+		CreatedAt: now(),
+	}
+	setRow(q, q.data.oauthTokens, token.ID, token)
+	return token, nil
+}
+
+func (q *memQueries) GetOauthToken(ctx context.Context, id string) (OauthToken, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	token, ok := q.data.oauthTokens[id]
+	if !ok {
+		return OauthToken{}, sql.ErrNoRows
+	}
+	return token, nil
+}
+
+func (q *memQueries) RevokeOauthToken(ctx context.Context, id string) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	token, ok := q.data.oauthTokens[id]
+	if ok && !token.RevokedAt.Valid {
+		token.RevokedAt = sql.NullTime{Time: now(), Valid: true}
+		setRow(q, q.data.oauthTokens, id, token)
+	}
+	return nil
+}
+
+// rate limits
+
+func refilledTokens(bucket RateLimitBucket, burst float64, refillRate float64, at time.Time) float64 {
+	return math.Min(burst, bucket.Tokens+at.Sub(bucket.UpdatedAt).Seconds()*refillRate)
+}
+
+func (q *memQueries) TakeRateLimitToken(ctx context.Context, arg TakeRateLimitTokenParams) (float64, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	at := time.Now()
+	bucket, ok := q.data.rateLimitBuckets[arg.Key]
+	if !ok {
+		bucket = RateLimitBucket{Key: arg.Key, Tokens: arg.Burst - 1, UpdatedAt: at}
+		setRow(q, q.data.rateLimitBuckets, arg.Key, bucket)
+		return bucket.Tokens, nil
+	}
+
+	tokens := refilledTokens(bucket, arg.Burst, arg.RefillRate, at)
+	if tokens < 1 {
+		return 0, sql.ErrNoRows
+	}
+	bucket.Tokens = tokens - 1
+	bucket.UpdatedAt = at
+	setRow(q, q.data.rateLimitBuckets, arg.Key, bucket)
+	return bucket.Tokens, nil
+}
+
+func (q *memQueries) GetRateLimitTokens(ctx context.Context, arg GetRateLimitTokensParams) (float64, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	bucket, ok := q.data.rateLimitBuckets[arg.Key]
+	if !ok {
+		return 0, sql.ErrNoRows
+	}
+	return refilledTokens(bucket, arg.Burst, arg.RefillRate, time.Now()), nil
+}
+
+func (q *memQueries) DeleteIdleRateLimitBuckets(ctx context.Context, idleSeconds float64) (int64, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	cutoff := time.Now().Add(-time.Duration(idleSeconds * float64(time.Second)))
+	var deleted int64
+	for key, bucket := range q.data.rateLimitBuckets {
+		if bucket.UpdatedAt.Before(cutoff) {
+			deleteRow(q, q.data.rateLimitBuckets, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
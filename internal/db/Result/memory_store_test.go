@@ -0,0 +1,65 @@
+package Anuskh
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStoreRollback(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryStore().(*MemoryStore)
+	user := conformanceUser(t, store)
+	account := conformanceAccount(t, store, user.Username, util.USD)
+	other := conformanceAccount(t, store, user.Username, util.USD)
+	errAbort := errors.New("abort")
+
+	payee, err := store.CreatePayee(ctx, CreatePayeeParams{
+		Owner:         conformanceUser(t, store).Username,
+		AccountID:     account.ID,
+		AccountNumber: account.AccountNumber,
+		Nickname:      "rent",
+		Currency:      util.USD,
+	})
+	require.NoError(t, err)
+	entry, err := store.CreateEntries(ctx, CreateEntriesParams{AccountID: other.ID, Amount: 5})
+	require.NoError(t, err)
+
+	var created Account
+	err = store.execTx(ctx, func(q Querier) error {
+		var err error
+		created, err = q.CreateAccounts(ctx, CreateAccountsParams{
+			Owner:         user.Username,
+			Currency:      util.EUR,
+			AccountType:   AccountChecking,
+			AccountNumber: randomAccountNumber(t),
+		})
+		require.NoError(t, err)
+		_, err = q.AddBalance(ctx, AddBalanceParams{ID: account.ID, Balance: 10})
+		require.NoError(t, err)
+		require.NoError(t, q.DeleteAccounts(ctx, account.ID)) // and its payees
+		require.NoError(t, q.DeleteEntries(ctx, other.ID))
+		require.NoError(t, q.QueueAuditEvent(ctx, QueueAuditEventParams{Before: []byte("{}"), After: []byte("{}")}))
+		return errAbort
+	})
+	require.ErrorIs(t, err, errAbort)
+
+	_, err = store.GetAccounts(ctx, created.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	got, err := store.GetAccounts(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, account, got)
+	gotPayee, err := store.GetPayee(ctx, payee.ID)
+	require.NoError(t, err)
+	require.Equal(t, payee, gotPayee)
+	gotEntry, err := store.GetEntries(ctx, entry.ID)
+	require.NoError(t, err)
+	require.Equal(t, entry, gotEntry)
+	queued, err := store.ListQueuedAuditEvents(ctx, 10)
+	require.NoError(t, err)
+	require.Empty(t, queued)
+}
@@ -5,15 +5,55 @@
 package Anuskh
 
 import (
+	"database/sql"
+	"encoding/json"
 	"time"
 )
 
 type Account struct {
-	ID        int64     `json:"id"`
-	Owner     string    `json:"owner"`
-	Balance   int64     `json:"balance"`
-	Currency  string    `json:"currency"`
-	CreatedAt time.Time `json:"created_at"`
+	ID            int64     `json:"id"`
+	Owner         string    `json:"owner"`
+	Balance       int64     `json:"balance"`
+	Currency      string    `json:"currency"`
+	CreatedAt     time.Time `json:"created_at"`
+	Status        string    `json:"status"`
+	StatusReason  string    `json:"status_reason"`
+	Nickname      string    `json:"nickname"`
+	AccountType   string    `json:"account_type"`
+	AccountNumber string    `json:"account_number"`
+}
+
+type ApiKey struct {
+	ID           int64        `json:"id"`
+	Owner        string       `json:"owner"`
+	Name         string       `json:"name"`
+	Prefix       string       `json:"prefix"`
+	HashedSecret string       `json:"hashed_secret"`
+	Scopes       []string     `json:"scopes"`
+	AllowedIps   []string     `json:"allowed_ips"`
+	ExpiresAt    sql.NullTime `json:"expires_at"`
+	CreatedAt    time.Time    `json:"created_at"`
+}
+
+type AuditEvent struct {
+	ID        int64           `json:"id"`
+	Actor     string          `json:"actor"`
+	Action    string          `json:"action"`
+	Resource  string          `json:"resource"`
+	Ip        string          `json:"ip"`
+	RequestID string          `json:"request_id"`
+	Status    int32           `json:"status"`
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+	PrevHash  string          `json:"prev_hash"`
+	Hash      string          `json:"hash"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type CurrencySetting struct {
+	Code      string    `json:"code"`
+	Enabled   bool      `json:"enabled"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type Entry struct {
@@ -23,14 +63,145 @@ type Entry struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
-type Transfer struct {
+type HeldTransfer struct {
+	ID            int64         `json:"id"`
+	RequestedBy   string        `json:"requested_by"`
+	FromAccountID int64         `json:"from_account_id"`
+	ToAccountID   int64         `json:"to_account_id"`
+	Amount        int64         `json:"amount"`
+	Currency      string        `json:"currency"`
+	Reasons       []string      `json:"reasons"`
+	Status        string        `json:"status"`
+	ReviewedBy    string        `json:"reviewed_by"`
+	ReviewNote    string        `json:"review_note"`
+	TransferID    sql.NullInt64 `json:"transfer_id"`
+	CreatedAt     time.Time     `json:"created_at"`
+	ReviewedAt    sql.NullTime  `json:"reviewed_at"`
+}
+
+type InterestAccrual struct {
+	ID           int64         `json:"id"`
+	AccountID    int64         `json:"account_id"`
+	AccrualDate  time.Time     `json:"accrual_date"`
+	Balance      int64         `json:"balance"`
+	RateBps      int64         `json:"rate_bps"`
+	AmountMicros int64         `json:"amount_micros"`
+	PostingID    sql.NullInt64 `json:"posting_id"`
+	CreatedAt    time.Time     `json:"created_at"`
+}
+
+type InterestPosting struct {
+	ID               int64     `json:"id"`
+	AccountID        int64     `json:"account_id"`
+	FundingAccountID int64     `json:"funding_account_id"`
+	Period           time.Time `json:"period"`
+	Amount           int64     `json:"amount"`
+	CarryMicros      int64     `json:"carry_micros"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type KycDocument struct {
+	ID          int64     `json:"id"`
+	Owner       string    `json:"owner"`
+	Kind        string    `json:"kind"`
+	ContentType string    `json:"content_type"`
+	SizeBytes   int64     `json:"size_bytes"`
+	Sha256      string    `json:"sha256"`
+	BlobKey     string    `json:"blob_key"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type OauthAuthorizationCode struct {
+	CodeHash      string    `json:"code_hash"`
+	ClientID      string    `json:"client_id"`
+	Username      string    `json:"username"`
+	RedirectUri   string    `json:"redirect_uri"`
+	Scopes        []string  `json:"scopes"`
+	CodeChallenge string    `json:"code_challenge"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type OauthClient struct {
+	ID           string    `json:"id"`
+	Owner        string    `json:"owner"`
+	Name         string    `json:"name"`
+	HashedSecret string    `json:"hashed_secret"`
+	RedirectUris []string  `json:"redirect_uris"`
+	Scopes       []string  `json:"scopes"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type OauthConsent struct {
+	Username  string    `json:"username"`
+	ClientID  string    `json:"client_id"`
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type OauthToken struct {
+	ID        string       `json:"id"`
+	ClientID  string       `json:"client_id"`
+	Username  string       `json:"username"`
+	Scopes    []string     `json:"scopes"`
+	ExpiresAt time.Time    `json:"expires_at"`
+	RevokedAt sql.NullTime `json:"revoked_at"`
+	CreatedAt time.Time    `json:"created_at"`
+}
+
+type Payee struct {
 	ID            int64     `json:"id"`
-	FromAccountID int64     `json:"from_account_id"`
-	ToAccountID   int64     `json:"to_account_id"`
-	Amount        int64     `json:"amount"`
+	Owner         string    `json:"owner"`
+	AccountID     int64     `json:"account_id"`
+	AccountNumber string    `json:"account_number"`
+	Nickname      string    `json:"nickname"`
+	Currency      string    `json:"currency"`
 	CreatedAt     time.Time `json:"created_at"`
 }
 
+type QueuedAuditEvent struct {
+	ID        int64           `json:"id"`
+	Actor     string          `json:"actor"`
+	Action    string          `json:"action"`
+	Resource  string          `json:"resource"`
+	Ip        string          `json:"ip"`
+	RequestID string          `json:"request_id"`
+	Status    int32           `json:"status"`
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type RateLimitBucket struct {
+	Key       string    `json:"key"`
+	Tokens    float64   `json:"tokens"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ScreeningResult struct {
+	ID          int64     `json:"id"`
+	SubjectType string    `json:"subject_type"`
+	Subject     string    `json:"subject"`
+	Name        string    `json:"name"`
+	RequestedBy string    `json:"requested_by"`
+	ListVersion string    `json:"list_version"`
+	Matched     bool      `json:"matched"`
+	Score       float64   `json:"score"`
+	EntryID     string    `json:"entry_id"`
+	EntryName   string    `json:"entry_name"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type Transfer struct {
+	ID            int64         `json:"id"`
+	FromAccountID int64         `json:"from_account_id"`
+	ToAccountID   int64         `json:"to_account_id"`
+	Amount        int64         `json:"amount"`
+	CreatedAt     time.Time     `json:"created_at"`
+	Fee           int64         `json:"fee"`
+	FeeAccountID  sql.NullInt64 `json:"fee_account_id"`
+}
+
 type User struct {
 	Username          string    `json:"username"`
 	HashedPassword    string    `json:"hashed_password"`
@@ -38,4 +209,10 @@ type User struct {
 	Email             string    `json:"email"`
 	PasswordChangedAt time.Time `json:"password_changed_at"`
 	CreatedAt         time.Time `json:"created_at"`
+	Role              string    `json:"role"`
+	Tier              string    `json:"tier"`
+	KycStatus         string    `json:"kyc_status"`
+	KycNote           string    `json:"kyc_note"`
+	KycReviewedBy     string    `json:"kyc_reviewed_by"`
+	KycUpdatedAt      time.Time `json:"kyc_updated_at"`
 }
@@ -0,0 +1,109 @@
+package Anuskh
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func CreateRandomOauthClient(t *testing.T) OauthClient {
+	user := CreateRandomUser(t)
+
+	arg := CreateOauthClientParams{
+		ID:           util.RandomString(16),
+		Owner:        user.Username,
+		Name:         util.RandomOwner(),
+		HashedSecret: util.HashSecret(util.RandomString(32)),
+		RedirectUris: []string{"https://example.com/callback"},
+		Scopes:       []string{"accounts:read", "transfers:read"},
+	}
+
+	client, err := testQueries.CreateOauthClient(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.ID, client.ID)
+	require.Equal(t, arg.RedirectUris, client.RedirectUris)
+	require.Equal(t, arg.Scopes, client.Scopes)
+	require.NotZero(t, client.CreatedAt)
+
+	return client
+}
+
+func TestGetOauthClient(t *testing.T) {
+	client := CreateRandomOauthClient(t)
+
+	client2, err := testQueries.GetOauthClient(context.Background(), client.ID)
+	require.NoError(t, err)
+	require.Equal(t, client, client2)
+}
+
+func TestConsumeOauthAuthorizationCode(t *testing.T) {
+	client := CreateRandomOauthClient(t)
+
+	arg := CreateOauthAuthorizationCodeParams{
+		CodeHash:      util.HashSecret(util.RandomString(32)),
+		ClientID:      client.ID,
+		Username:      client.Owner,
+		RedirectUri:   client.RedirectUris[0],
+		Scopes:        []string{"transfers:read"},
+		CodeChallenge: util.RandomString(43),
+		ExpiresAt:     time.Now().Add(time.Minute),
+	}
+	_, err := testQueries.CreateOauthAuthorizationCode(context.Background(), arg)
+	require.NoError(t, err)
+
+	code, err := testQueries.ConsumeOauthAuthorizationCode(context.Background(), arg.CodeHash)
+	require.NoError(t, err)
+	require.Equal(t, arg.Username, code.Username)
+	require.Equal(t, arg.Scopes, code.Scopes)
+
+	_, err = testQueries.ConsumeOauthAuthorizationCode(context.Background(), arg.CodeHash)
+	require.EqualError(t, err, sql.ErrNoRows.Error())
+}
+
+func TestUpsertOauthConsent(t *testing.T) {
+	client := CreateRandomOauthClient(t)
+
+	arg := UpsertOauthConsentParams{
+		Username: client.Owner,
+		ClientID: client.ID,
+		Scopes:   []string{"accounts:read"},
+	}
+	_, err := testQueries.UpsertOauthConsent(context.Background(), arg)
+	require.NoError(t, err)
+
+	arg.Scopes = []string{"accounts:read", "transfers:read"}
+	_, err = testQueries.UpsertOauthConsent(context.Background(), arg)
+	require.NoError(t, err)
+
+	consent, err := testQueries.GetOauthConsent(context.Background(), GetOauthConsentParams{
+		Username: client.Owner,
+		ClientID: client.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, arg.Scopes, consent.Scopes)
+}
+
+func TestRevokeOauthToken(t *testing.T) {
+	client := CreateRandomOauthClient(t)
+
+	token, err := testQueries.CreateOauthToken(context.Background(), CreateOauthTokenParams{
+		ID:        util.RandomString(36),
+		ClientID:  client.ID,
+		Username:  client.Owner,
+		Scopes:    client.Scopes,
+		ExpiresAt: time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.False(t, token.RevokedAt.Valid)
+
+	err = testQueries.RevokeOauthToken(context.Background(), token.ID)
+	require.NoError(t, err)
+
+	token2, err := testQueries.GetOauthToken(context.Background(), token.ID)
+	require.NoError(t, err)
+	require.True(t, token2.RevokedAt.Valid)
+}
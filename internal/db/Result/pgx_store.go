@@ -0,0 +1,107 @@
+package Anuskh
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+)
+
+// PoolConfig tunes the connection pool. Zero values keep the driver defaults.
+type PoolConfig struct {
+	MaxConns               int32
+	MinConns               int32 // pgx only, connections kept open while idle
+	MaxConnLifetime        time.Duration
+	MaxConnIdleTime        time.Duration
+	HealthCheckPeriod      time.Duration // pgx only, how often idle connections are checked
+	StatementCacheCapacity int           // pgx only, prepared statements kept per connection; 0 disables the cache
+}
+
+// ConfigureSQLPool applies the parts of cfg that database/sql supports.
+func ConfigureSQLPool(db *sql.DB, cfg PoolConfig) {
+	if cfg.MaxConns > 0 {
+		db.SetMaxOpenConns(int(cfg.MaxConns))
+		db.SetMaxIdleConns(int(cfg.MaxConns))
+	}
+	db.SetConnMaxLifetime(cfg.MaxConnLifetime)
+	db.SetConnMaxIdleTime(cfg.MaxConnIdleTime)
+}
+
+// NewPgxPoolConfig parses source and applies cfg on top of it.
+func NewPgxPoolConfig(source string, cfg PoolConfig) (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(source)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxConns > 0 {
+		poolConfig.MaxConns = cfg.MaxConns
+	}
+	poolConfig.MinConns = cfg.MinConns
+	if cfg.MaxConnLifetime > 0 {
+		poolConfig.MaxConnLifetime = cfg.MaxConnLifetime
+	}
+	if cfg.MaxConnIdleTime > 0 {
+		poolConfig.MaxConnIdleTime = cfg.MaxConnIdleTime
+	}
+	if cfg.HealthCheckPeriod > 0 {
+		poolConfig.HealthCheckPeriod = cfg.HealthCheckPeriod
+	}
+
+	if cfg.StatementCacheCapacity > 0 {
+		poolConfig.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeCacheStatement
+		poolConfig.ConnConfig.StatementCacheCapacity = cfg.StatementCacheCapacity
+	} else {
+		// describing every statement costs a round trip but holds no server
+		// side state, which PgBouncer in transaction mode needs
+		poolConfig.ConnConfig.DefaultQueryExecMode = pgx.QueryExecModeDescribeExec
+	}
+	return poolConfig, nil
+}
+
+func NewPgxPool(ctx context.Context, source string, cfg PoolConfig) (*pgxpool.Pool, error) {
+	poolConfig, err := NewPgxPoolConfig(source, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return pgxpool.NewWithConfig(ctx, poolConfig)
+}
+
+// PgxStore runs the generated queries over a pgxpool.Pool through pgx's
+// database/sql adapter, so it shares every query and transaction with
+// RealStore while connections, statement caching and health checks are pgx's.
+//
+// Going through database/sql keeps one querier to maintain, at the cost of
+// the native pgx/v5 API: no batching or COPY, rows are scanned through the
+// sql interfaces, and errors come back as *pgconn.PgError instead of
+// *pq.Error, which is why ErrorCode and ErrorConstraint read both.
+type PgxStore struct {
+	*RealStore
+	pool *pgxpool.Pool
+}
+
+func NewPgxStore(pool *pgxpool.Pool, opts ...StoreOption) *PgxStore {
+	return &PgxStore{
+		RealStore: newRealStore(stdlib.OpenDBFromPool(pool), opts...),
+		pool:      pool,
+	}
+}
+
+// Ping acquires a connection from the pool and checks it.
+func (store *PgxStore) Ping(ctx context.Context) error {
+	return store.pool.Ping(ctx)
+}
+
+// DB returns the database/sql view of the pool, for migrations and metrics.
+func (store *PgxStore) DB() *sql.DB {
+	return store.db
+}
+
+func (store *PgxStore) Close() error {
+	err := store.db.Close()
+	store.pool.Close()
+	return err
+}
@@ -6,28 +6,94 @@ package Anuskh
 
 import (
 	"context"
+	"time"
 )
 
 type Querier interface {
 	AddBalance(ctx context.Context, arg AddBalanceParams) (Account, error)
+	ConsumeOauthAuthorizationCode(ctx context.Context, codeHash string) (OauthAuthorizationCode, error)
+	CountAccounts(ctx context.Context, owner string) (int64, error)
+	CountKycDocuments(ctx context.Context, owner string) (int64, error)
+	CountTransfersInRange(ctx context.Context, arg CountTransfersInRangeParams) (int64, error)
+	CountTransfersTo(ctx context.Context, arg CountTransfersToParams) (int64, error)
 	CreateAccounts(ctx context.Context, arg CreateAccountsParams) (Account, error)
+	CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) (AuditEvent, error)
 	CreateEntries(ctx context.Context, arg CreateEntriesParams) (Entry, error)
+	CreateHeldTransfer(ctx context.Context, arg CreateHeldTransferParams) (HeldTransfer, error)
+	CreateInterestAccrual(ctx context.Context, arg CreateInterestAccrualParams) (InterestAccrual, error)
+	CreateInterestPosting(ctx context.Context, arg CreateInterestPostingParams) (InterestPosting, error)
+	CreateKycDocument(ctx context.Context, arg CreateKycDocumentParams) (KycDocument, error)
+	CreateOauthAuthorizationCode(ctx context.Context, arg CreateOauthAuthorizationCodeParams) (OauthAuthorizationCode, error)
+	CreateOauthClient(ctx context.Context, arg CreateOauthClientParams) (OauthClient, error)
+	CreateOauthToken(ctx context.Context, arg CreateOauthTokenParams) (OauthToken, error)
+	CreatePayee(ctx context.Context, arg CreatePayeeParams) (Payee, error)
+	CreateScreeningResult(ctx context.Context, arg CreateScreeningResultParams) (ScreeningResult, error)
 	CreateTransfers(ctx context.Context, arg CreateTransfersParams) (Transfer, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
 	DeleteAccounts(ctx context.Context, id int64) error
+	DeleteApiKey(ctx context.Context, arg DeleteApiKeyParams) (int64, error)
 	DeleteEntries(ctx context.Context, accountID int64) error
+	DeleteIdleRateLimitBuckets(ctx context.Context, idleSeconds float64) (int64, error)
+	DeletePayee(ctx context.Context, arg DeletePayeeParams) (int64, error)
+	DeleteQueuedAuditEvents(ctx context.Context, ids []int64) (int64, error)
 	DeleteTransfers(ctx context.Context, id int64) error
+	GetAccountBalanceAt(ctx context.Context, arg GetAccountBalanceAtParams) (int64, error)
+	GetAccountByNumber(ctx context.Context, accountNumber string) (Account, error)
 	GetAccounts(ctx context.Context, id int64) (Account, error)
 	GetAccountsForUpdate(ctx context.Context, id int64) (Account, error)
+	GetApiKeyByPrefix(ctx context.Context, prefix string) (ApiKey, error)
+	GetAuditEventBefore(ctx context.Context, id int64) (AuditEvent, error)
 	GetEntries(ctx context.Context, id int64) (Entry, error)
+	GetHeldTransfer(ctx context.Context, id int64) (HeldTransfer, error)
+	GetHeldTransferForUpdate(ctx context.Context, id int64) (HeldTransfer, error)
+	GetKycDocument(ctx context.Context, id int64) (KycDocument, error)
+	GetLastAuditEventHash(ctx context.Context) (string, error)
+	GetLatestEntry(ctx context.Context, accountID int64) (Entry, error)
+	GetLatestInterestAccrual(ctx context.Context, accountID int64) (InterestAccrual, error)
+	GetLatestInterestPosting(ctx context.Context, accountID int64) (InterestPosting, error)
+	GetOauthClient(ctx context.Context, id string) (OauthClient, error)
+	GetOauthConsent(ctx context.Context, arg GetOauthConsentParams) (OauthConsent, error)
+	GetOauthToken(ctx context.Context, id string) (OauthToken, error)
+	GetPayee(ctx context.Context, id int64) (Payee, error)
+	GetPayeeByAccount(ctx context.Context, arg GetPayeeByAccountParams) (Payee, error)
+	GetRateLimitTokens(ctx context.Context, arg GetRateLimitTokensParams) (float64, error)
+	GetTransferActivity(ctx context.Context, arg GetTransferActivityParams) (GetTransferActivityRow, error)
 	GetTransfers(ctx context.Context, id int64) (Transfer, error)
 	GetUser(ctx context.Context, username string) (User, error)
+	GetUserForUpdate(ctx context.Context, username string) (User, error)
 	ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error)
+	ListAccountsWithUnpostedInterest(ctx context.Context, before time.Time) ([]int64, error)
+	ListApiKeys(ctx context.Context, arg ListApiKeysParams) ([]ApiKey, error)
+	ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error)
+	ListCurrencySettings(ctx context.Context) ([]CurrencySetting, error)
 	ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error)
+	ListHeldTransfers(ctx context.Context, arg ListHeldTransfersParams) ([]HeldTransfer, error)
+	ListInterestAccruals(ctx context.Context, arg ListInterestAccrualsParams) ([]InterestAccrual, error)
+	ListInterestBearingAccounts(ctx context.Context, arg ListInterestBearingAccountsParams) ([]Account, error)
+	ListInterestPostings(ctx context.Context, arg ListInterestPostingsParams) ([]InterestPosting, error)
+	ListKycDocuments(ctx context.Context, owner string) ([]KycDocument, error)
+	ListPayees(ctx context.Context, arg ListPayeesParams) ([]Payee, error)
+	ListQueuedAuditEvents(ctx context.Context, limit int32) ([]QueuedAuditEvent, error)
+	ListScreeningResults(ctx context.Context, arg ListScreeningResultsParams) ([]ScreeningResult, error)
 	ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error)
+	ListUnpostedInterestAccruals(ctx context.Context, arg ListUnpostedInterestAccrualsParams) ([]InterestAccrual, error)
+	ListUsersByKycStatus(ctx context.Context, arg ListUsersByKycStatusParams) ([]User, error)
+	LockAuditChain(ctx context.Context) error
+	QueueAuditEvent(ctx context.Context, arg QueueAuditEventParams) error
+	ReviewHeldTransfer(ctx context.Context, arg ReviewHeldTransferParams) (HeldTransfer, error)
+	RevokeOauthToken(ctx context.Context, id string) error
+	SetCurrencyEnabled(ctx context.Context, arg SetCurrencyEnabledParams) (CurrencySetting, error)
+	SetInterestAccrualsPosted(ctx context.Context, arg SetInterestAccrualsPostedParams) (int64, error)
+	SumTransfers(ctx context.Context, arg SumTransfersParams) (SumTransfersRow, error)
+	SumTransfersTo(ctx context.Context, arg SumTransfersToParams) (int64, error)
+	TakeRateLimitToken(ctx context.Context, arg TakeRateLimitTokenParams) (float64, error)
+	UpdateAccountStatus(ctx context.Context, arg UpdateAccountStatusParams) (Account, error)
 	UpdateAccounts(ctx context.Context, arg UpdateAccountsParams) (Account, error)
 	UpdateEntries(ctx context.Context, arg UpdateEntriesParams) error
 	UpdateTransfers(ctx context.Context, arg UpdateTransfersParams) error
+	UpdateUserKyc(ctx context.Context, arg UpdateUserKycParams) (User, error)
+	UpsertOauthConsent(ctx context.Context, arg UpsertOauthConsentParams) (OauthConsent, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,38 @@
+package Anuskh
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTakeRateLimitToken(t *testing.T) {
+	// a refill rate of zero keeps the bucket from topping up mid-test
+	arg := TakeRateLimitTokenParams{
+		Key:        "test:" + util.RandomString(12),
+		Burst:      2,
+		RefillRate: 0,
+	}
+
+	tokens, err := testQueries.TakeRateLimitToken(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, 1.0, tokens)
+
+	tokens, err = testQueries.TakeRateLimitToken(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, 0.0, tokens)
+
+	_, err = testQueries.TakeRateLimitToken(context.Background(), arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	tokens, err = testQueries.GetRateLimitTokens(context.Background(), GetRateLimitTokensParams{
+		Burst:      arg.Burst,
+		RefillRate: arg.RefillRate,
+		Key:        arg.Key,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 0.0, tokens)
+}
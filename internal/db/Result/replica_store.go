@@ -0,0 +1,212 @@
+package Anuskh
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// Replica is a read-only copy of the primary database.
+type Replica interface {
+	Querier
+	// ReplicationLag reports how far behind the primary the replica is.
+	ReplicationLag(ctx context.Context) (time.Duration, error)
+}
+
+type sqlReplica struct {
+	*Queries
+	db *sql.DB
+}
+
+func NewSQLReplica(db *sql.DB) Replica {
+	return &sqlReplica{Queries: New(traceDB(db)), db: db}
+}
+
+// ReplicationLag is zero once the replica has replayed everything it received;
+// otherwise it is the age of the last transaction it replayed. Comparing
+// against now() alone would report an idle primary as lag.
+func (replica *sqlReplica) ReplicationLag(ctx context.Context) (time.Duration, error) {
+	var seconds float64
+	err := replica.db.QueryRowContext(ctx, `
+SELECT CASE
+  WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+  ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+END::float8`).Scan(&seconds)
+	return time.Duration(seconds * float64(time.Second)), err
+}
+
+type ReplicaConfig struct {
+	MaxLag      time.Duration // replicas further behind are skipped
+	CheckPeriod time.Duration // how often lag is measured
+}
+
+type replicaState struct {
+	Replica
+	eligible atomic.Bool
+}
+
+// ReplicaStore sends reads that can tolerate slightly stale data (Get* and
+// List*) to replicas whose lag is within MaxLag. Writes, TransferTx,
+// GetAccountsForUpdate, reads of state the caller has just written
+// (GetLastAuditEventHash, GetRateLimitTokens) and the users, API keys and
+// OAuth clients, consents and tokens requests are authenticated and
+// authorized against go to the primary, so a revoked key or demoted admin
+// stops working at once. So does any read a replica fails, so a row created
+// moments ago is still found.
+type ReplicaStore struct {
+	Store
+	replicas []*replicaState
+	config   ReplicaConfig
+	next     atomic.Uint64
+}
+
+// NewReplicaStore wraps primary. Replicas take no reads until MonitorLag has
+// measured them.
+func NewReplicaStore(primary Store, replicas []Replica, config ReplicaConfig) *ReplicaStore {
+	store := &ReplicaStore{Store: primary, config: config}
+	for _, replica := range replicas {
+		store.replicas = append(store.replicas, &replicaState{Replica: replica})
+	}
+	return store
+}
+
+// MonitorLag measures every replica each CheckPeriod until ctx is done.
+func (store *ReplicaStore) MonitorLag(ctx context.Context) {
+	ticker := time.NewTicker(store.config.CheckPeriod)
+	defer ticker.Stop()
+
+	for {
+		store.checkLag(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (store *ReplicaStore) checkLag(ctx context.Context) {
+	for i, replica := range store.replicas {
+		checkCtx, cancel := context.WithTimeout(ctx, store.config.CheckPeriod)
+		lag, err := replica.ReplicationLag(checkCtx)
+		cancel()
+
+		eligible := err == nil && lag <= store.config.MaxLag
+		if was := replica.eligible.Swap(eligible); was == eligible {
+			continue
+		}
+		if eligible {
+			slog.InfoContext(ctx, "replica taking reads", "replica", i, "lag", lag)
+		} else {
+			slog.WarnContext(ctx, "replica skipped", "replica", i, "lag", lag, "error", err)
+		}
+	}
+}
+
+// pick returns the eligible replicas in turn, or nil when there are none.
+func (store *ReplicaStore) pick() Querier {
+	eligible := make([]Querier, 0, len(store.replicas))
+	for _, replica := range store.replicas {
+		if replica.eligible.Load() {
+			eligible = append(eligible, replica)
+		}
+	}
+	if len(eligible) == 0 {
+		return nil
+	}
+	return eligible[store.next.Add(1)%uint64(len(eligible))]
+}
+
+func readFromReplica[T any](ctx context.Context, store *ReplicaStore, read func(q Querier) (T, error)) (T, error) {
+	replica := store.pick()
+	if replica == nil {
+		return read(store.Store)
+	}
+	result, err := read(replica)
+	if err == nil || ctx.Err() != nil {
+		return result, err
+	}
+	return read(store.Store)
+}
+
+func (store *ReplicaStore) GetAccounts(ctx context.Context, id int64) (Account, error) {
+	return readFromReplica(ctx, store, func(q Querier) (Account, error) { return q.GetAccounts(ctx, id) })
+}
+
+func (store *ReplicaStore) GetAccountByNumber(ctx context.Context, accountNumber string) (Account, error) {
+	return readFromReplica(ctx, store, func(q Querier) (Account, error) { return q.GetAccountByNumber(ctx, accountNumber) })
+}
+
+func (store *ReplicaStore) GetAuditEventBefore(ctx context.Context, id int64) (AuditEvent, error) {
+	return readFromReplica(ctx, store, func(q Querier) (AuditEvent, error) { return q.GetAuditEventBefore(ctx, id) })
+}
+
+func (store *ReplicaStore) GetEntries(ctx context.Context, id int64) (Entry, error) {
+	return readFromReplica(ctx, store, func(q Querier) (Entry, error) { return q.GetEntries(ctx, id) })
+}
+
+func (store *ReplicaStore) GetKycDocument(ctx context.Context, id int64) (KycDocument, error) {
+	return readFromReplica(ctx, store, func(q Querier) (KycDocument, error) { return q.GetKycDocument(ctx, id) })
+}
+
+func (store *ReplicaStore) GetHeldTransfer(ctx context.Context, id int64) (HeldTransfer, error) {
+	return readFromReplica(ctx, store, func(q Querier) (HeldTransfer, error) { return q.GetHeldTransfer(ctx, id) })
+}
+
+func (store *ReplicaStore) GetPayee(ctx context.Context, id int64) (Payee, error) {
+	return readFromReplica(ctx, store, func(q Querier) (Payee, error) { return q.GetPayee(ctx, id) })
+}
+
+func (store *ReplicaStore) GetTransfers(ctx context.Context, id int64) (Transfer, error) {
+	return readFromReplica(ctx, store, func(q Querier) (Transfer, error) { return q.GetTransfers(ctx, id) })
+}
+
+func (store *ReplicaStore) ListAccounts(ctx context.Context, arg ListAccountsParams) ([]Account, error) {
+	return readFromReplica(ctx, store, func(q Querier) ([]Account, error) { return q.ListAccounts(ctx, arg) })
+}
+
+func (store *ReplicaStore) ListApiKeys(ctx context.Context, arg ListApiKeysParams) ([]ApiKey, error) {
+	return readFromReplica(ctx, store, func(q Querier) ([]ApiKey, error) { return q.ListApiKeys(ctx, arg) })
+}
+
+func (store *ReplicaStore) ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error) {
+	return readFromReplica(ctx, store, func(q Querier) ([]AuditEvent, error) { return q.ListAuditEvents(ctx, arg) })
+}
+
+func (store *ReplicaStore) ListEntries(ctx context.Context, arg ListEntriesParams) ([]Entry, error) {
+	return readFromReplica(ctx, store, func(q Querier) ([]Entry, error) { return q.ListEntries(ctx, arg) })
+}
+
+func (store *ReplicaStore) ListHeldTransfers(ctx context.Context, arg ListHeldTransfersParams) ([]HeldTransfer, error) {
+	return readFromReplica(ctx, store, func(q Querier) ([]HeldTransfer, error) { return q.ListHeldTransfers(ctx, arg) })
+}
+
+func (store *ReplicaStore) ListInterestAccruals(ctx context.Context, arg ListInterestAccrualsParams) ([]InterestAccrual, error) {
+	return readFromReplica(ctx, store, func(q Querier) ([]InterestAccrual, error) { return q.ListInterestAccruals(ctx, arg) })
+}
+
+func (store *ReplicaStore) ListInterestPostings(ctx context.Context, arg ListInterestPostingsParams) ([]InterestPosting, error) {
+	return readFromReplica(ctx, store, func(q Querier) ([]InterestPosting, error) { return q.ListInterestPostings(ctx, arg) })
+}
+
+func (store *ReplicaStore) ListKycDocuments(ctx context.Context, owner string) ([]KycDocument, error) {
+	return readFromReplica(ctx, store, func(q Querier) ([]KycDocument, error) { return q.ListKycDocuments(ctx, owner) })
+}
+
+func (store *ReplicaStore) ListPayees(ctx context.Context, arg ListPayeesParams) ([]Payee, error) {
+	return readFromReplica(ctx, store, func(q Querier) ([]Payee, error) { return q.ListPayees(ctx, arg) })
+}
+
+func (store *ReplicaStore) ListScreeningResults(ctx context.Context, arg ListScreeningResultsParams) ([]ScreeningResult, error) {
+	return readFromReplica(ctx, store, func(q Querier) ([]ScreeningResult, error) { return q.ListScreeningResults(ctx, arg) })
+}
+
+func (store *ReplicaStore) ListTransfers(ctx context.Context, arg ListTransfersParams) ([]Transfer, error) {
+	return readFromReplica(ctx, store, func(q Querier) ([]Transfer, error) { return q.ListTransfers(ctx, arg) })
+}
+
+func (store *ReplicaStore) ListUsersByKycStatus(ctx context.Context, arg ListUsersByKycStatusParams) ([]User, error) {
+	return readFromReplica(ctx, store, func(q Querier) ([]User, error) { return q.ListUsersByKycStatus(ctx, arg) })
+}
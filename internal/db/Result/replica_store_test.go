@@ -0,0 +1,153 @@
+package Anuskh
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReplica struct {
+	Store
+	mu  sync.Mutex
+	lag time.Duration
+	err error
+}
+
+func (replica *fakeReplica) ReplicationLag(ctx context.Context) (time.Duration, error) {
+	replica.mu.Lock()
+	defer replica.mu.Unlock()
+	return replica.lag, replica.err
+}
+
+func (replica *fakeReplica) set(lag time.Duration, err error) {
+	replica.mu.Lock()
+	defer replica.mu.Unlock()
+	replica.lag, replica.err = lag, err
+}
+
+func TestReplicaStoreRouting(t *testing.T) {
+	ctx := context.Background()
+	primary := NewMemoryStore()
+	replica := &fakeReplica{Store: NewMemoryStore()}
+	store := NewReplicaStore(primary, []Replica{replica}, ReplicaConfig{MaxLag: time.Second, CheckPeriod: time.Second})
+
+	// the same user and account exist on both, with different balances
+	for _, s := range []Store{primary, replica} {
+		user := conformanceUser(t, s)
+		_, err := s.CreateAccounts(ctx, CreateAccountsParams{Owner: user.Username, Currency: util.USD, AccountType: AccountChecking, AccountNumber: randomAccountNumber(t)})
+		require.NoError(t, err)
+	}
+	_, err := replica.AddBalance(ctx, AddBalanceParams{ID: 1, Balance: 100})
+	require.NoError(t, err)
+
+	// unmeasured replicas take no reads
+	account, err := store.GetAccounts(ctx, 1)
+	require.NoError(t, err)
+	require.Zero(t, account.Balance)
+
+	store.checkLag(ctx)
+	account, err = store.GetAccounts(ctx, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), account.Balance)
+
+	account, err = store.GetAccountsForUpdate(ctx, 1)
+	require.NoError(t, err)
+	require.Zero(t, account.Balance)
+
+	// writes go to the primary, and a row the replica hasn't seen yet is
+	// still found there
+	entry, err := store.CreateEntries(ctx, CreateEntriesParams{AccountID: 1, Amount: 5})
+	require.NoError(t, err)
+	entry2, err := store.GetEntries(ctx, entry.ID)
+	require.NoError(t, err)
+	require.Equal(t, entry, entry2)
+
+	entries, err := store.ListEntries(ctx, ListEntriesParams{AccountID: 1, Limit: 5})
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	replica.set(2*time.Second, nil)
+	store.checkLag(ctx)
+	entries, err = store.ListEntries(ctx, ListEntriesParams{AccountID: 1, Limit: 5})
+	require.NoError(t, err)
+	require.Equal(t, []Entry{entry}, entries)
+
+	replica.set(0, errors.New("connection refused"))
+	store.checkLag(ctx)
+	account, err = store.GetAccounts(ctx, 1)
+	require.NoError(t, err)
+	require.Zero(t, account.Balance)
+}
+
+func TestReplicaStoreAuthReadsPrimary(t *testing.T) {
+	ctx := context.Background()
+	primary := NewMemoryStore()
+	replica := &fakeReplica{Store: NewMemoryStore()}
+	store := NewReplicaStore(primary, []Replica{replica}, ReplicaConfig{MaxLag: time.Second, CheckPeriod: time.Second})
+
+	// the replica still has a user and key the primary has since removed
+	user := conformanceUser(t, replica)
+	key, err := replica.CreateApiKey(ctx, CreateApiKeyParams{
+		Owner:        user.Username,
+		Name:         "ci",
+		Prefix:       util.RandomString(8),
+		HashedSecret: util.RandomString(32),
+		Scopes:       []string{"accounts:read"},
+		AllowedIps:   []string{},
+	})
+	require.NoError(t, err)
+	store.checkLag(ctx)
+
+	_, err = store.GetUser(ctx, user.Username)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = store.GetApiKeyByPrefix(ctx, key.Prefix)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestReplicaStoreRoundRobin(t *testing.T) {
+	ctx := context.Background()
+	primary := NewMemoryStore()
+
+	var replicas []Replica
+	for i := 0; i < 3; i++ {
+		replica := &fakeReplica{Store: NewMemoryStore()}
+		conformanceUser(t, replica)
+		replicas = append(replicas, replica)
+	}
+	replicas[1].(*fakeReplica).set(time.Hour, nil)
+
+	store := NewReplicaStore(primary, replicas, ReplicaConfig{MaxLag: time.Second, CheckPeriod: time.Second})
+	store.checkLag(ctx)
+
+	seen := map[Querier]int{}
+	for i := 0; i < 6; i++ {
+		seen[store.pick()]++
+	}
+	require.Equal(t, map[Querier]int{store.replicas[0]: 3, store.replicas[2]: 3}, seen)
+}
+
+func TestReplicaStoreMonitorLag(t *testing.T) {
+	replica := &fakeReplica{Store: NewMemoryStore()}
+	store := NewReplicaStore(NewMemoryStore(), []Replica{replica}, ReplicaConfig{MaxLag: time.Second, CheckPeriod: 10 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		store.MonitorLag(ctx)
+		close(done)
+	}()
+
+	require.Eventually(t, func() bool { return store.pick() != nil }, time.Second, 5*time.Millisecond)
+	replica.set(time.Minute, nil)
+	require.Eventually(t, func() bool { return store.pick() == nil }, time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+}
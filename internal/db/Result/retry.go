@@ -0,0 +1,108 @@
+package Anuskh
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/nilesh0729/Transactly/internal/metrics"
+)
+
+// RetryPolicy bounds how often a transaction is retried after Postgres aborted
+// it with a serialization failure or a deadlock.
+type RetryPolicy struct {
+	MaxAttempts int           // including the first one
+	BaseBackoff time.Duration // backoff ceiling before the first retry, doubled after each one
+	MaxBackoff  time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseBackoff: 10 * time.Millisecond,
+	MaxBackoff:  250 * time.Millisecond,
+}
+
+// backoff picks a random wait up to an exponentially growing ceiling, so
+// transactions that collided once don't collide again in lockstep.
+func (policy RetryPolicy) backoff(attempt int) time.Duration {
+	ceiling := policy.BaseBackoff << (attempt - 1)
+	if ceiling <= 0 || ceiling > policy.MaxBackoff {
+		ceiling = policy.MaxBackoff
+	}
+	if ceiling <= 0 {
+		return 0
+	}
+	return rand.N(ceiling + 1)
+}
+
+type StoreOption func(store *RealStore)
+
+// WithTransferIsolation sets the isolation level TransferTx runs at.
+func WithTransferIsolation(level sql.IsolationLevel) StoreOption {
+	return func(store *RealStore) {
+		store.transferIsolation = level
+	}
+}
+
+func WithRetryPolicy(policy RetryPolicy) StoreOption {
+	return func(store *RealStore) {
+		store.retryPolicy = policy
+	}
+}
+
+// ParseIsolationLevel accepts "read committed", "repeatable read" or
+// "serializable", with spaces, dashes or underscores. Empty means the
+// database default.
+func ParseIsolationLevel(s string) (sql.IsolationLevel, error) {
+	normalized := strings.NewReplacer("_", " ", "-", " ").Replace(strings.ToLower(strings.TrimSpace(s)))
+	switch normalized {
+	case "":
+		return sql.LevelDefault, nil
+	case "read committed":
+		return sql.LevelReadCommitted, nil
+	case "repeatable read":
+		return sql.LevelRepeatableRead, nil
+	case "serializable":
+		return sql.LevelSerializable, nil
+	}
+	return sql.LevelDefault, fmt.Errorf("unsupported isolation level : %s", s)
+}
+
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// isRetryable reports whether Postgres aborted the transaction only because
+// of concurrent ones, so running it again may well succeed.
+func isRetryable(err error) bool {
+	code := ErrorCode(err)
+	return code == serializationFailure || code == deadlockDetected
+}
+
+// execTxWithRetry runs fn in a transaction until it commits, fails with an
+// error that isn't retryable, runs out of attempts or ctx is done. fn must
+// not leak state between attempts.
+func (store *RealStore) execTxWithRetry(ctx context.Context, opts *sql.TxOptions, fn func(*Queries) error) error {
+	policy := store.retryPolicy
+	for attempt := 1; ; attempt++ {
+		err := store.execTx(ctx, opts, fn)
+		if err == nil || !isRetryable(err) || attempt >= policy.MaxAttempts {
+			return err
+		}
+
+		metrics.TxRetries.Inc()
+		wait := policy.backoff(attempt)
+		slog.InfoContext(ctx, "retrying transaction", "attempt", attempt, "backoff", wait, "error", err)
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
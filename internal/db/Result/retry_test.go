@@ -0,0 +1,149 @@
+package Anuskh
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryable(t *testing.T) {
+	require.True(t, isRetryable(&pq.Error{Code: serializationFailure}))
+	require.True(t, isRetryable(fmt.Errorf("wrapped: %w", &pq.Error{Code: deadlockDetected})))
+	require.False(t, isRetryable(&pq.Error{Code: "23505"}))
+	require.False(t, isRetryable(sql.ErrNoRows))
+	require.False(t, isRetryable(nil))
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, BaseBackoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+
+	for attempt := 1; attempt <= 10; attempt++ {
+		ceiling := min(policy.BaseBackoff<<(attempt-1), policy.MaxBackoff)
+		for i := 0; i < 20; i++ {
+			wait := policy.backoff(attempt)
+			require.GreaterOrEqual(t, wait, time.Duration(0))
+			require.LessOrEqual(t, wait, ceiling)
+		}
+	}
+
+	require.Zero(t, RetryPolicy{}.backoff(1))
+}
+
+func TestParseIsolationLevel(t *testing.T) {
+	testCases := map[string]sql.IsolationLevel{
+		"":                sql.LevelDefault,
+		"read committed":  sql.LevelReadCommitted,
+		"REPEATABLE_READ": sql.LevelRepeatableRead,
+		"serializable":    sql.LevelSerializable,
+	}
+	for s, want := range testCases {
+		level, err := ParseIsolationLevel(s)
+		require.NoError(t, err, s)
+		require.Equal(t, want, level, s)
+	}
+
+	_, err := ParseIsolationLevel("read uncommitted")
+	require.Error(t, err)
+}
+
+func TestExecTxWithRetry(t *testing.T) {
+	store := NewTxConn(TestDb, WithRetryPolicy(RetryPolicy{MaxAttempts: 3})).(*RealStore)
+
+	attempts := 0
+	err := store.execTxWithRetry(context.Background(), nil, func(q *Queries) error {
+		attempts++
+		if attempts < 3 {
+			return &pq.Error{Code: serializationFailure}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, attempts)
+
+	attempts = 0
+	err = store.execTxWithRetry(context.Background(), nil, func(q *Queries) error {
+		attempts++
+		return &pq.Error{Code: deadlockDetected}
+	})
+	require.Error(t, err)
+	require.Equal(t, 3, attempts)
+
+	attempts = 0
+	failure := errors.New("not transient")
+	err = store.execTxWithRetry(context.Background(), nil, func(q *Queries) error {
+		attempts++
+		return failure
+	})
+	require.ErrorIs(t, err, failure)
+	require.Equal(t, 1, attempts)
+}
+
+func TestExecTxWithRetryStopsOnCancel(t *testing.T) {
+	store := NewTxConn(TestDb, WithRetryPolicy(RetryPolicy{
+		MaxAttempts: 100,
+		BaseBackoff: time.Hour,
+		MaxBackoff:  time.Hour,
+	})).(*RealStore)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	attempts := 0
+	err := store.execTxWithRetry(ctx, nil, func(q *Queries) error {
+		attempts++
+		return &pq.Error{Code: serializationFailure}
+	})
+	require.Error(t, err)
+	require.Equal(t, 1, attempts)
+}
+
+// TestTransferTxSerializableRetry sends transfers both ways between the same
+// two accounts at serializable isolation. Postgres aborts many of them; every
+// one must still go through once retried.
+func TestTransferTxSerializableRetry(t *testing.T) {
+	store := NewTxConn(TestDb,
+		WithTransferIsolation(sql.LevelSerializable),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 50, BaseBackoff: 5 * time.Millisecond, MaxBackoff: 100 * time.Millisecond}),
+	)
+
+	account1 := CreateRandomAccount(t)
+	account2 := CreateRandomAccount(t)
+
+	n := 20
+	amount := int64(10)
+	errs := make(chan error)
+
+	for i := 0; i < n; i++ {
+		fromAccountID, toAccountID := account1.ID, account2.ID
+		if i%2 == 0 {
+			fromAccountID, toAccountID = account2.ID, account1.ID
+		}
+
+		go func() {
+			_, err := store.TransferTx(context.Background(), TransferTxParams{
+				FromAccountID: fromAccountID,
+				ToAccountID:   toAccountID,
+				Amount:        amount,
+			})
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	updatedAccount1, err := testQueries.GetAccounts(context.Background(), account1.ID)
+	require.NoError(t, err)
+	updatedAccount2, err := testQueries.GetAccounts(context.Background(), account2.ID)
+	require.NoError(t, err)
+
+	require.Equal(t, account1.Balance, updatedAccount1.Balance)
+	require.Equal(t, account2.Balance, updatedAccount2.Balance)
+}
@@ -0,0 +1,1316 @@
+package Anuskh
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// testStoreConformance holds every Store implementation to the same behaviour.
+// It only uses random keys, so it can run against a shared database.
+func testStoreConformance(t *testing.T, store Store) {
+	t.Run("Users", func(t *testing.T) { testConformanceUsers(t, store) })
+	t.Run("Accounts", func(t *testing.T) { testConformanceAccounts(t, store) })
+	t.Run("MultipleAccounts", func(t *testing.T) { testConformanceMultipleAccounts(t, store) })
+	t.Run("EntriesAndTransfers", func(t *testing.T) { testConformanceEntriesAndTransfers(t, store) })
+	t.Run("TransferTx", func(t *testing.T) { testConformanceTransferTx(t, store) })
+	t.Run("AccountStatus", func(t *testing.T) { testConformanceAccountStatus(t, store) })
+	t.Run("TransferLimits", func(t *testing.T) { testConformanceTransferLimits(t, store) })
+	t.Run("TransferLimitsConcurrent", func(t *testing.T) { testConformanceTransferLimitsConcurrent(t, store) })
+	t.Run("TransferFees", func(t *testing.T) { testConformanceTransferFees(t, store) })
+	t.Run("TransferTxConcurrent", func(t *testing.T) { testConformanceTransferTxConcurrent(t, store) })
+	t.Run("AccountLimitConcurrent", func(t *testing.T) { testConformanceAccountLimitConcurrent(t, store) })
+	t.Run("ApiKeys", func(t *testing.T) { testConformanceApiKeys(t, store) })
+	t.Run("Payees", func(t *testing.T) { testConformancePayees(t, store) })
+	t.Run("PayeeCoolingOff", func(t *testing.T) { testConformancePayeeCoolingOff(t, store) })
+	t.Run("HeldTransfers", func(t *testing.T) { testConformanceHeldTransfers(t, store) })
+	t.Run("TransferActivity", func(t *testing.T) { testConformanceTransferActivity(t, store) })
+	t.Run("ScreeningResults", func(t *testing.T) { testConformanceScreeningResults(t, store) })
+	t.Run("Kyc", func(t *testing.T) { testConformanceKyc(t, store) })
+	t.Run("KycGating", func(t *testing.T) { testConformanceKycGating(t, store) })
+	t.Run("Interest", func(t *testing.T) { testConformanceInterest(t, store) })
+	t.Run("CurrencySettings", func(t *testing.T) { testConformanceCurrencySettings(t, store) })
+	t.Run("Oauth", func(t *testing.T) { testConformanceOauth(t, store) })
+	t.Run("AuditChain", func(t *testing.T) { testConformanceAuditChain(t, store) })
+	t.Run("RateLimit", func(t *testing.T) { testConformanceRateLimit(t, store) })
+}
+
+func TestMemoryStoreConformance(t *testing.T) {
+	testStoreConformance(t, NewMemoryStore())
+}
+
+func TestRealStoreConformance(t *testing.T) {
+	testStoreConformance(t, NewTxConn(TestDb))
+}
+
+func requireErrorCode(t *testing.T, err error, code string) {
+	require.Error(t, err)
+	require.Equal(t, code, ErrorCode(err), "unexpected error %T: %v", err, err)
+}
+
+func conformanceUser(t *testing.T, store Store) User {
+	user, err := store.CreateUser(context.Background(), CreateUserParams{
+		Username:       util.RandomOwner() + util.RandomString(6),
+		HashedPassword: util.RandomString(32),
+		FullName:       util.RandomOwner(),
+		Email:          util.RandomString(12) + "@" + util.RandomString(6) + ".com",
+	})
+	require.NoError(t, err)
+	return user
+}
+
+func conformanceAccount(t *testing.T, store Store, owner string, currency string) Account {
+	account, err := store.CreateAccounts(context.Background(), CreateAccountsParams{
+		Owner:         owner,
+		Balance:       util.RandomBalance(),
+		Currency:      currency,
+		AccountType:   AccountChecking,
+		AccountNumber: randomAccountNumber(t),
+	})
+	require.NoError(t, err)
+	return account
+}
+
+func testConformanceUsers(t *testing.T, store Store) {
+	ctx := context.Background()
+	user := conformanceUser(t, store)
+
+	require.Equal(t, util.DepositorRole, user.Role)
+	require.True(t, user.PasswordChangedAt.IsZero())
+	require.WithinDuration(t, time.Now(), user.CreatedAt, time.Minute)
+
+	user2, err := store.GetUser(ctx, user.Username)
+	require.NoError(t, err)
+	require.Equal(t, user, user2)
+
+	_, err = store.CreateUser(ctx, CreateUserParams{
+		Username: user.Username,
+		Email:    util.RandomString(12) + "@example.com",
+	})
+	requireErrorCode(t, err, UniqueViolation)
+
+	_, err = store.CreateUser(ctx, CreateUserParams{
+		Username: util.RandomOwner() + util.RandomString(6),
+		Email:    user.Email,
+	})
+	requireErrorCode(t, err, UniqueViolation)
+
+	_, err = store.GetUser(ctx, util.RandomString(20))
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testConformanceAccounts(t *testing.T, store Store) {
+	ctx := context.Background()
+	user := conformanceUser(t, store)
+
+	usd := conformanceAccount(t, store, user.Username, util.USD)
+	eur := conformanceAccount(t, store, user.Username, util.EUR)
+	require.Less(t, usd.ID, eur.ID)
+
+	_, err := store.CreateAccounts(ctx, CreateAccountsParams{Owner: util.RandomString(20), Currency: util.USD, AccountType: AccountChecking, AccountNumber: randomAccountNumber(t)})
+	requireErrorCode(t, err, ForeignKeyViolation)
+
+	_, err = store.CreateAccounts(ctx, CreateAccountsParams{Owner: user.Username, Currency: util.USD, AccountType: "brokerage", AccountNumber: randomAccountNumber(t)})
+	requireErrorCode(t, err, CheckViolation)
+
+	_, err = store.CreateAccounts(ctx, CreateAccountsParams{Owner: user.Username, Currency: util.USD, AccountType: AccountChecking, AccountNumber: usd.AccountNumber})
+	requireErrorCode(t, err, UniqueViolation)
+	require.Equal(t, "accounts_account_number_idx", ErrorConstraint(err))
+
+	account, err := store.GetAccounts(ctx, usd.ID)
+	require.NoError(t, err)
+	require.Equal(t, usd, account)
+
+	account, err = store.GetAccountByNumber(ctx, usd.AccountNumber)
+	require.NoError(t, err)
+	require.Equal(t, usd, account)
+
+	_, err = store.GetAccountByNumber(ctx, randomAccountNumber(t))
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	accounts, err := store.ListAccounts(ctx, ListAccountsParams{Owner: user.Username, Limit: 5})
+	require.NoError(t, err)
+	require.Equal(t, []Account{usd, eur}, accounts)
+
+	accounts, err = store.ListAccounts(ctx, ListAccountsParams{Owner: user.Username, Limit: 5, Offset: 2})
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+	require.NotNil(t, accounts)
+
+	account, err = store.AddBalance(ctx, AddBalanceParams{ID: usd.ID, Balance: 10})
+	require.NoError(t, err)
+	require.Equal(t, usd.Balance+10, account.Balance)
+
+	account, err = store.UpdateAccounts(ctx, UpdateAccountsParams{ID: usd.ID, Balance: 7})
+	require.NoError(t, err)
+	require.Equal(t, int64(7), account.Balance)
+
+	_, err = store.AddBalance(ctx, AddBalanceParams{ID: -1, Balance: 10})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	require.NoError(t, store.DeleteAccounts(ctx, eur.ID))
+	_, err = store.GetAccounts(ctx, eur.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testConformanceMultipleAccounts(t *testing.T, store Store) {
+	ctx := context.Background()
+	user := conformanceUser(t, store)
+
+	create := func(nickname string, accountType string, balance int64) Account {
+		account, err := store.CreateAccountTx(ctx, CreateAccountTxParams{
+			CreateAccountsParams: CreateAccountsParams{
+				Owner:       user.Username,
+				Balance:     balance,
+				Currency:    util.INR,
+				Nickname:    nickname,
+				AccountType: accountType,
+			},
+			MaxAccounts: 3,
+		})
+		require.NoError(t, err)
+		require.Equal(t, nickname, account.Nickname)
+		require.Equal(t, accountType, account.AccountType)
+		require.True(t, util.IsValidAccountNumber(account.AccountNumber))
+		return account
+	}
+	bills := create("bills", AccountChecking, 30)
+	savings := create("savings", AccountSavings, 10)
+
+	_, err := store.CreateAccounts(ctx, CreateAccountsParams{Owner: user.Username, Currency: util.USD, Nickname: "bills", AccountType: AccountChecking, AccountNumber: randomAccountNumber(t)})
+	requireErrorCode(t, err, UniqueViolation)
+
+	// nicknames are optional, and only unique once set
+	unnamed := create("", AccountSavings, 20)
+	_, err = store.CreateAccountTx(ctx, CreateAccountTxParams{
+		CreateAccountsParams: CreateAccountsParams{Owner: user.Username, Currency: util.INR, AccountType: AccountSavings},
+		MaxAccounts:          3,
+	})
+	require.ErrorIs(t, err, ErrAccountLimitReached)
+
+	count, err := store.CountAccounts(ctx, user.Username)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+
+	list := func(accountType string, sortBy string) []Account {
+		accounts, err := store.ListAccounts(ctx, ListAccountsParams{
+			Owner:       user.Username,
+			Limit:       5,
+			AccountType: sql.NullString{String: accountType, Valid: accountType != ""},
+			SortBy:      sortBy,
+		})
+		require.NoError(t, err)
+		return accounts
+	}
+	require.Equal(t, []Account{bills, savings, unnamed}, list("", ""))
+	require.Equal(t, []Account{savings, unnamed}, list(AccountSavings, ""))
+	require.Equal(t, []Account{savings, unnamed, bills}, list("", "balance"))
+	require.Equal(t, []Account{unnamed, bills, savings}, list("", "nickname"))
+	require.Equal(t, []Account{unnamed, savings}, list(AccountSavings, "nickname"))
+
+	_, err = store.CreateAccountTx(ctx, CreateAccountTxParams{
+		CreateAccountsParams: CreateAccountsParams{Owner: util.RandomString(20), Currency: util.INR, AccountType: AccountChecking},
+		MaxAccounts:          3,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testConformanceAccountLimitConcurrent(t *testing.T, store Store) {
+	ctx := context.Background()
+	user := conformanceUser(t, store)
+
+	n := 5
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.CreateAccountTx(ctx, CreateAccountTxParams{
+				CreateAccountsParams: CreateAccountsParams{Owner: user.Username, Currency: util.EUR, AccountType: AccountChecking},
+				MaxAccounts:          2,
+			})
+			errs <- err
+		}()
+	}
+
+	created := 0
+	for i := 0; i < n; i++ {
+		if err := <-errs; err == nil {
+			created++
+		} else {
+			require.ErrorIs(t, err, ErrAccountLimitReached)
+		}
+	}
+	require.Equal(t, 2, created)
+}
+
+func testConformanceEntriesAndTransfers(t *testing.T, store Store) {
+	ctx := context.Background()
+	account1 := conformanceAccount(t, store, conformanceUser(t, store).Username, util.USD)
+	account2 := conformanceAccount(t, store, conformanceUser(t, store).Username, util.USD)
+
+	entry, err := store.CreateEntries(ctx, CreateEntriesParams{AccountID: account1.ID, Amount: 5})
+	require.NoError(t, err)
+
+	entry2, err := store.GetEntries(ctx, entry.ID)
+	require.NoError(t, err)
+	require.Equal(t, entry, entry2)
+
+	_, err = store.CreateEntries(ctx, CreateEntriesParams{AccountID: -1, Amount: 5})
+	requireErrorCode(t, err, ForeignKeyViolation)
+
+	transfer, err := store.CreateTransfers(ctx, CreateTransfersParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        5,
+	})
+	require.NoError(t, err)
+
+	_, err = store.CreateTransfers(ctx, CreateTransfersParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   -1,
+		Amount:        5,
+	})
+	requireErrorCode(t, err, ForeignKeyViolation)
+
+	transfers, err := store.ListTransfers(ctx, ListTransfersParams{
+		FromAccountID: account2.ID,
+		ToAccountID:   account2.ID,
+		Limit:         5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Transfer{transfer}, transfers)
+
+	// both accounts are still referenced
+	requireErrorCode(t, store.DeleteAccounts(ctx, account1.ID), ForeignKeyViolation)
+	requireErrorCode(t, store.DeleteAccounts(ctx, account2.ID), ForeignKeyViolation)
+
+	require.NoError(t, store.DeleteTransfers(ctx, transfer.ID))
+	require.NoError(t, store.DeleteAccounts(ctx, account2.ID))
+
+	require.NoError(t, store.DeleteEntries(ctx, account1.ID))
+	entries, err := store.ListEntries(ctx, ListEntriesParams{AccountID: account1.ID, Limit: 5})
+	require.NoError(t, err)
+	require.Empty(t, entries)
+	require.NoError(t, store.DeleteAccounts(ctx, account1.ID))
+}
+
+func testConformanceTransferTx(t *testing.T, store Store) {
+	ctx := context.Background()
+	account1 := conformanceAccount(t, store, conformanceUser(t, store).Username, util.USD)
+	account2 := conformanceAccount(t, store, conformanceUser(t, store).Username, util.USD)
+
+	result, err := store.TransferTx(ctx, TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   account2.ID,
+		Amount:        10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance-10, result.FromAccount.Balance)
+	require.Equal(t, account2.Balance+10, result.ToAccount.Balance)
+	require.Equal(t, int64(-10), result.FromEntry.Amount)
+	require.Equal(t, int64(10), result.ToEntry.Amount)
+
+	transfer, err := store.GetTransfers(ctx, result.Transfer.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.Transfer, transfer)
+
+	// a failed transfer must leave nothing behind
+	_, err = store.TransferTx(ctx, TransferTxParams{
+		FromAccountID: account1.ID,
+		ToAccountID:   -1,
+		Amount:        10,
+	})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	entries, err := store.ListEntries(ctx, ListEntriesParams{AccountID: account1.ID, Limit: 5})
+	require.NoError(t, err)
+	require.Equal(t, []Entry{result.FromEntry}, entries)
+
+	account, err := store.GetAccounts(ctx, account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, result.FromAccount.Balance, account.Balance)
+}
+
+func testConformanceTransferLimits(t *testing.T, store Store) {
+	ctx := context.Background()
+	sender := conformanceUser(t, store)
+	checking := conformanceAccount(t, store, sender.Username, util.USD)
+	savings := conformanceAccount(t, store, sender.Username, util.USD)
+	euros := conformanceAccount(t, store, sender.Username, util.EUR)
+	recipient := conformanceAccount(t, store, conformanceUser(t, store).Username, util.USD)
+	limits := util.TransferLimits{"standard/USD": {PerTransaction: 100, Daily: 150, Monthly: 1000}}
+
+	transfer := func(from Account, amount int64) error {
+		_, err := store.TransferTx(ctx, TransferTxParams{
+			FromAccountID: from.ID,
+			ToAccountID:   recipient.ID,
+			Amount:        amount,
+			Limits:        limits,
+		})
+		return err
+	}
+
+	require.ErrorIs(t, transfer(checking, 120), ErrTransferLimitExceeded)
+	require.NoError(t, transfer(checking, 80))
+	// the daily limit covers all of the sender's accounts in the currency
+	require.ErrorIs(t, transfer(savings, 80), ErrTransferLimitExceeded)
+	require.NoError(t, transfer(savings, 70))
+	require.ErrorIs(t, transfer(checking, 1), ErrTransferLimitExceeded)
+
+	usage, err := TransferUsage(ctx, store, sender.Username, util.USD, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, SumTransfersRow{DailyAmount: 150, MonthlyAmount: 150, HourlyCount: 2}, usage)
+
+	// other currencies have their own limits, none here
+	_, err = store.TransferTx(ctx, TransferTxParams{
+		FromAccountID: euros.ID,
+		ToAccountID:   conformanceAccount(t, store, recipient.Owner, util.EUR).ID,
+		Amount:        500,
+		Limits:        limits,
+	})
+	require.NoError(t, err)
+}
+
+func testConformanceTransferFees(t *testing.T, store Store) {
+	ctx := context.Background()
+	sender := conformanceUser(t, store)
+	from := conformanceAccount(t, store, sender.Username, util.USD)
+	savings := conformanceAccount(t, store, sender.Username, util.USD)
+	to := conformanceAccount(t, store, conformanceUser(t, store).Username, util.USD)
+	house := conformanceAccount(t, store, conformanceUser(t, store).Username, util.USD)
+	fees := util.FeeSchedule{util.USD: {Account: house.ID, Tiers: []util.FeeTier{{Flat: 5, Bps: 100}}}}
+
+	result, err := store.TransferTx(ctx, TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        100,
+		Fees:          fees,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(6), result.Transfer.Fee)
+	require.Equal(t, sql.NullInt64{Int64: house.ID, Valid: true}, result.Transfer.FeeAccountID)
+	require.Equal(t, from.Balance-106, result.FromAccount.Balance)
+	require.Equal(t, to.Balance+100, result.ToAccount.Balance)
+	require.Equal(t, int64(-100), result.FromEntry.Amount)
+	require.NotNil(t, result.Fee)
+	require.Equal(t, from.ID, result.Fee.FromEntry.AccountID)
+	require.Equal(t, int64(-6), result.Fee.FromEntry.Amount)
+	require.Equal(t, house.ID, result.Fee.ToEntry.AccountID)
+	require.Equal(t, int64(6), result.Fee.ToEntry.Amount)
+
+	house2, err := store.GetAccounts(ctx, house.ID)
+	require.NoError(t, err)
+	require.Equal(t, house.Balance+6, house2.Balance)
+
+	// moving money between one's own accounts is free
+	result, err = store.TransferTx(ctx, TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   savings.ID,
+		Amount:        100,
+		Fees:          fees,
+	})
+	require.NoError(t, err)
+	require.Zero(t, result.Transfer.Fee)
+	require.False(t, result.Transfer.FeeAccountID.Valid)
+	require.Nil(t, result.Fee)
+	require.Equal(t, from.Balance-206, result.FromAccount.Balance)
+
+	// a fee account in another currency fails the whole transfer
+	_, err = store.TransferTx(ctx, TransferTxParams{
+		FromAccountID: from.ID,
+		ToAccountID:   to.ID,
+		Amount:        100,
+		Fees:          util.FeeSchedule{util.USD: {Account: conformanceAccount(t, store, house.Owner, util.EUR).ID, Tiers: []util.FeeTier{{Flat: 5}}}},
+	})
+	require.Error(t, err)
+	from2, err := store.GetAccounts(ctx, from.ID)
+	require.NoError(t, err)
+	require.Equal(t, from.Balance-206, from2.Balance)
+}
+
+func testConformanceTransferLimitsConcurrent(t *testing.T, store Store) {
+	ctx := context.Background()
+	sender := conformanceUser(t, store)
+	accounts := []Account{
+		conformanceAccount(t, store, sender.Username, util.USD),
+		conformanceAccount(t, store, sender.Username, util.USD),
+	}
+	recipient := conformanceAccount(t, store, conformanceUser(t, store).Username, util.USD)
+
+	n := 6
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.TransferTx(ctx, TransferTxParams{
+				FromAccountID: accounts[i%2].ID,
+				ToAccountID:   recipient.ID,
+				Amount:        1,
+				Limits:        util.TransferLimits{"standard/*": {HourlyCount: 2}},
+			})
+			errs <- err
+		}()
+	}
+
+	sent := 0
+	for i := 0; i < n; i++ {
+		if err := <-errs; err == nil {
+			sent++
+		} else {
+			require.ErrorIs(t, err, ErrTransferLimitExceeded)
+		}
+	}
+	require.Equal(t, 2, sent)
+}
+
+func testConformanceAccountStatus(t *testing.T, store Store) {
+	ctx := context.Background()
+	account1 := conformanceAccount(t, store, conformanceUser(t, store).Username, util.USD)
+	account2 := conformanceAccount(t, store, conformanceUser(t, store).Username, util.USD)
+	require.Equal(t, AccountActive, account1.Status)
+
+	frozen, err := store.ChangeAccountStatus(ctx, ChangeAccountStatusParams{
+		AccountID: account1.ID,
+		From:      AccountActive,
+		To:        AccountFrozen,
+		Reason:    "chargeback investigation",
+	})
+	require.NoError(t, err)
+	require.Equal(t, AccountFrozen, frozen.Status)
+	require.Equal(t, "chargeback investigation", frozen.StatusReason)
+
+	for _, arg := range []TransferTxParams{
+		{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 1},
+		{FromAccountID: account2.ID, ToAccountID: account1.ID, Amount: 1},
+	} {
+		_, err = store.TransferTx(ctx, arg)
+		require.ErrorIs(t, err, ErrAccountNotActive)
+	}
+
+	_, err = store.ChangeAccountStatus(ctx, ChangeAccountStatusParams{AccountID: account1.ID, From: AccountFrozen, To: AccountClosed})
+	require.ErrorIs(t, err, ErrInvalidStatusTransition)
+
+	// the account isn't closed, so this isn't a reopen
+	_, err = store.ChangeAccountStatus(ctx, ChangeAccountStatusParams{AccountID: account1.ID, From: AccountClosed, To: AccountActive})
+	require.ErrorIs(t, err, ErrInvalidStatusTransition)
+
+	_, err = store.ChangeAccountStatus(ctx, ChangeAccountStatusParams{AccountID: account1.ID, From: AccountFrozen, To: AccountActive})
+	require.NoError(t, err)
+
+	_, err = store.ChangeAccountStatus(ctx, ChangeAccountStatusParams{AccountID: account1.ID, From: AccountActive, To: AccountClosed})
+	require.ErrorIs(t, err, ErrAccountNotEmpty)
+
+	_, err = store.UpdateAccounts(ctx, UpdateAccountsParams{ID: account1.ID, Balance: 0})
+	require.NoError(t, err)
+	closed, err := store.ChangeAccountStatus(ctx, ChangeAccountStatusParams{AccountID: account1.ID, From: AccountActive, To: AccountClosed})
+	require.NoError(t, err)
+	require.Equal(t, AccountClosed, closed.Status)
+
+	_, err = store.TransferTx(ctx, TransferTxParams{FromAccountID: account2.ID, ToAccountID: account1.ID, Amount: 1})
+	require.ErrorIs(t, err, ErrAccountNotActive)
+
+	entries, err := store.ListEntries(ctx, ListEntriesParams{AccountID: account1.ID, Limit: 5})
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	_, err = store.ChangeAccountStatus(ctx, ChangeAccountStatusParams{AccountID: -1, From: AccountActive, To: AccountFrozen})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testConformanceTransferTxConcurrent(t *testing.T, store Store) {
+	ctx := context.Background()
+	account1 := conformanceAccount(t, store, conformanceUser(t, store).Username, util.USD)
+	account2 := conformanceAccount(t, store, conformanceUser(t, store).Username, util.USD)
+
+	n := 10
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		arg := TransferTxParams{FromAccountID: account1.ID, ToAccountID: account2.ID, Amount: 10}
+		if i%2 == 0 {
+			arg.FromAccountID, arg.ToAccountID = arg.ToAccountID, arg.FromAccountID
+		}
+		go func() {
+			_, err := store.TransferTx(ctx, arg)
+			errs <- err
+		}()
+	}
+	for i := 0; i < n; i++ {
+		require.NoError(t, <-errs)
+	}
+
+	updated1, err := store.GetAccounts(ctx, account1.ID)
+	require.NoError(t, err)
+	require.Equal(t, account1.Balance, updated1.Balance)
+
+	updated2, err := store.GetAccounts(ctx, account2.ID)
+	require.NoError(t, err)
+	require.Equal(t, account2.Balance, updated2.Balance)
+}
+
+func testConformancePayees(t *testing.T, store Store) {
+	ctx := context.Background()
+	user := conformanceUser(t, store)
+	target := conformanceAccount(t, store, conformanceUser(t, store).Username, util.EUR)
+	target2 := conformanceAccount(t, store, conformanceUser(t, store).Username, util.EUR)
+
+	arg := CreatePayeeParams{
+		Owner:         user.Username,
+		AccountID:     target.ID,
+		AccountNumber: target.AccountNumber,
+		Nickname:      "landlord",
+		Currency:      target.Currency,
+	}
+	payee, err := store.CreatePayee(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.AccountID, payee.AccountID)
+	require.WithinDuration(t, time.Now(), payee.CreatedAt, time.Minute)
+
+	payee2, err := store.GetPayee(ctx, payee.ID)
+	require.NoError(t, err)
+	require.Equal(t, payee, payee2)
+
+	_, err = store.CreatePayee(ctx, CreatePayeeParams{Owner: user.Username, AccountID: target.ID, AccountNumber: target.AccountNumber, Nickname: "rent", Currency: target.Currency})
+	requireErrorCode(t, err, UniqueViolation)
+	_, err = store.CreatePayee(ctx, CreatePayeeParams{Owner: user.Username, AccountID: target2.ID, AccountNumber: target2.AccountNumber, Nickname: "landlord", Currency: target2.Currency})
+	requireErrorCode(t, err, UniqueViolation)
+	_, err = store.CreatePayee(ctx, CreatePayeeParams{Owner: user.Username, AccountID: -1, AccountNumber: randomAccountNumber(t), Nickname: "nobody", Currency: util.EUR})
+	requireErrorCode(t, err, ForeignKeyViolation)
+
+	// another user can save the same account under the same name
+	other, err := store.CreatePayee(ctx, CreatePayeeParams{Owner: conformanceUser(t, store).Username, AccountID: target.ID, AccountNumber: target.AccountNumber, Nickname: "landlord", Currency: target.Currency})
+	require.NoError(t, err)
+
+	payees, err := store.ListPayees(ctx, ListPayeesParams{Owner: user.Username, Limit: 5})
+	require.NoError(t, err)
+	require.Equal(t, []Payee{payee}, payees)
+
+	rows, err := store.DeletePayee(ctx, DeletePayeeParams{ID: payee.ID, Owner: other.Owner})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	rows, err = store.DeletePayee(ctx, DeletePayeeParams{ID: payee.ID, Owner: user.Username})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	_, err = store.GetPayee(ctx, payee.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// payees go with the account they point at
+	require.NoError(t, store.DeleteAccounts(ctx, target.ID))
+	_, err = store.GetPayee(ctx, other.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testConformancePayeeCoolingOff(t *testing.T, store Store) {
+	ctx := context.Background()
+	sender := conformanceUser(t, store)
+	from := conformanceAccount(t, store, sender.Username, util.USD)
+	own := conformanceAccount(t, store, sender.Username, util.USD)
+	saved := conformanceAccount(t, store, conformanceUser(t, store).Username, util.USD)
+	unsaved := conformanceAccount(t, store, conformanceUser(t, store).Username, util.USD)
+
+	_, err := store.CreatePayee(ctx, CreatePayeeParams{Owner: sender.Username, AccountID: saved.ID, AccountNumber: saved.AccountNumber, Nickname: "landlord", Currency: util.USD})
+	require.NoError(t, err)
+
+	coolingOff := CoolingOff{Period: time.Hour, Limit: 1000}
+	transfer := func(to Account, amount int64, coolingOff CoolingOff) error {
+		_, err := store.TransferTx(ctx, TransferTxParams{FromAccountID: from.ID, ToAccountID: to.ID, Amount: amount, CoolingOff: coolingOff})
+		return err
+	}
+
+	// the limit covers everything sent within the period, not each transfer
+	require.NoError(t, transfer(saved, 600, coolingOff))
+	require.ErrorIs(t, transfer(saved, 401, coolingOff), ErrPayeeCoolingOff)
+	require.NoError(t, transfer(saved, 400, coolingOff))
+	require.ErrorIs(t, transfer(saved, 1, coolingOff), ErrPayeeCoolingOff)
+
+	// accounts that aren't saved payees are only capped when asked to
+	require.NoError(t, transfer(unsaved, 5000, coolingOff))
+	coolingOff.UnsavedLimit = 6000
+	require.NoError(t, transfer(unsaved, 1000, coolingOff))
+	require.ErrorIs(t, transfer(unsaved, 1, coolingOff), ErrPayeeCoolingOff)
+
+	require.NoError(t, transfer(own, 5000, coolingOff))
+
+	// approving a held transfer counts what was sent while it waited
+	held, err := store.CreateHeldTransfer(ctx, CreateHeldTransferParams{
+		RequestedBy:   sender.Username,
+		FromAccountID: from.ID,
+		ToAccountID:   unsaved.ID,
+		Amount:        1,
+		Currency:      util.USD,
+		Reasons:       []string{"velocity"},
+	})
+	require.NoError(t, err)
+	_, err = store.ReviewHeldTransferTx(ctx, ReviewHeldTransferTxParams{ID: held.ID, Approve: true, Reviewer: "admin", CoolingOff: coolingOff})
+	require.ErrorIs(t, err, ErrPayeeCoolingOff)
+	stillPending, err := store.GetHeldTransfer(ctx, held.ID)
+	require.NoError(t, err)
+	require.Equal(t, HeldTransferPending, stillPending.Status)
+
+	// a transfer is checked before it is held, so one the new_payee rule
+	// holds with the default settings can still be approved
+	defaults := CoolingOff{Period: 24 * time.Hour, Limit: 1000}
+	require.ErrorIs(t, store.CheckTransferTx(ctx, TransferTxParams{FromAccountID: from.ID, ToAccountID: saved.ID, Amount: 5000, CoolingOff: defaults}), ErrPayeeCoolingOff)
+	stranger := conformanceAccount(t, store, conformanceUser(t, store).Username, util.USD)
+	newPayee := TransferTxParams{FromAccountID: from.ID, ToAccountID: stranger.ID, Amount: 5000, CoolingOff: defaults}
+	require.NoError(t, store.CheckTransferTx(ctx, newPayee))
+	held, err = store.CreateHeldTransfer(ctx, CreateHeldTransferParams{
+		RequestedBy:   sender.Username,
+		FromAccountID: from.ID,
+		ToAccountID:   stranger.ID,
+		Amount:        newPayee.Amount,
+		Currency:      util.USD,
+		Reasons:       []string{"new_payee"},
+	})
+	require.NoError(t, err)
+	approved, err := store.ReviewHeldTransferTx(ctx, ReviewHeldTransferTxParams{ID: held.ID, Approve: true, Reviewer: "admin", CoolingOff: defaults})
+	require.NoError(t, err)
+	require.Equal(t, HeldTransferApproved, approved.HeldTransfer.Status)
+
+	// once the payee has been saved for the period it no longer applies
+	time.Sleep(10 * time.Millisecond)
+	require.NoError(t, transfer(saved, 5000, CoolingOff{Period: 5 * time.Millisecond, Limit: 1000}))
+}
+
+func testConformanceApiKeys(t *testing.T, store Store) {
+	ctx := context.Background()
+	user := conformanceUser(t, store)
+
+	arg := CreateApiKeyParams{
+		Owner:        user.Username,
+		Name:         "ci",
+		Prefix:       util.RandomString(12),
+		HashedSecret: util.RandomString(32),
+		Scopes:       []string{"accounts:read"},
+		AllowedIps:   []string{},
+	}
+	key, err := store.CreateApiKey(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, arg.Scopes, key.Scopes)
+
+	key2, err := store.GetApiKeyByPrefix(ctx, arg.Prefix)
+	require.NoError(t, err)
+	require.Equal(t, key, key2)
+
+	_, err = store.CreateApiKey(ctx, arg)
+	requireErrorCode(t, err, UniqueViolation)
+
+	arg.Prefix = util.RandomString(12)
+	arg.Owner = util.RandomString(20)
+	_, err = store.CreateApiKey(ctx, arg)
+	requireErrorCode(t, err, ForeignKeyViolation)
+
+	keys, err := store.ListApiKeys(ctx, ListApiKeysParams{Owner: user.Username, Limit: 5})
+	require.NoError(t, err)
+	require.Equal(t, []ApiKey{key}, keys)
+
+	rows, err := store.DeleteApiKey(ctx, DeleteApiKeyParams{ID: key.ID, Owner: util.RandomString(20)})
+	require.NoError(t, err)
+	require.Zero(t, rows)
+
+	rows, err = store.DeleteApiKey(ctx, DeleteApiKeyParams{ID: key.ID, Owner: user.Username})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), rows)
+
+	_, err = store.GetApiKeyByPrefix(ctx, key.Prefix)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testConformanceHeldTransfers(t *testing.T, store Store) {
+	ctx := context.Background()
+	sender := conformanceUser(t, store)
+	from := conformanceAccount(t, store, sender.Username, util.USD)
+	to := conformanceAccount(t, store, conformanceUser(t, store).Username, util.USD)
+
+	hold := func() HeldTransfer {
+		held, err := store.CreateHeldTransfer(ctx, CreateHeldTransferParams{
+			RequestedBy:   sender.Username,
+			FromAccountID: from.ID,
+			ToAccountID:   to.ID,
+			Amount:        25,
+			Currency:      util.USD,
+			Reasons:       []string{"velocity: 11 transfers in 10m0s"},
+		})
+		require.NoError(t, err)
+		return held
+	}
+
+	held := hold()
+	require.Equal(t, HeldTransferPending, held.Status)
+	require.False(t, held.TransferID.Valid)
+	require.False(t, held.ReviewedAt.Valid)
+
+	_, err := store.CreateHeldTransfer(ctx, CreateHeldTransferParams{
+		RequestedBy:   sender.Username,
+		FromAccountID: -1,
+		ToAccountID:   to.ID,
+		Reasons:       []string{},
+	})
+	requireErrorCode(t, err, ForeignKeyViolation)
+
+	pending, err := store.ListHeldTransfers(ctx, ListHeldTransfersParams{Status: HeldTransferPending, Limit: 1000})
+	require.NoError(t, err)
+	require.Contains(t, pending, held)
+
+	approved, err := store.ReviewHeldTransferTx(ctx, ReviewHeldTransferTxParams{ID: held.ID, Approve: true, Reviewer: "admin", Note: "known landlord"})
+	require.NoError(t, err)
+	require.Equal(t, HeldTransferApproved, approved.HeldTransfer.Status)
+	require.Equal(t, "admin", approved.HeldTransfer.ReviewedBy)
+	require.True(t, approved.HeldTransfer.ReviewedAt.Valid)
+	require.NotNil(t, approved.Transfer)
+	require.Equal(t, approved.Transfer.Transfer.ID, approved.HeldTransfer.TransferID.Int64)
+	require.Equal(t, from.Balance-25, approved.Transfer.FromAccount.Balance)
+
+	_, err = store.ReviewHeldTransferTx(ctx, ReviewHeldTransferTxParams{ID: held.ID, Reviewer: "admin"})
+	require.ErrorIs(t, err, ErrHeldTransferReviewed)
+
+	rejected, err := store.ReviewHeldTransferTx(ctx, ReviewHeldTransferTxParams{ID: hold().ID, Reviewer: "admin", Note: "mule account"})
+	require.NoError(t, err)
+	require.Equal(t, HeldTransferRejected, rejected.HeldTransfer.Status)
+	require.Nil(t, rejected.Transfer)
+	require.False(t, rejected.HeldTransfer.TransferID.Valid)
+
+	// an approval that can't go through leaves the transfer pending
+	frozen := hold()
+	_, err = store.ChangeAccountStatus(ctx, ChangeAccountStatusParams{AccountID: to.ID, From: AccountActive, To: AccountFrozen, Reason: "investigation"})
+	require.NoError(t, err)
+	_, err = store.ReviewHeldTransferTx(ctx, ReviewHeldTransferTxParams{ID: frozen.ID, Approve: true, Reviewer: "admin"})
+	require.ErrorIs(t, err, ErrAccountNotActive)
+
+	stillPending, err := store.GetHeldTransfer(ctx, frozen.ID)
+	require.NoError(t, err)
+	require.Equal(t, frozen, stillPending)
+
+	_, err = store.ReviewHeldTransferTx(ctx, ReviewHeldTransferTxParams{ID: -1, Approve: true})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func testConformanceTransferActivity(t *testing.T, store Store) {
+	ctx := context.Background()
+	sender := conformanceUser(t, store)
+	from := conformanceAccount(t, store, sender.Username, util.USD)
+	recipients := []Account{
+		conformanceAccount(t, store, conformanceUser(t, store).Username, util.USD),
+		conformanceAccount(t, store, conformanceUser(t, store).Username, util.USD),
+	}
+
+	_, err := store.GetLatestEntry(ctx, from.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	var last TransferTxResult
+	for i, amount := range []int64{9500, 9500, 9550, 20} {
+		to := recipients[0]
+		if i == 3 {
+			to = recipients[1]
+		}
+		last, err = store.TransferTx(ctx, TransferTxParams{FromAccountID: from.ID, ToAccountID: to.ID, Amount: amount})
+		require.NoError(t, err)
+	}
+	since := time.Now().Add(-time.Hour)
+
+	activity, err := store.GetTransferActivity(ctx, GetTransferActivityParams{ToAccountID: recipients[0].ID, Owner: sender.Username, Since: since})
+	require.NoError(t, err)
+	require.Equal(t, GetTransferActivityRow{Transfers: 4, Recipients: 2, ToAccountTransfers: 3}, activity)
+
+	activity, err = store.GetTransferActivity(ctx, GetTransferActivityParams{Owner: sender.Username, Since: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	require.Zero(t, activity.Transfers)
+
+	count, err := store.CountTransfersInRange(ctx, CountTransfersInRangeParams{
+		Owner:     sender.Username,
+		Since:     since,
+		MinAmount: 9000,
+		MaxAmount: 10000,
+		RoundTo:   100,
+		Currency:  util.USD,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), count)
+
+	count, err = store.CountTransfersInRange(ctx, CountTransfersInRangeParams{
+		Owner:     sender.Username,
+		Since:     since,
+		MinAmount: 9000,
+		MaxAmount: 10000,
+		RoundTo:   100,
+		Currency:  util.EUR,
+	})
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	count, err = store.CountTransfersTo(ctx, CountTransfersToParams{Owner: sender.Username, ToAccountID: recipients[0].ID})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), count)
+
+	count, err = store.CountTransfersTo(ctx, CountTransfersToParams{Owner: sender.Username, ToAccountID: from.ID})
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	entry, err := store.GetLatestEntry(ctx, from.ID)
+	require.NoError(t, err)
+	require.Equal(t, last.FromEntry, entry)
+}
+
+func testConformanceScreeningResults(t *testing.T, store Store) {
+	ctx := context.Background()
+	subject := util.RandomOwner() + util.RandomString(6)
+
+	clear, err := store.CreateScreeningResult(ctx, CreateScreeningResultParams{
+		SubjectType: "user",
+		Subject:     subject,
+		Name:        "Alice Smith",
+		RequestedBy: subject,
+		ListVersion: "v1",
+		Score:       0.42,
+	})
+	require.NoError(t, err)
+	require.False(t, clear.Matched)
+	require.Empty(t, clear.EntryID)
+	require.WithinDuration(t, time.Now(), clear.CreatedAt, time.Minute)
+
+	hit, err := store.CreateScreeningResult(ctx, CreateScreeningResultParams{
+		SubjectType: "payee",
+		Subject:     subject,
+		Name:        "Ivan Drago",
+		RequestedBy: subject,
+		ListVersion: "v1",
+		Matched:     true,
+		Score:       0.97,
+		EntryID:     "1",
+		EntryName:   "Ivan Petrovich Drago",
+	})
+	require.NoError(t, err)
+	require.Greater(t, hit.ID, clear.ID)
+
+	results, err := store.ListScreeningResults(ctx, ListScreeningResultsParams{Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, []ScreeningResult{hit, clear}, results)
+
+	results, err = store.ListScreeningResults(ctx, ListScreeningResultsParams{Limit: 1, Matched: sql.NullBool{Bool: false, Valid: true}})
+	require.NoError(t, err)
+	require.Equal(t, []ScreeningResult{clear}, results)
+
+	_, err = store.CreateScreeningResult(ctx, CreateScreeningResultParams{SubjectType: "account", Subject: subject})
+	requireErrorCode(t, err, CheckViolation)
+}
+
+func testConformanceKyc(t *testing.T, store Store) {
+	ctx := context.Background()
+	user := conformanceUser(t, store)
+	admin := conformanceUser(t, store)
+	require.Equal(t, KycUnverified, user.KycStatus)
+
+	change := func(to string, reviewer string, note string) (User, error) {
+		return store.ChangeKycStatus(ctx, ChangeKycStatusParams{Username: user.Username, To: to, Reviewer: reviewer, Note: note})
+	}
+
+	_, err := change(KycPending, "", "")
+	require.ErrorIs(t, err, ErrKycNoDocuments)
+	_, err = change(KycVerified, admin.Username, "")
+	require.ErrorIs(t, err, ErrInvalidKycTransition)
+
+	upload := func(kind string) (KycDocument, error) {
+		return store.CreateKycDocument(ctx, CreateKycDocumentParams{
+			Owner:       user.Username,
+			Kind:        kind,
+			ContentType: "application/pdf",
+			SizeBytes:   1024,
+			Sha256:      util.RandomString(64),
+			BlobKey:     "kyc/" + user.Username + "/" + util.RandomString(12),
+		})
+	}
+	passport, err := upload(KycPassport)
+	require.NoError(t, err)
+	bill, err := upload(KycProofOfAddress)
+	require.NoError(t, err)
+	_, err = upload("library_card")
+	requireErrorCode(t, err, CheckViolation)
+
+	_, err = store.CreateKycDocument(ctx, CreateKycDocumentParams{Owner: util.RandomString(12), Kind: KycPassport, BlobKey: util.RandomString(12)})
+	requireErrorCode(t, err, ForeignKeyViolation)
+	_, err = store.CreateKycDocument(ctx, CreateKycDocumentParams{Owner: user.Username, Kind: KycPassport, BlobKey: passport.BlobKey})
+	requireErrorCode(t, err, UniqueViolation)
+
+	documents, err := store.ListKycDocuments(ctx, user.Username)
+	require.NoError(t, err)
+	require.Equal(t, []KycDocument{passport, bill}, documents)
+	document, err := store.GetKycDocument(ctx, bill.ID)
+	require.NoError(t, err)
+	require.Equal(t, bill, document)
+
+	pending, err := change(KycPending, "", "")
+	require.NoError(t, err)
+	require.Equal(t, KycPending, pending.KycStatus)
+
+	queue, err := store.ListUsersByKycStatus(ctx, ListUsersByKycStatusParams{KycStatus: KycPending, Limit: 1000})
+	require.NoError(t, err)
+	require.Contains(t, queue, pending)
+
+	rejected, err := change(KycRejected, admin.Username, "passport has expired")
+	require.NoError(t, err)
+	require.Equal(t, KycRejected, rejected.KycStatus)
+	require.Equal(t, "passport has expired", rejected.KycNote)
+	require.Equal(t, admin.Username, rejected.KycReviewedBy)
+
+	_, err = change(KycPending, "", "")
+	require.NoError(t, err)
+	verified, err := change(KycVerified, admin.Username, "")
+	require.NoError(t, err)
+	require.Equal(t, KycVerified, verified.KycStatus)
+
+	_, err = change(KycPending, "", "")
+	require.ErrorIs(t, err, ErrInvalidKycTransition)
+
+	_, err = store.ChangeKycStatus(ctx, ChangeKycStatusParams{Username: util.RandomString(12), To: KycPending})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+// testConformanceKycGating checks that the unverified account and transfer
+// limits apply until the user's KYC is verified, and only until then.
+func testConformanceKycGating(t *testing.T, store Store) {
+	ctx := context.Background()
+	user := conformanceUser(t, store)
+	admin := conformanceUser(t, store)
+
+	createAccount := func(currency string) (Account, error) {
+		return store.CreateAccountTx(ctx, CreateAccountTxParams{
+			CreateAccountsParams:  CreateAccountsParams{Owner: user.Username, Currency: currency, Balance: 1000, AccountType: AccountChecking},
+			MaxAccounts:           3,
+			MaxUnverifiedAccounts: 1,
+		})
+	}
+	from, err := createAccount(util.USD)
+	require.NoError(t, err)
+	_, err = createAccount(util.EUR)
+	require.ErrorIs(t, err, ErrAccountLimitReached)
+
+	to := conformanceAccount(t, store, admin.Username, util.USD)
+	limits := util.TransferLimits{
+		"standard/*":   {PerTransaction: 500},
+		"unverified/*": {PerTransaction: 100, Daily: 1000},
+	}
+	transfer := func(amount int64) error {
+		_, err := store.TransferTx(ctx, TransferTxParams{FromAccountID: from.ID, ToAccountID: to.ID, Amount: amount, Limits: limits})
+		return err
+	}
+	require.ErrorIs(t, transfer(150), ErrTransferLimitExceeded)
+
+	_, err = store.CreateKycDocument(ctx, CreateKycDocumentParams{Owner: user.Username, Kind: KycIDCard, BlobKey: "kyc/" + user.Username + "/" + util.RandomString(12)})
+	require.NoError(t, err)
+	_, err = store.ChangeKycStatus(ctx, ChangeKycStatusParams{Username: user.Username, To: KycPending})
+	require.NoError(t, err)
+	// still not verified while pending
+	require.ErrorIs(t, transfer(150), ErrTransferLimitExceeded)
+
+	_, err = store.ChangeKycStatus(ctx, ChangeKycStatusParams{Username: user.Username, To: KycVerified, Reviewer: admin.Username})
+	require.NoError(t, err)
+	require.NoError(t, transfer(150))
+	require.ErrorIs(t, transfer(600), ErrTransferLimitExceeded)
+	_, err = createAccount(util.EUR)
+	require.NoError(t, err)
+}
+
+// testConformanceInterest checks accruals use the balance at the end of their
+// day and postings pay whole minor units, carrying the rest into the next.
+func testConformanceInterest(t *testing.T, store Store) {
+	ctx := context.Background()
+	owner := conformanceUser(t, store)
+	account, err := store.CreateAccounts(ctx, CreateAccountsParams{
+		Owner:         owner.Username,
+		Balance:       10_000_000,
+		Currency:      util.USD,
+		AccountType:   AccountSavings,
+		AccountNumber: randomAccountNumber(t),
+	})
+	require.NoError(t, err)
+	funding := conformanceAccount(t, store, conformanceUser(t, store).Username, util.USD)
+	rate := util.InterestRate{Bps: 3650, FundingAccount: funding.ID}
+
+	_, err = store.TransferTx(ctx, TransferTxParams{FromAccountID: account.ID, ToAccountID: funding.ID, Amount: 4_000_000})
+	require.NoError(t, err)
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	yesterday, tomorrow := today.AddDate(0, 0, -1), today.AddDate(0, 0, 1)
+	expected := func(balance int64, day time.Time) int64 {
+		micros, err := util.DailyInterestMicros(balance, rate.Bps, day)
+		require.NoError(t, err)
+		return micros
+	}
+
+	// the transfer was made today, so yesterday still had the full balance
+	accrual, err := AccrueInterest(ctx, store, account, rate, yesterday)
+	require.NoError(t, err)
+	require.Equal(t, int64(10_000_000), accrual.Balance)
+	require.Equal(t, expected(10_000_000, yesterday), accrual.AmountMicros)
+	require.True(t, accrual.AccrualDate.Equal(yesterday))
+
+	accrual, err = AccrueInterest(ctx, store, account, rate, today)
+	require.NoError(t, err)
+	require.Equal(t, int64(6_000_000), accrual.Balance)
+	micros := expected(10_000_000, yesterday) + expected(6_000_000, today)
+
+	_, err = AccrueInterest(ctx, store, account, rate, today)
+	requireErrorCode(t, err, UniqueViolation)
+
+	latest, err := store.GetLatestInterestAccrual(ctx, account.ID)
+	require.NoError(t, err)
+	require.Equal(t, accrual.ID, latest.ID)
+
+	ids, err := store.ListAccountsWithUnpostedInterest(ctx, tomorrow.AddDate(0, 1, 0))
+	require.NoError(t, err)
+	require.Contains(t, ids, account.ID)
+
+	// this month's posting covers both days, even if yesterday was last month
+	result, err := store.PostInterestTx(ctx, PostInterestTxParams{AccountID: account.ID, FundingAccountID: funding.ID, Period: today})
+	require.NoError(t, err)
+	amount, carry := util.SplitMicros(micros)
+	require.Positive(t, amount)
+	require.Equal(t, amount, result.Posting.Amount)
+	require.Equal(t, carry, result.Posting.CarryMicros)
+	require.True(t, result.Posting.Period.Equal(util.MonthStart(today)))
+	require.Equal(t, int64(6_000_000)+amount, result.Account.Balance)
+	require.Equal(t, funding.Balance+4_000_000-amount, result.FundingAccount.Balance)
+	require.Equal(t, amount, result.Entry.Amount)
+	require.Equal(t, -amount, result.FundingEntry.Amount)
+
+	accruals, err := store.ListInterestAccruals(ctx, ListInterestAccrualsParams{AccountID: account.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, accruals, 2)
+	require.True(t, accruals[0].AccrualDate.Equal(today))
+	for _, accrual := range accruals {
+		require.Equal(t, sql.NullInt64{Int64: result.Posting.ID, Valid: true}, accrual.PostingID)
+	}
+
+	_, err = store.PostInterestTx(ctx, PostInterestTxParams{AccountID: account.ID, FundingAccountID: funding.ID, Period: today})
+	require.ErrorIs(t, err, ErrNoInterestAccrued)
+
+	// the next posting starts from what this one carried
+	_, err = AccrueInterest(ctx, store, account, rate, util.MonthStart(today).AddDate(0, 1, 0))
+	require.NoError(t, err)
+	next, err := store.PostInterestTx(ctx, PostInterestTxParams{AccountID: account.ID, FundingAccountID: funding.ID, Period: util.MonthStart(today).AddDate(0, 1, 0)})
+	require.NoError(t, err)
+	amount, carry = util.SplitMicros(carry + expected(6_000_000+amount, util.MonthStart(today).AddDate(0, 1, 0)))
+	require.Equal(t, amount, next.Posting.Amount)
+	require.Equal(t, carry, next.Posting.CarryMicros)
+
+	postings, err := store.ListInterestPostings(ctx, ListInterestPostingsParams{AccountID: account.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, postings, 2)
+	require.Equal(t, next.Posting.ID, postings[0].ID)
+
+	frozen := conformanceAccount(t, store, funding.Owner, util.USD)
+	_, err = store.ChangeAccountStatus(ctx, ChangeAccountStatusParams{AccountID: frozen.ID, From: AccountActive, To: AccountFrozen})
+	require.NoError(t, err)
+	_, err = AccrueInterest(ctx, store, account, rate, util.MonthStart(today).AddDate(0, 2, 0))
+	require.NoError(t, err)
+	_, err = store.PostInterestTx(ctx, PostInterestTxParams{AccountID: account.ID, FundingAccountID: frozen.ID, Period: util.MonthStart(today).AddDate(0, 2, 0)})
+	require.ErrorIs(t, err, ErrAccountNotActive)
+}
+
+func testConformanceCurrencySettings(t *testing.T, store Store) {
+	ctx := context.Background()
+
+	setting, err := store.SetCurrencyEnabled(ctx, SetCurrencyEnabledParams{Code: "XOF", Enabled: true})
+	require.NoError(t, err)
+	require.True(t, setting.Enabled)
+
+	// switching it again updates the one row
+	setting, err = store.SetCurrencyEnabled(ctx, SetCurrencyEnabledParams{Code: "XOF", Enabled: false})
+	require.NoError(t, err)
+	require.False(t, setting.Enabled)
+
+	settings, err := store.ListCurrencySettings(ctx)
+	require.NoError(t, err)
+	var found int
+	for i, s := range settings {
+		if i > 0 {
+			require.Less(t, settings[i-1].Code, s.Code)
+		}
+		if s.Code == "XOF" {
+			found++
+			require.False(t, s.Enabled)
+		}
+	}
+	require.Equal(t, 1, found)
+}
+
+func testConformanceOauth(t *testing.T, store Store) {
+	ctx := context.Background()
+	user := conformanceUser(t, store)
+
+	client, err := store.CreateOauthClient(ctx, CreateOauthClientParams{
+		ID:           util.RandomString(24),
+		Owner:        user.Username,
+		Name:         "dashboard",
+		RedirectUris: []string{"https://example.com/callback"},
+		Scopes:       []string{"accounts:read", "transfers:read"},
+	})
+	require.NoError(t, err)
+
+	client2, err := store.GetOauthClient(ctx, client.ID)
+	require.NoError(t, err)
+	require.Equal(t, client, client2)
+
+	codeArg := CreateOauthAuthorizationCodeParams{
+		CodeHash:      util.HashSecret(util.RandomString(32)),
+		ClientID:      client.ID,
+		Username:      user.Username,
+		RedirectUri:   client.RedirectUris[0],
+		Scopes:        []string{"accounts:read"},
+		CodeChallenge: util.RandomString(43),
+		ExpiresAt:     time.Now().Add(time.Minute),
+	}
+	_, err = store.CreateOauthAuthorizationCode(ctx, codeArg)
+	require.NoError(t, err)
+
+	codeArg.ClientID = util.RandomString(24)
+	codeArg.CodeHash = util.HashSecret(util.RandomString(32))
+	_, err = store.CreateOauthAuthorizationCode(ctx, codeArg)
+	requireErrorCode(t, err, ForeignKeyViolation)
+
+	code, err := store.ConsumeOauthAuthorizationCode(ctx, codeArg.CodeHash)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+	require.Empty(t, code)
+
+	consentArg := UpsertOauthConsentParams{Username: user.Username, ClientID: client.ID, Scopes: []string{"accounts:read"}}
+	_, err = store.UpsertOauthConsent(ctx, consentArg)
+	require.NoError(t, err)
+	consentArg.Scopes = client.Scopes
+	_, err = store.UpsertOauthConsent(ctx, consentArg)
+	require.NoError(t, err)
+
+	consent, err := store.GetOauthConsent(ctx, GetOauthConsentParams{Username: user.Username, ClientID: client.ID})
+	require.NoError(t, err)
+	require.Equal(t, client.Scopes, consent.Scopes)
+
+	token, err := store.CreateOauthToken(ctx, CreateOauthTokenParams{
+		ID:        util.RandomString(36),
+		ClientID:  client.ID,
+		Username:  user.Username,
+		Scopes:    client.Scopes,
+		ExpiresAt: time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.False(t, token.RevokedAt.Valid)
+
+	require.NoError(t, store.RevokeOauthToken(ctx, token.ID))
+	revoked, err := store.GetOauthToken(ctx, token.ID)
+	require.NoError(t, err)
+	require.True(t, revoked.RevokedAt.Valid)
+
+	require.NoError(t, store.RevokeOauthToken(ctx, token.ID))
+	revoked2, err := store.GetOauthToken(ctx, token.ID)
+	require.NoError(t, err)
+	require.Equal(t, revoked.RevokedAt, revoked2.RevokedAt)
+}
+
+func testConformanceAuditChain(t *testing.T, store Store) {
+	ctx := context.Background()
+	actor := util.RandomOwner() + util.RandomString(6)
+
+	for i := 0; i < 3; i++ {
+		err := store.AppendAuditEvent(ctx, AppendAuditEventParams{
+			Actor:     actor,
+			Action:    "POST /accounts",
+			Resource:  "/accounts",
+			IP:        "127.0.0.1",
+			RequestID: util.RandomString(12),
+			Status:    200,
+			After:     map[string]int64{"balance": util.RandomBalance()},
+		})
+		require.NoError(t, err)
+	}
+
+	// queued events only join the chain once sequenced
+	listed, err := store.ListAuditEvents(ctx, ListAuditEventsParams{
+		Actor:    sql.NullString{String: actor, Valid: true},
+		PageSize: 10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, listed)
+
+	var events []AuditEvent
+	for {
+		sequenced, err := store.SequenceAuditEvents(ctx, 100)
+		require.NoError(t, err)
+		for _, event := range sequenced {
+			require.Equal(t, AuditEventHash(event), event.Hash)
+			if event.Actor == actor {
+				events = append(events, event)
+			}
+		}
+		if len(sequenced) < 100 {
+			break
+		}
+	}
+	require.Len(t, events, 3)
+	require.Equal(t, events[0].Hash, events[1].PrevHash)
+	require.Equal(t, events[1].Hash, events[2].PrevHash)
+
+	listed, err = store.ListAuditEvents(ctx, ListAuditEventsParams{
+		AfterID:  events[0].ID - 1,
+		Actor:    sql.NullString{String: actor, Valid: true},
+		PageSize: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, listed, 3)
+	require.Equal(t, events[0].ID, listed[0].ID)
+
+	sequenced, err := store.SequenceAuditEvents(ctx, 100)
+	require.NoError(t, err)
+	require.Empty(t, sequenced)
+
+	previous, err := store.GetAuditEventBefore(ctx, events[0].ID)
+	if err == nil {
+		require.Equal(t, previous.Hash, events[0].PrevHash)
+	} else {
+		require.ErrorIs(t, err, sql.ErrNoRows)
+		require.Empty(t, events[0].PrevHash)
+	}
+
+	last, err := store.GetLastAuditEventHash(ctx)
+	require.NoError(t, err)
+	require.NotEmpty(t, last)
+}
+
+func testConformanceRateLimit(t *testing.T, store Store) {
+	ctx := context.Background()
+	arg := TakeRateLimitTokenParams{
+		Key:        "test:" + util.RandomString(12),
+		Burst:      2,
+		RefillRate: 0,
+	}
+
+	_, err := store.GetRateLimitTokens(ctx, GetRateLimitTokensParams{Key: arg.Key, Burst: arg.Burst})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	tokens, err := store.TakeRateLimitToken(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, 1.0, tokens)
+
+	tokens, err = store.TakeRateLimitToken(ctx, arg)
+	require.NoError(t, err)
+	require.Equal(t, 0.0, tokens)
+
+	_, err = store.TakeRateLimitToken(ctx, arg)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	tokens, err = store.GetRateLimitTokens(ctx, GetRateLimitTokensParams{Key: arg.Key, Burst: arg.Burst})
+	require.NoError(t, err)
+	require.Equal(t, 0.0, tokens)
+
+	// a bucket used within the idle time is kept, an older one is pruned
+	_, err = store.DeleteIdleRateLimitBuckets(ctx, time.Hour.Seconds())
+	require.NoError(t, err)
+	_, err = store.GetRateLimitTokens(ctx, GetRateLimitTokensParams{Key: arg.Key, Burst: arg.Burst})
+	require.NoError(t, err)
+
+	deleted, err := store.DeleteIdleRateLimitBuckets(ctx, 0)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, deleted, int64(1))
+	_, err = store.GetRateLimitTokens(ctx, GetRateLimitTokensParams{Key: arg.Key, Burst: arg.Burst})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,93 @@
+package Anuskh
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nilesh0729/Transactly/internal/util"
+)
+
+var ErrTransferLimitExceeded = errors.New("transfer limit exceeded")
+
+// TransferUsage sums what owner has sent from their accounts in currency over
+// each window of util.LimitWindows that includes at.
+func TransferUsage(ctx context.Context, q Querier, owner string, currency string, at time.Time) (SumTransfersRow, error) {
+	hour, day, month := util.LimitWindows(at)
+	return q.SumTransfers(ctx, SumTransfersParams{
+		DayStart:   day,
+		MonthStart: month,
+		HourStart:  hour,
+		Owner:      owner,
+		Currency:   currency,
+	})
+}
+
+// CheckTransferTx runs the limit and cooling-off checks TransferTx would make
+// for arg without making the transfer. A transfer held for review is checked
+// first, so it isn't one that could never be approved.
+func (store *RealStore) CheckTransferTx(ctx context.Context, arg TransferTxParams) error {
+	opts := &sql.TxOptions{Isolation: store.transferIsolation}
+	return store.execTxWithRetry(ctx, opts, func(q *Queries) error {
+		return checkTransfer(ctx, q, arg)
+	})
+}
+
+func checkTransfer(ctx context.Context, q Querier, arg TransferTxParams) error {
+	if arg.Limits != nil {
+		if err := checkTransferLimits(ctx, q, arg); err != nil {
+			return err
+		}
+	}
+	if arg.CoolingOff.Period > 0 {
+		if err := checkCoolingOff(ctx, q, arg); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkTransferLimits refuses a transfer that would take the sender past the
+// limit for their tier, or the unverified limit if that is lower and their
+// KYC isn't verified yet. It locks the sender's user row first, so transfers
+// from their other accounts wait rather than being counted twice against the
+// same allowance.
+func checkTransferLimits(ctx context.Context, q Querier, arg TransferTxParams) error {
+	from, err := q.GetAccounts(ctx, arg.FromAccountID)
+	if err != nil {
+		return err
+	}
+	user, err := q.GetUserForUpdate(ctx, from.Owner)
+	if err != nil {
+		return err
+	}
+
+	limit := TransferLimitFor(arg.Limits, user, from.Currency)
+	if limit.IsZero() {
+		return nil
+	}
+	if limit.PerTransaction > 0 && arg.Amount > limit.PerTransaction {
+		return fmt.Errorf("%w: %d %s is over the per transaction limit of %d",
+			ErrTransferLimitExceeded, arg.Amount, from.Currency, limit.PerTransaction)
+	}
+
+	usage, err := TransferUsage(ctx, q, from.Owner, from.Currency, time.Now())
+	if err != nil {
+		return err
+	}
+	if limit.Daily > 0 && usage.DailyAmount+arg.Amount > limit.Daily {
+		return fmt.Errorf("%w: %d %s of the daily limit of %d remains",
+			ErrTransferLimitExceeded, max(limit.Daily-usage.DailyAmount, 0), from.Currency, limit.Daily)
+	}
+	if limit.Monthly > 0 && usage.MonthlyAmount+arg.Amount > limit.Monthly {
+		return fmt.Errorf("%w: %d %s of the monthly limit of %d remains",
+			ErrTransferLimitExceeded, max(limit.Monthly-usage.MonthlyAmount, 0), from.Currency, limit.Monthly)
+	}
+	if limit.HourlyCount > 0 && usage.HourlyCount >= limit.HourlyCount {
+		return fmt.Errorf("%w: at most %d transfers in %s an hour",
+			ErrTransferLimitExceeded, limit.HourlyCount, from.Currency)
+	}
+	return nil
+}
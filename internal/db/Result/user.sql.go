@@ -18,7 +18,7 @@ INSERT INTO "user"(
 ) VALUES (
   $1, $2, $3, $4
 )
-RETURNING username, hashed_password, full_name, email, password_changed_at, created_at
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, role, tier, kyc_status, kyc_note, kyc_reviewed_by, kyc_updated_at
 `
 
 type CreateUserParams struct {
@@ -43,12 +43,18 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.Email,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.Role,
+		&i.Tier,
+		&i.KycStatus,
+		&i.KycNote,
+		&i.KycReviewedBy,
+		&i.KycUpdatedAt,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT username, hashed_password, full_name, email, password_changed_at, created_at FROM "user"
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, role, tier, kyc_status, kyc_note, kyc_reviewed_by, kyc_updated_at FROM "user"
 WHERE username = $1
 LIMIT 1
 `
@@ -63,6 +69,132 @@ func (q *Queries) GetUser(ctx context.Context, username string) (User, error) {
 		&i.Email,
 		&i.PasswordChangedAt,
 		&i.CreatedAt,
+		&i.Role,
+		&i.Tier,
+		&i.KycStatus,
+		&i.KycNote,
+		&i.KycReviewedBy,
+		&i.KycUpdatedAt,
+	)
+	return i, err
+}
+
+const getUserForUpdate = `-- name: GetUserForUpdate :one
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, role, tier, kyc_status, kyc_note, kyc_reviewed_by, kyc_updated_at FROM "user"
+WHERE username = $1
+LIMIT 1
+FOR NO KEY UPDATE
+`
+
+func (q *Queries) GetUserForUpdate(ctx context.Context, username string) (User, error) {
+	row := q.db.QueryRowContext(ctx, getUserForUpdate, username)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.Role,
+		&i.Tier,
+		&i.KycStatus,
+		&i.KycNote,
+		&i.KycReviewedBy,
+		&i.KycUpdatedAt,
+	)
+	return i, err
+}
+
+const listUsersByKycStatus = `-- name: ListUsersByKycStatus :many
+SELECT username, hashed_password, full_name, email, password_changed_at, created_at, role, tier, kyc_status, kyc_note, kyc_reviewed_by, kyc_updated_at FROM "user"
+WHERE kyc_status = $1
+ORDER BY kyc_updated_at, username
+LIMIT $2
+OFFSET $3
+`
+
+type ListUsersByKycStatusParams struct {
+	KycStatus string `json:"kyc_status"`
+	Limit     int32  `json:"limit"`
+	Offset    int32  `json:"offset"`
+}
+
+func (q *Queries) ListUsersByKycStatus(ctx context.Context, arg ListUsersByKycStatusParams) ([]User, error) {
+	rows, err := q.db.QueryContext(ctx, listUsersByKycStatus, arg.KycStatus, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []User{}
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.Username,
+			&i.HashedPassword,
+			&i.FullName,
+			&i.Email,
+			&i.PasswordChangedAt,
+			&i.CreatedAt,
+			&i.Role,
+			&i.Tier,
+			&i.KycStatus,
+			&i.KycNote,
+			&i.KycReviewedBy,
+			&i.KycUpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateUserKyc = `-- name: UpdateUserKyc :one
+UPDATE "user"
+SET
+  kyc_status = $2,
+  kyc_note = $3,
+  kyc_reviewed_by = $4,
+  kyc_updated_at = now()
+WHERE username = $1
+RETURNING username, hashed_password, full_name, email, password_changed_at, created_at, role, tier, kyc_status, kyc_note, kyc_reviewed_by, kyc_updated_at
+`
+
+type UpdateUserKycParams struct {
+	Username      string `json:"username"`
+	KycStatus     string `json:"kyc_status"`
+	KycNote       string `json:"kyc_note"`
+	KycReviewedBy string `json:"kyc_reviewed_by"`
+}
+
+func (q *Queries) UpdateUserKyc(ctx context.Context, arg UpdateUserKycParams) (User, error) {
+	row := q.db.QueryRowContext(ctx, updateUserKyc,
+		arg.Username,
+		arg.KycStatus,
+		arg.KycNote,
+		arg.KycReviewedBy,
+	)
+	var i User
+	err := row.Scan(
+		&i.Username,
+		&i.HashedPassword,
+		&i.FullName,
+		&i.Email,
+		&i.PasswordChangedAt,
+		&i.CreatedAt,
+		&i.Role,
+		&i.Tier,
+		&i.KycStatus,
+		&i.KycNote,
+		&i.KycReviewedBy,
+		&i.KycUpdatedAt,
 	)
 	return i, err
 }
@@ -0,0 +1,15 @@
+CREATE TABLE api_keys (
+  id bigserial PRIMARY KEY,
+  owner varchar NOT NULL,
+  name varchar NOT NULL,
+  prefix varchar UNIQUE NOT NULL,
+  hashed_secret varchar NOT NULL,
+  scopes varchar[] NOT NULL,
+  allowed_ips varchar[] NOT NULL DEFAULT '{}',
+  expires_at timestamp,
+  created_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX ON api_keys (owner);
+
+ALTER TABLE api_keys ADD FOREIGN KEY (owner) REFERENCES "user" (username);
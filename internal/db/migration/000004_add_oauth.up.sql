@@ -0,0 +1,52 @@
+CREATE TABLE oauth_clients (
+  id varchar PRIMARY KEY,
+  owner varchar NOT NULL,
+  name varchar NOT NULL,
+  hashed_secret varchar NOT NULL DEFAULT '',
+  redirect_uris varchar[] NOT NULL,
+  scopes varchar[] NOT NULL,
+  created_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE TABLE oauth_authorization_codes (
+  code_hash varchar PRIMARY KEY,
+  client_id varchar NOT NULL,
+  username varchar NOT NULL,
+  redirect_uri varchar NOT NULL,
+  scopes varchar[] NOT NULL,
+  code_challenge varchar NOT NULL,
+  expires_at timestamp NOT NULL,
+  created_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE TABLE oauth_consents (
+  username varchar NOT NULL,
+  client_id varchar NOT NULL,
+  scopes varchar[] NOT NULL,
+  created_at timestamp NOT NULL DEFAULT now(),
+  PRIMARY KEY (username, client_id)
+);
+
+CREATE TABLE oauth_tokens (
+  id varchar PRIMARY KEY,
+  client_id varchar NOT NULL,
+  username varchar NOT NULL,
+  scopes varchar[] NOT NULL,
+  expires_at timestamp NOT NULL,
+  revoked_at timestamp,
+  created_at timestamp NOT NULL DEFAULT now()
+);
+
+CREATE INDEX ON oauth_clients (owner);
+CREATE INDEX ON oauth_tokens (client_id);
+
+ALTER TABLE oauth_clients ADD FOREIGN KEY (owner) REFERENCES "user" (username);
+
+ALTER TABLE oauth_authorization_codes ADD FOREIGN KEY (client_id) REFERENCES oauth_clients (id);
+ALTER TABLE oauth_authorization_codes ADD FOREIGN KEY (username) REFERENCES "user" (username);
+
+ALTER TABLE oauth_consents ADD FOREIGN KEY (client_id) REFERENCES oauth_clients (id);
+ALTER TABLE oauth_consents ADD FOREIGN KEY (username) REFERENCES "user" (username);
+
+ALTER TABLE oauth_tokens ADD FOREIGN KEY (client_id) REFERENCES oauth_clients (id);
+ALTER TABLE oauth_tokens ADD FOREIGN KEY (username) REFERENCES "user" (username);
@@ -0,0 +1 @@
+ALTER TABLE "user" ADD COLUMN role varchar NOT NULL DEFAULT 'depositor';
@@ -0,0 +1,28 @@
+-- before/after use json rather than jsonb: the hash chain covers the exact
+-- bytes written, and jsonb would normalise them.
+CREATE TABLE audit_events (
+  id bigserial PRIMARY KEY,
+  actor varchar NOT NULL,
+  action varchar NOT NULL,
+  resource varchar NOT NULL,
+  ip varchar NOT NULL,
+  request_id varchar NOT NULL,
+  status integer NOT NULL,
+  before json NOT NULL,
+  after json NOT NULL,
+  prev_hash varchar NOT NULL,
+  hash varchar UNIQUE NOT NULL,
+  created_at timestamp NOT NULL
+);
+
+CREATE INDEX ON audit_events (actor);
+
+CREATE FUNCTION audit_events_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'audit_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+BEFORE UPDATE OR DELETE ON audit_events
+FOR EACH ROW EXECUTE FUNCTION audit_events_append_only();
@@ -0,0 +1,4 @@
+ALTER TABLE accounts
+  ADD COLUMN status varchar NOT NULL DEFAULT 'active',
+  ADD COLUMN status_reason varchar NOT NULL DEFAULT '',
+  ADD CONSTRAINT accounts_status_check CHECK (status IN ('active', 'frozen', 'closed'));
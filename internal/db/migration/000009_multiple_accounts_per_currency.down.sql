@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS accounts_owner_nickname_idx;
+
+ALTER TABLE accounts
+  DROP CONSTRAINT IF EXISTS accounts_account_type_check,
+  DROP COLUMN IF EXISTS account_type,
+  DROP COLUMN IF EXISTS nickname;
+
+-- fails if an owner has opened a second account in the same currency
+CREATE UNIQUE INDEX accounts_owner_currency_idx ON accounts (owner, currency);
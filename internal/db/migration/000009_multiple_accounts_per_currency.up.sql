@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS accounts_owner_currency_idx;
+
+ALTER TABLE accounts
+  ADD COLUMN nickname varchar NOT NULL DEFAULT '',
+  ADD COLUMN account_type varchar NOT NULL DEFAULT 'checking',
+  ADD CONSTRAINT accounts_account_type_check CHECK (account_type IN ('checking', 'savings'));
+
+CREATE UNIQUE INDEX accounts_owner_nickname_idx ON accounts (owner, nickname) WHERE nickname <> '';
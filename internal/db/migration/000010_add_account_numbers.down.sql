@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS accounts_account_number_idx;
+
+ALTER TABLE accounts DROP COLUMN IF EXISTS account_number;
@@ -0,0 +1,15 @@
+ALTER TABLE accounts ADD COLUMN account_number varchar;
+
+-- Existing accounts get a random number in the same TX + check digits + 16
+-- digits format the API generates. 'TX00' moved to the end reads as 293300.
+UPDATE accounts a
+SET account_number = 'TX' || lpad((98 - (r.bban || '293300')::numeric % 97)::text, 2, '0') || r.bban
+FROM (
+  SELECT id, lpad(floor(random() * 1e16)::bigint::text, 16, '0') AS bban
+  FROM accounts
+) r
+WHERE a.id = r.id;
+
+ALTER TABLE accounts ALTER COLUMN account_number SET NOT NULL;
+
+CREATE UNIQUE INDEX accounts_account_number_idx ON accounts (account_number);
@@ -0,0 +1,16 @@
+CREATE TABLE payees (
+  id bigserial PRIMARY KEY,
+  owner varchar NOT NULL,
+  account_id bigint NOT NULL,
+  account_number varchar NOT NULL,
+  nickname varchar NOT NULL,
+  currency varchar NOT NULL,
+  created_at timestamp NOT NULL DEFAULT now(),
+  CONSTRAINT payees_owner_account_id_key UNIQUE (owner, account_id),
+  CONSTRAINT payees_owner_nickname_key UNIQUE (owner, nickname)
+);
+
+ALTER TABLE payees ADD FOREIGN KEY (owner) REFERENCES "user" (username);
+
+-- someone else deleting their account shouldn't be blocked by your address book
+ALTER TABLE payees ADD FOREIGN KEY (account_id) REFERENCES accounts (id) ON DELETE CASCADE;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS transfers_from_account_id_created_at_idx;
+
+ALTER TABLE "user"
+  DROP CONSTRAINT IF EXISTS user_tier_check,
+  DROP COLUMN IF EXISTS tier;
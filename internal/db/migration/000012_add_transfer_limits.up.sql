@@ -0,0 +1,6 @@
+ALTER TABLE "user"
+  ADD COLUMN tier varchar NOT NULL DEFAULT 'standard',
+  ADD CONSTRAINT user_tier_check CHECK (tier IN ('standard', 'premium'));
+
+-- transfer limits sum what each account sent over the current day and month
+CREATE INDEX transfers_from_account_id_created_at_idx ON transfers (from_account_id, created_at);
@@ -0,0 +1,29 @@
+CREATE TABLE held_transfers (
+  id bigserial PRIMARY KEY,
+  requested_by varchar NOT NULL,
+  from_account_id bigint NOT NULL,
+  to_account_id bigint NOT NULL,
+  amount bigint NOT NULL,
+  currency varchar NOT NULL,
+  reasons varchar[] NOT NULL,
+  status varchar NOT NULL DEFAULT 'pending',
+  reviewed_by varchar NOT NULL DEFAULT '',
+  review_note varchar NOT NULL DEFAULT '',
+  transfer_id bigint,
+  created_at timestamp NOT NULL DEFAULT now(),
+  reviewed_at timestamp,
+  CONSTRAINT held_transfers_status_check CHECK (status IN ('pending', 'approved', 'rejected'))
+);
+
+CREATE INDEX held_transfers_status_id_idx ON held_transfers (status, id);
+
+ALTER TABLE held_transfers ADD FOREIGN KEY (requested_by) REFERENCES "user" (username);
+
+ALTER TABLE held_transfers ADD FOREIGN KEY (from_account_id) REFERENCES accounts (id);
+
+ALTER TABLE held_transfers ADD FOREIGN KEY (to_account_id) REFERENCES accounts (id);
+
+ALTER TABLE held_transfers ADD FOREIGN KEY (transfer_id) REFERENCES transfers (id);
+
+-- the fraud rules look back at the sender's recent transfers and each account's last activity
+CREATE INDEX entries_account_id_created_at_idx ON entries (account_id, created_at);
@@ -0,0 +1,17 @@
+CREATE TABLE screening_results (
+  id bigserial PRIMARY KEY,
+  subject_type varchar NOT NULL,
+  subject varchar NOT NULL,
+  name varchar NOT NULL,
+  requested_by varchar NOT NULL,
+  list_version varchar NOT NULL,
+  matched boolean NOT NULL,
+  score double precision NOT NULL,
+  entry_id varchar NOT NULL DEFAULT '',
+  entry_name varchar NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL DEFAULT now(),
+  CONSTRAINT screening_results_subject_type_check CHECK (subject_type IN ('user', 'payee'))
+);
+
+-- no foreign keys: refused sign-ups are recorded too, and the record outlives the user
+CREATE INDEX screening_results_matched_id_idx ON screening_results (matched, id);
@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS kyc_documents;
+
+DROP INDEX IF EXISTS user_kyc_status_kyc_updated_at_idx;
+
+ALTER TABLE "user"
+  DROP CONSTRAINT IF EXISTS user_kyc_status_check,
+  DROP COLUMN IF EXISTS kyc_updated_at,
+  DROP COLUMN IF EXISTS kyc_reviewed_by,
+  DROP COLUMN IF EXISTS kyc_note,
+  DROP COLUMN IF EXISTS kyc_status;
@@ -0,0 +1,30 @@
+-- Users who signed up before KYC existed already hold accounts and move
+-- money, so they are taken as verified rather than suddenly finding
+-- themselves on the unverified limits. Only new sign-ups start unverified.
+ALTER TABLE "user"
+  ADD COLUMN kyc_status varchar NOT NULL DEFAULT 'verified',
+  ADD COLUMN kyc_note varchar NOT NULL DEFAULT '',
+  ADD COLUMN kyc_reviewed_by varchar NOT NULL DEFAULT '',
+  ADD COLUMN kyc_updated_at timestamp NOT NULL DEFAULT now(),
+  ADD CONSTRAINT user_kyc_status_check CHECK (kyc_status IN ('unverified', 'pending', 'verified', 'rejected'));
+
+ALTER TABLE "user" ALTER COLUMN kyc_status SET DEFAULT 'unverified';
+
+-- the admin review queue, oldest submission first
+CREATE INDEX user_kyc_status_kyc_updated_at_idx ON "user" (kyc_status, kyc_updated_at);
+
+CREATE TABLE kyc_documents (
+  id bigserial PRIMARY KEY,
+  owner varchar NOT NULL,
+  kind varchar NOT NULL,
+  content_type varchar NOT NULL,
+  size_bytes bigint NOT NULL,
+  sha256 varchar NOT NULL,
+  blob_key varchar UNIQUE NOT NULL,
+  created_at timestamp NOT NULL DEFAULT now(),
+  CONSTRAINT kyc_documents_kind_check CHECK (kind IN ('passport', 'id_card', 'driving_licence', 'proof_of_address'))
+);
+
+CREATE INDEX kyc_documents_owner_id_idx ON kyc_documents (owner, id);
+
+ALTER TABLE kyc_documents ADD FOREIGN KEY (owner) REFERENCES "user" (username);
@@ -0,0 +1,5 @@
+ALTER TABLE transfers
+  DROP CONSTRAINT IF EXISTS transfers_fee_account_id_fkey,
+  DROP CONSTRAINT IF EXISTS transfers_fee_check,
+  DROP COLUMN IF EXISTS fee_account_id,
+  DROP COLUMN IF EXISTS fee;
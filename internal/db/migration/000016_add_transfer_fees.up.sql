@@ -0,0 +1,6 @@
+ALTER TABLE transfers
+  ADD COLUMN fee bigint NOT NULL DEFAULT 0,
+  ADD COLUMN fee_account_id bigint,
+  ADD CONSTRAINT transfers_fee_check CHECK (fee >= 0);
+
+ALTER TABLE transfers ADD FOREIGN KEY (fee_account_id) REFERENCES accounts (id);
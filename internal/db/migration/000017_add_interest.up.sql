@@ -0,0 +1,38 @@
+CREATE TABLE interest_postings (
+  id bigserial PRIMARY KEY,
+  account_id bigint NOT NULL,
+  funding_account_id bigint NOT NULL,
+  period date NOT NULL,
+  amount bigint NOT NULL,
+  carry_micros bigint NOT NULL,
+  created_at timestamp NOT NULL DEFAULT now(),
+  CONSTRAINT interest_postings_amount_check CHECK (amount >= 0)
+);
+
+-- one posting per account and month, so two servers can't both post it
+CREATE UNIQUE INDEX interest_postings_account_id_period_idx ON interest_postings (account_id, period);
+
+ALTER TABLE interest_postings ADD FOREIGN KEY (account_id) REFERENCES accounts (id);
+
+ALTER TABLE interest_postings ADD FOREIGN KEY (funding_account_id) REFERENCES accounts (id);
+
+CREATE TABLE interest_accruals (
+  id bigserial PRIMARY KEY,
+  account_id bigint NOT NULL,
+  accrual_date date NOT NULL,
+  balance bigint NOT NULL,
+  rate_bps bigint NOT NULL,
+  amount_micros bigint NOT NULL,
+  posting_id bigint,
+  created_at timestamp NOT NULL DEFAULT now(),
+  CONSTRAINT interest_accruals_amount_micros_check CHECK (amount_micros >= 0)
+);
+
+-- one accrual per account and day, so a day is never accrued twice
+CREATE UNIQUE INDEX interest_accruals_account_id_accrual_date_idx ON interest_accruals (account_id, accrual_date);
+
+CREATE INDEX interest_accruals_unposted_idx ON interest_accruals (accrual_date) WHERE posting_id IS NULL;
+
+ALTER TABLE interest_accruals ADD FOREIGN KEY (account_id) REFERENCES accounts (id);
+
+ALTER TABLE interest_accruals ADD FOREIGN KEY (posting_id) REFERENCES interest_postings (id);
@@ -0,0 +1,5 @@
+UPDATE held_transfers SET currency = 'YEN' WHERE currency = 'JPY';
+UPDATE payees SET currency = 'YEN' WHERE currency = 'JPY';
+UPDATE accounts SET currency = 'YEN' WHERE currency = 'JPY';
+
+DROP TABLE IF EXISTS currency_settings;
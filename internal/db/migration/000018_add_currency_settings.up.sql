@@ -0,0 +1,12 @@
+-- Only currencies an admin has switched on or off at runtime are stored;
+-- the rest follow the CURRENCIES setting.
+CREATE TABLE currency_settings (
+  code varchar PRIMARY KEY,
+  enabled boolean NOT NULL,
+  updated_at timestamptz NOT NULL DEFAULT (now())
+);
+
+-- YEN was never an ISO 4217 code; the yen is JPY.
+UPDATE accounts SET currency = 'JPY' WHERE currency = 'YEN';
+UPDATE payees SET currency = 'JPY' WHERE currency = 'YEN';
+UPDATE held_transfers SET currency = 'JPY' WHERE currency = 'YEN';
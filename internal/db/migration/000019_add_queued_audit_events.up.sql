@@ -0,0 +1,15 @@
+-- Writers queue their events here inside their own transactions, without
+-- touching the hash chain; a sequencer moves them into audit_events in order
+-- after they commit, so the chain never serialises unrelated writes.
+CREATE TABLE queued_audit_events (
+  id bigserial PRIMARY KEY,
+  actor varchar NOT NULL,
+  action varchar NOT NULL,
+  resource varchar NOT NULL,
+  ip varchar NOT NULL,
+  request_id varchar NOT NULL,
+  status integer NOT NULL,
+  before json NOT NULL,
+  after json NOT NULL,
+  created_at timestamp NOT NULL
+);
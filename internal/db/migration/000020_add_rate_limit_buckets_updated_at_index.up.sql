@@ -0,0 +1,2 @@
+-- Lets the pruner find idle buckets without scanning the whole table.
+CREATE INDEX rate_limit_buckets_updated_at_idx ON rate_limit_buckets (updated_at);
@@ -0,0 +1,128 @@
+// Package migration embeds the SQL migrations in this directory and applies
+// them with golang-migrate.
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"strconv"
+	"strings"
+
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+//go:embed *.sql
+var files embed.FS
+
+// newMigrate runs on a single connection taken from db, so closing it leaves
+// the pool alone. The postgres driver holds pg_advisory_lock on that
+// connection while migrating, which keeps concurrent instances from applying
+// the same migration twice.
+func newMigrate(ctx context.Context, db *sql.DB) (*migrate.Migrate, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	driver, err := postgres.WithConnection(ctx, conn, &postgres.Config{})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("cannot create migration driver : %w", err)
+	}
+
+	source, err := iofs.New(files, ".")
+	if err != nil {
+		driver.Close()
+		return nil, fmt.Errorf("cannot read embedded migrations : %w", err)
+	}
+
+	return migrate.NewWithInstance("iofs", source, "postgres", driver)
+}
+
+// Up applies every migration that hasn't been applied yet.
+func Up(ctx context.Context, db *sql.DB) error {
+	m, err := newMigrate(ctx, db)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return err
+	}
+	return nil
+}
+
+// Down rolls back the last steps migrations.
+func Down(ctx context.Context, db *sql.DB, steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive, got %d", steps)
+	}
+
+	m, err := newMigrate(ctx, db)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	return m.Steps(-steps)
+}
+
+// Version returns the applied schema version, which is 0 on an empty database.
+func Version(ctx context.Context, db *sql.DB) (version uint, dirty bool, err error) {
+	m, err := newMigrate(ctx, db)
+	if err != nil {
+		return 0, false, err
+	}
+	defer m.Close()
+
+	version, dirty, err = m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	return version, dirty, err
+}
+
+// Latest returns the highest migration version embedded in the binary, which
+// is the schema version the code expects.
+func Latest() (uint, error) {
+	names, err := fs.Glob(files, "*.up.sql")
+	if err != nil {
+		return 0, err
+	}
+
+	var latest uint
+	for _, name := range names {
+		prefix, _, ok := strings.Cut(name, "_")
+		if !ok {
+			return 0, fmt.Errorf("invalid migration file name : %s", name)
+		}
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid migration file name : %s", name)
+		}
+		latest = max(latest, uint(version))
+	}
+	return latest, nil
+}
+
+// CheckVersion fails unless the database is clean and at least at Latest.
+func CheckVersion(version uint, dirty bool) error {
+	latest, err := Latest()
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("migration %d is dirty", version)
+	}
+	if version < latest {
+		return fmt.Errorf("schema version %d is older than %d", version, latest)
+	}
+	return nil
+}
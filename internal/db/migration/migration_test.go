@@ -0,0 +1,92 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"strings"
+	"testing"
+
+	_ "github.com/lib/pq"
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatest(t *testing.T) {
+	names, err := fs.Glob(files, "*.up.sql")
+	require.NoError(t, err)
+	require.NotEmpty(t, names)
+
+	latest, err := Latest()
+	require.NoError(t, err)
+	require.EqualValues(t, len(names), latest)
+}
+
+func TestEveryUpHasDown(t *testing.T) {
+	names, err := fs.Glob(files, "*.up.sql")
+	require.NoError(t, err)
+
+	for _, name := range names {
+		_, err := fs.Stat(files, strings.TrimSuffix(name, ".up.sql")+".down.sql")
+		require.NoError(t, err, name)
+	}
+}
+
+func TestCheckVersion(t *testing.T) {
+	latest, err := Latest()
+	require.NoError(t, err)
+
+	require.NoError(t, CheckVersion(latest, false))
+	require.NoError(t, CheckVersion(latest+1, false))
+	require.Error(t, CheckVersion(latest-1, false))
+	require.Error(t, CheckVersion(latest, true))
+	require.Error(t, CheckVersion(0, false))
+}
+
+func TestKycBackfill(t *testing.T) {
+	ctx := context.Background()
+	config, err := util.LoadConfig("../../..")
+	require.NoError(t, err)
+
+	// migrate a schema of our own so the shared one is left alone
+	admin, err := sql.Open(config.DBDriver, config.DBSource)
+	require.NoError(t, err)
+	defer admin.Close()
+	schema := "migration_test_" + util.RandomString(8)
+	_, err = admin.ExecContext(ctx, "CREATE SCHEMA "+schema)
+	require.NoError(t, err)
+	defer admin.ExecContext(ctx, "DROP SCHEMA "+schema+" CASCADE")
+
+	separator := "?"
+	if strings.Contains(config.DBSource, "?") {
+		separator = "&"
+	}
+	db, err := sql.Open(config.DBDriver, config.DBSource+separator+"search_path="+schema)
+	require.NoError(t, err)
+	defer db.Close()
+
+	m, err := newMigrate(ctx, db)
+	require.NoError(t, err)
+	defer m.Close()
+
+	addUser := func(username string) {
+		_, err := db.ExecContext(ctx, `INSERT INTO "user" (username, hashed_password, full_name, email) VALUES ($1, 'x', $1, $1 || '@example.com')`, username)
+		require.NoError(t, err)
+	}
+	kycStatus := func(username string) string {
+		var status string
+		require.NoError(t, db.QueryRowContext(ctx, `SELECT kyc_status FROM "user" WHERE username = $1`, username).Scan(&status))
+		return status
+	}
+
+	require.NoError(t, m.Migrate(14))
+	addUser("existing")
+	require.NoError(t, m.Migrate(15))
+	addUser("newcomer")
+
+	// users from before KYC keep their access, new ones start unverified
+	require.Equal(t, "verified", kycStatus("existing"))
+	require.Equal(t, "unverified", kycStatus("newcomer"))
+
+	require.NoError(t, m.Up())
+}
@@ -0,0 +1,169 @@
+// Package interest accrues interest on interest-bearing accounts every day
+// and posts it to them once a month.
+package interest
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/metrics"
+	"github.com/nilesh0729/Transactly/internal/util"
+)
+
+// pageSize is how many accounts are read at a time while accruing.
+const pageSize = 100
+
+const day = 24 * time.Hour
+
+type Job struct {
+	store Anuskh.Store
+	rates util.InterestRates
+}
+
+// NewJob checks every rate names a known account type.
+func NewJob(store Anuskh.Store, rates util.InterestRates) (*Job, error) {
+	for key := range rates {
+		accountType, _, _ := strings.Cut(key, "/")
+		if accountType != Anuskh.AccountChecking && accountType != Anuskh.AccountSavings {
+			return nil, fmt.Errorf("interest rate %s: unknown account type %s", key, accountType)
+		}
+	}
+	return &Job{store: store, rates: rates}, nil
+}
+
+// Run calls RunOnce straight away and then every period until ctx is done.
+func (job *Job) Run(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		if err := job.RunOnce(ctx, time.Now()); err != nil {
+			slog.ErrorContext(ctx, "interest run failed", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce accrues interest for every whole UTC day before at and posts what
+// accrued in the months before at's. Days and months already done are
+// skipped, so several servers can run it side by side.
+func (job *Job) RunOnce(ctx context.Context, at time.Time) error {
+	today := at.UTC().Truncate(day)
+	return errors.Join(job.accrue(ctx, today), job.post(ctx, today))
+}
+
+func (job *Job) accrue(ctx context.Context, today time.Time) error {
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(job.rates)) {
+		accountType, currency, _ := strings.Cut(key, "/")
+		rate := job.rates[key]
+
+		var afterID int64
+		for {
+			accounts, err := job.store.ListInterestBearingAccounts(ctx, Anuskh.ListInterestBearingAccountsParams{
+				AccountType: accountType,
+				Currency:    currency,
+				AfterID:     afterID,
+				PageSize:    pageSize,
+			})
+			if err != nil {
+				errs = append(errs, err)
+				break
+			}
+			for _, account := range accounts {
+				if account.ID == rate.FundingAccount {
+					continue
+				}
+				if err := job.accrueAccount(ctx, account, rate, today); err != nil {
+					errs = append(errs, fmt.Errorf("account %d: %w", account.ID, err))
+				}
+			}
+			if len(accounts) < pageSize {
+				break
+			}
+			afterID = accounts[len(accounts)-1].ID
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// accrueAccount accrues each day from the one after the account's last
+// accrual up to yesterday. An account that never accrued starts yesterday, or
+// the day it was opened if that is later, so introducing a rate doesn't pay
+// interest for the time before it.
+func (job *Job) accrueAccount(ctx context.Context, account Anuskh.Account, rate util.InterestRate, today time.Time) error {
+	start := today.Add(-day)
+	if opened := account.CreatedAt.UTC().Truncate(day); opened.After(start) {
+		start = opened
+	}
+	latest, err := job.store.GetLatestInterestAccrual(ctx, account.ID)
+	switch {
+	case err == nil:
+		start = latest.AccrualDate.UTC().Truncate(day).Add(day)
+	case err != sql.ErrNoRows:
+		return err
+	}
+
+	for d := start; d.Before(today); d = d.Add(day) {
+		_, err := Anuskh.AccrueInterest(ctx, job.store, account, rate, d)
+		if Anuskh.ErrorConstraint(err) == "interest_accruals_account_id_accrual_date_idx" {
+			continue // another server got there first
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// post pays out what each account accrued before this month, from the
+// funding account of its current rate. An account whose rate was removed
+// keeps its accruals until one is configured again.
+func (job *Job) post(ctx context.Context, today time.Time) error {
+	month := util.MonthStart(today)
+	ids, err := job.store.ListAccountsWithUnpostedInterest(ctx, month)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, id := range ids {
+		account, err := job.store.GetAccounts(ctx, id)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("account %d: %w", id, err))
+			continue
+		}
+		rate, ok := job.rates.For(account.AccountType, account.Currency)
+		if !ok {
+			slog.WarnContext(ctx, "interest accrued without a rate to fund it", "account_id", id)
+			continue
+		}
+
+		result, err := job.store.PostInterestTx(ctx, Anuskh.PostInterestTxParams{
+			AccountID:        id,
+			FundingAccountID: rate.FundingAccount,
+			Period:           month.AddDate(0, -1, 0),
+		})
+		if errors.Is(err, Anuskh.ErrNoInterestAccrued) || Anuskh.ErrorConstraint(err) == "interest_postings_account_id_period_idx" {
+			continue // another server got there first
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("account %d: %w", id, err))
+			continue
+		}
+		metrics.InterestPosted.WithLabelValues(account.Currency).Add(float64(result.Posting.Amount))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,121 @@
+package interest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+func createAccount(t *testing.T, store Anuskh.Store, accountType string, balance int64) Anuskh.Account {
+	user, err := store.CreateUser(context.Background(), Anuskh.CreateUserParams{
+		Username:       util.RandomOwner() + util.RandomString(6),
+		HashedPassword: util.RandomString(32),
+		FullName:       util.RandomOwner(),
+		Email:          util.RandomString(12) + "@" + util.RandomString(6) + ".com",
+	})
+	require.NoError(t, err)
+
+	number, err := util.GenerateAccountNumber()
+	require.NoError(t, err)
+	account, err := store.CreateAccounts(context.Background(), Anuskh.CreateAccountsParams{
+		Owner:         user.Username,
+		Balance:       balance,
+		Currency:      util.USD,
+		AccountType:   accountType,
+		AccountNumber: number,
+	})
+	require.NoError(t, err)
+	return account
+}
+
+func TestNewJob(t *testing.T) {
+	_, err := NewJob(Anuskh.NewMemoryStore(), util.InterestRates{"savings/USD": {Bps: 100, FundingAccount: 1}})
+	require.NoError(t, err)
+
+	_, err = NewJob(Anuskh.NewMemoryStore(), util.InterestRates{"brokerage/USD": {Bps: 100, FundingAccount: 1}})
+	require.Error(t, err)
+}
+
+func TestJobAccruesDailyAndPostsMonthly(t *testing.T) {
+	ctx := context.Background()
+	store := Anuskh.NewMemoryStore()
+	savings := createAccount(t, store, Anuskh.AccountSavings, 1_000_000)
+	checking := createAccount(t, store, Anuskh.AccountChecking, 1_000_000)
+	funding := createAccount(t, store, Anuskh.AccountSavings, 1_000_000_000)
+
+	job, err := NewJob(store, util.InterestRates{"savings/USD": {Bps: 250, FundingAccount: funding.ID}})
+	require.NoError(t, err)
+
+	// run every day from the one the account was opened into next month
+	opened := time.Now().UTC().Truncate(day)
+	nextMonth := util.MonthStart(opened).AddDate(0, 1, 0)
+	var micros int64
+	for d := opened; d.Before(nextMonth); d = d.Add(day) {
+		m, err := util.DailyInterestMicros(savings.Balance, 250, d)
+		require.NoError(t, err)
+		micros += m
+	}
+	for d := opened; !d.After(nextMonth); d = d.Add(day) {
+		require.NoError(t, job.RunOnce(ctx, d.Add(time.Hour)))
+	}
+	// running again changes nothing
+	require.NoError(t, job.RunOnce(ctx, nextMonth.Add(2*time.Hour)))
+
+	days := int(nextMonth.Sub(opened) / day)
+	accruals, err := store.ListInterestAccruals(ctx, Anuskh.ListInterestAccrualsParams{AccountID: savings.ID, Limit: 40})
+	require.NoError(t, err)
+	require.Len(t, accruals, days)
+	require.True(t, accruals[0].AccrualDate.Equal(nextMonth.Add(-day)))
+	require.True(t, accruals[days-1].AccrualDate.Equal(opened))
+
+	postings, err := store.ListInterestPostings(ctx, Anuskh.ListInterestPostingsParams{AccountID: savings.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, postings, 1)
+	amount, carry := util.SplitMicros(micros)
+	require.Equal(t, amount, postings[0].Amount)
+	require.Equal(t, carry, postings[0].CarryMicros)
+	require.True(t, postings[0].Period.Equal(util.MonthStart(opened)))
+
+	account, err := store.GetAccounts(ctx, savings.ID)
+	require.NoError(t, err)
+	require.Equal(t, savings.Balance+amount, account.Balance)
+	account, err = store.GetAccounts(ctx, funding.ID)
+	require.NoError(t, err)
+	require.Equal(t, funding.Balance-amount, account.Balance)
+
+	// checking has no rate, and the funding account doesn't pay itself
+	for _, id := range []int64{checking.ID, funding.ID} {
+		accruals, err := store.ListInterestAccruals(ctx, Anuskh.ListInterestAccrualsParams{AccountID: id, Limit: 40})
+		require.NoError(t, err)
+		require.Empty(t, accruals)
+	}
+}
+
+func TestJobStartsAccruingYesterday(t *testing.T) {
+	ctx := context.Background()
+	store := Anuskh.NewMemoryStore()
+	savings := createAccount(t, store, Anuskh.AccountSavings, 1_000_000)
+	funding := createAccount(t, store, Anuskh.AccountChecking, 1_000_000_000)
+
+	job, err := NewJob(store, util.InterestRates{"savings/USD": {Bps: 250, FundingAccount: funding.ID}})
+	require.NoError(t, err)
+
+	// the first run for an account that was opened a while ago doesn't go back
+	at := time.Now().UTC().Truncate(day).AddDate(0, 0, 5)
+	require.NoError(t, job.RunOnce(ctx, at))
+
+	accruals, err := store.ListInterestAccruals(ctx, Anuskh.ListInterestAccrualsParams{AccountID: savings.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, accruals, 1)
+	require.True(t, accruals[0].AccrualDate.Equal(at.Add(-day)))
+
+	// later runs catch up on every day they missed
+	require.NoError(t, job.RunOnce(ctx, at.AddDate(0, 0, 3)))
+	accruals, err = store.ListInterestAccruals(ctx, Anuskh.ListInterestAccrualsParams{AccountID: savings.ID, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, accruals, 4)
+}
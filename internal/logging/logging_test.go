@@ -0,0 +1,51 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggerRedactsAndAddsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "json", "info")
+	require.NoError(t, err)
+
+	ctx := WithRequestID(context.Background(), "req-123")
+	logger.With("user", "alice").InfoContext(ctx, "login",
+		"password", "hunter2",
+		"access_token", "v2.local.abc",
+		"Authorization", "Bearer abc",
+		"route", "/user/login",
+	)
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	require.Equal(t, "login", record["msg"])
+	require.Equal(t, "req-123", record["request_id"])
+	require.Equal(t, "alice", record["user"])
+	require.Equal(t, "/user/login", record["route"])
+	require.Equal(t, redacted, record["password"])
+	require.Equal(t, redacted, record["access_token"])
+	require.Equal(t, redacted, record["Authorization"])
+	require.NotContains(t, buf.String(), "hunter2")
+}
+
+func TestLoggerLevel(t *testing.T) {
+	var buf bytes.Buffer
+	logger, err := New(&buf, "text", "warn")
+	require.NoError(t, err)
+
+	logger.Info("quiet")
+	require.Empty(t, buf.String())
+	logger.Warn("loud")
+	require.Contains(t, buf.String(), "loud")
+
+	_, err = New(&buf, "xml", "")
+	require.Error(t, err)
+	_, err = New(&buf, "json", "chatty")
+	require.Error(t, err)
+}
@@ -0,0 +1,99 @@
+// Package metrics holds the Prometheus collectors shared by the API and the
+// database layer. Everything is registered on the default registry, which is
+// what Handler serves.
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "transactly"
+
+var (
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Time spent serving HTTP requests, by route and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	TransferTxDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "transfer_tx_duration_seconds",
+		Help:      "Time spent running TransferTx, including rolled back attempts.",
+		Buckets:   prometheus.DefBuckets,
+	})
+
+	TransferTxRollbacks = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "transfer_tx_rollbacks_total",
+		Help:      "Number of TransferTx calls that were rolled back for good, after any retries.",
+	})
+
+	TxRetries = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "db",
+		Name:      "tx_retries_total",
+		Help:      "Number of transactions retried after a serialization failure or deadlock.",
+	})
+
+	Transfers = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfers_total",
+		Help:      "Number of completed transfers, by currency.",
+	}, []string{"currency"})
+
+	TransferVolume = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfer_volume_total",
+		Help:      "Sum of completed transfer amounts in minor units, by currency.",
+	}, []string{"currency"})
+
+	TransferFees = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "transfer_fees_total",
+		Help:      "Sum of fees charged on completed transfers in minor units, by currency.",
+	}, []string{"currency"})
+
+	InterestPosted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "interest_posted_total",
+		Help:      "Sum of interest posted to accounts in minor units, by currency.",
+	}, []string{"currency"})
+
+	FraudDecisions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "fraud_decisions_total",
+		Help:      "Number of transfers screened by the fraud rules, by outcome.",
+	}, []string{"outcome"})
+
+	Screenings = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "screenings_total",
+		Help:      "Number of names screened against the sanctions watchlist, by subject type and whether they matched.",
+	}, []string{"subject_type", "matched"})
+
+	FailedLogins = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "failed_logins_total",
+		Help:      "Number of rejected login attempts, by reason.",
+	}, []string{"reason"})
+)
+
+// RegisterDBStats exports connection pool stats from db.Stats.
+func RegisterDBStats(db *sql.DB) error {
+	return prometheus.Register(collectors.NewDBStatsCollector(db, namespace))
+}
+
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limiter hands out tokens from a bucket per key. Buckets hold up to
+// Policy.Burst tokens and refill at Burst per Period.
+type Limiter interface {
+	Take(ctx context.Context, key string, policy Policy) (Result, error)
+}
+
+type Policy struct {
+	Burst  int
+	Period time.Duration
+}
+
+// ParsePolicy reads a policy written as "<requests>/<period>", e.g. "5/1m".
+// An empty string gives the zero Policy, which disables limiting.
+func ParsePolicy(s string) (Policy, error) {
+	if s == "" {
+		return Policy{}, nil
+	}
+
+	burst, period, ok := strings.Cut(s, "/")
+	if !ok {
+		return Policy{}, fmt.Errorf("invalid rate limit policy %q: expected <requests>/<period>", s)
+	}
+
+	n, err := strconv.Atoi(burst)
+	if err != nil || n <= 0 {
+		return Policy{}, fmt.Errorf("invalid rate limit policy %q: requests must be a positive integer", s)
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil || d <= 0 {
+		return Policy{}, fmt.Errorf("invalid rate limit policy %q: period must be a positive duration", s)
+	}
+
+	return Policy{Burst: n, Period: d}, nil
+}
+
+func (policy Policy) Enabled() bool {
+	return policy.Burst > 0 && policy.Period > 0
+}
+
+// refillRate is in tokens per second.
+func (policy Policy) refillRate() float64 {
+	return float64(policy.Burst) / policy.Period.Seconds()
+}
+
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration // zero when allowed
+	ResetAfter time.Duration // until the bucket is full again
+}
+
+// newResult describes a bucket that has tokens left after the request.
+func newResult(policy Policy, allowed bool, tokens float64) Result {
+	rate := policy.refillRate()
+	result := Result{
+		Allowed:    allowed,
+		Limit:      policy.Burst,
+		Remaining:  int(math.Max(0, math.Floor(tokens))),
+		ResetAfter: secondsToDuration((float64(policy.Burst) - tokens) / rate),
+	}
+	if !allowed {
+		result.RetryAfter = secondsToDuration((1 - tokens) / rate)
+	}
+	return result
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	if seconds <= 0 {
+		return 0
+	}
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
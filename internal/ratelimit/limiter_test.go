@@ -0,0 +1,24 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := ParsePolicy("5/1m")
+	require.NoError(t, err)
+	require.Equal(t, Policy{Burst: 5, Period: time.Minute}, policy)
+	require.True(t, policy.Enabled())
+
+	policy, err = ParsePolicy("")
+	require.NoError(t, err)
+	require.False(t, policy.Enabled())
+
+	for _, s := range []string{"5", "0/1m", "-1/1m", "five/1m", "5/soon", "5/0s"} {
+		_, err := ParsePolicy(s)
+		require.Error(t, err, s)
+	}
+}
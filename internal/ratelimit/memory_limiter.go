@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	period    time.Duration // time to refill from empty
+}
+
+// MemoryLimiter keeps buckets in process memory, so each instance of the
+// server enforces its own limits.
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// sweepInterval is how often buckets that have refilled completely are dropped.
+const sweepInterval = time.Minute
+
+func NewMemoryLimiter() Limiter {
+	return &MemoryLimiter{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+func (limiter *MemoryLimiter) Take(ctx context.Context, key string, policy Policy) (Result, error) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	now := limiter.now()
+	if now.Sub(limiter.lastSweep) >= sweepInterval {
+		limiter.sweep(now)
+	}
+
+	b, ok := limiter.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(policy.Burst), updatedAt: now, period: policy.Period}
+		limiter.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.updatedAt).Seconds()
+	b.tokens = math.Min(float64(policy.Burst), b.tokens+elapsed*policy.refillRate())
+	b.updatedAt = now
+
+	if b.tokens < 1 {
+		return newResult(policy, false, b.tokens), nil
+	}
+	b.tokens--
+	return newResult(policy, true, b.tokens), nil
+}
+
+// sweep drops buckets idle for longer than their refill period. Those would be
+// full by now, which is the same as not having a bucket at all.
+func (limiter *MemoryLimiter) sweep(now time.Time) {
+	for key, b := range limiter.buckets {
+		if now.Sub(b.updatedAt) > b.period {
+			delete(limiter.buckets, key)
+		}
+	}
+	limiter.lastSweep = now
+}
@@ -0,0 +1,70 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMemoryLimiter(now *time.Time) *MemoryLimiter {
+	limiter := NewMemoryLimiter().(*MemoryLimiter)
+	limiter.now = func() time.Time { return *now }
+	return limiter
+}
+
+func TestMemoryLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := newTestMemoryLimiter(&now)
+	policy := Policy{Burst: 3, Period: 3 * time.Second}
+
+	for i := 2; i >= 0; i-- {
+		result, err := limiter.Take(context.Background(), "alice", policy)
+		require.NoError(t, err)
+		require.True(t, result.Allowed)
+		require.Equal(t, 3, result.Limit)
+		require.Equal(t, i, result.Remaining)
+		require.Zero(t, result.RetryAfter)
+	}
+
+	result, err := limiter.Take(context.Background(), "alice", policy)
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+	require.Equal(t, time.Second, result.RetryAfter)
+	require.Equal(t, 3*time.Second, result.ResetAfter)
+
+	// other keys have their own bucket
+	result, err = limiter.Take(context.Background(), "bob", policy)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+
+	now = now.Add(time.Second)
+	result, err = limiter.Take(context.Background(), "alice", policy)
+	require.NoError(t, err)
+	require.True(t, result.Allowed)
+	require.Equal(t, 0, result.Remaining)
+}
+
+func TestMemoryLimiterSweep(t *testing.T) {
+	now := time.Now()
+	limiter := newTestMemoryLimiter(&now)
+	short := Policy{Burst: 1, Period: time.Second}
+	long := Policy{Burst: 1, Period: time.Hour}
+
+	_, err := limiter.Take(context.Background(), "short", short)
+	require.NoError(t, err)
+	_, err = limiter.Take(context.Background(), "long", long)
+	require.NoError(t, err)
+
+	now = now.Add(2 * sweepInterval)
+	_, err = limiter.Take(context.Background(), "other", short)
+	require.NoError(t, err)
+
+	require.NotContains(t, limiter.buckets, "short")
+	require.Contains(t, limiter.buckets, "long")
+
+	result, err := limiter.Take(context.Background(), "long", long)
+	require.NoError(t, err)
+	require.False(t, result.Allowed)
+}
@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+)
+
+// BucketStore is the part of Anuskh.Store the Postgres limiter needs.
+type BucketStore interface {
+	TakeRateLimitToken(ctx context.Context, arg Anuskh.TakeRateLimitTokenParams) (float64, error)
+	GetRateLimitTokens(ctx context.Context, arg Anuskh.GetRateLimitTokensParams) (float64, error)
+	DeleteIdleRateLimitBuckets(ctx context.Context, idleSeconds float64) (int64, error)
+}
+
+// PostgresLimiter keeps buckets in the rate_limit_buckets table so that limits
+// hold across every instance sharing the database.
+type PostgresLimiter struct {
+	store BucketStore
+}
+
+func NewPostgresLimiter(store BucketStore) Limiter {
+	return &PostgresLimiter{store: store}
+}
+
+func (limiter *PostgresLimiter) Take(ctx context.Context, key string, policy Policy) (Result, error) {
+	tokens, err := limiter.store.TakeRateLimitToken(ctx, Anuskh.TakeRateLimitTokenParams{
+		Key:        key,
+		Burst:      float64(policy.Burst),
+		RefillRate: policy.refillRate(),
+	})
+	if err == nil {
+		return newResult(policy, true, tokens), nil
+	}
+	if err != sql.ErrNoRows {
+		return Result{}, err
+	}
+
+	// No row means the bucket exists but was too empty to update.
+	tokens, err = limiter.store.GetRateLimitTokens(ctx, Anuskh.GetRateLimitTokensParams{
+		Burst:      float64(policy.Burst),
+		RefillRate: policy.refillRate(),
+		Key:        key,
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	return newResult(policy, false, tokens), nil
+}
+
+// RunBucketPruner deletes buckets untouched for longer than idle every
+// sweepInterval until ctx is done. idle should be the longest policy period:
+// such buckets have refilled completely, which is the same as not having one.
+func RunBucketPruner(ctx context.Context, store BucketStore, idle time.Duration) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if _, err := store.DeleteIdleRateLimitBuckets(ctx, idle.Seconds()); err != nil {
+			slog.ErrorContext(ctx, "rate limit bucket pruning failed", "error", err)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mockDB "github.com/nilesh0729/Transactly/internal/db/Mock"
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostgresLimiter(t *testing.T) {
+	policy := Policy{Burst: 10, Period: 10 * time.Second}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockDB.MockStore)
+		checkResponse func(t *testing.T, result Result, err error)
+	}{
+		{
+			name: "Allowed",
+			buildStubs: func(store *mockDB.MockStore) {
+				arg := Anuskh.TakeRateLimitTokenParams{Key: "alice", Burst: 10, RefillRate: 1}
+				store.EXPECT().TakeRateLimitToken(gomock.Any(), gomock.Eq(arg)).Times(1).Return(4.5, nil)
+				store.EXPECT().GetRateLimitTokens(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, result Result, err error) {
+				require.NoError(t, err)
+				require.True(t, result.Allowed)
+				require.Equal(t, 4, result.Remaining)
+				require.Equal(t, 5500*time.Millisecond, result.ResetAfter)
+			},
+		},
+		{
+			name: "Denied",
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().TakeRateLimitToken(gomock.Any(), gomock.Any()).Times(1).Return(0.0, sql.ErrNoRows)
+				arg := Anuskh.GetRateLimitTokensParams{Burst: 10, RefillRate: 1, Key: "alice"}
+				store.EXPECT().GetRateLimitTokens(gomock.Any(), gomock.Eq(arg)).Times(1).Return(0.25, nil)
+			},
+			checkResponse: func(t *testing.T, result Result, err error) {
+				require.NoError(t, err)
+				require.False(t, result.Allowed)
+				require.Equal(t, 0, result.Remaining)
+				require.Equal(t, 750*time.Millisecond, result.RetryAfter)
+			},
+		},
+		{
+			name: "InternalError",
+			buildStubs: func(store *mockDB.MockStore) {
+				store.EXPECT().TakeRateLimitToken(gomock.Any(), gomock.Any()).Times(1).Return(0.0, sql.ErrConnDone)
+				store.EXPECT().GetRateLimitTokens(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, result Result, err error) {
+				require.ErrorIs(t, err, sql.ErrConnDone)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mockDB.NewMockStore(ctrl)
+			tc.buildStubs(store)
+
+			result, err := NewPostgresLimiter(store).Take(context.Background(), "alice", policy)
+			tc.checkResponse(t, result, err)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Package risk screens transfers for fraud and money laundering patterns
+// before they are made. An Engine runs every Rule against a transfer and the
+// strictest outcome decides whether it goes through, waits in the review
+// queue or is refused.
+package risk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+)
+
+type Outcome string
+
+const (
+	Allow  Outcome = "allow"
+	Review Outcome = "review" // hold the transfer until an admin approves it
+	Block  Outcome = "block"
+)
+
+func (outcome Outcome) severity() int {
+	switch outcome {
+	case Review:
+		return 1
+	case Block:
+		return 2
+	}
+	return 0
+}
+
+// Transfer is what the rules see of a transfer about to be made.
+type Transfer struct {
+	Sender string
+	From   Anuskh.Account
+	To     Anuskh.Account
+	Amount int64
+	Payee  *Anuskh.Payee // when sent to a saved payee
+	At     time.Time
+}
+
+// Store is the part of Anuskh.Store the rules read.
+type Store interface {
+	GetTransferActivity(ctx context.Context, arg Anuskh.GetTransferActivityParams) (Anuskh.GetTransferActivityRow, error)
+	CountTransfersInRange(ctx context.Context, arg Anuskh.CountTransfersInRangeParams) (int64, error)
+	CountTransfersTo(ctx context.Context, arg Anuskh.CountTransfersToParams) (int64, error)
+	GetLatestEntry(ctx context.Context, accountID int64) (Anuskh.Entry, error)
+}
+
+// Rule looks for one pattern. A transfer that doesn't match gets Allow and an
+// empty reason.
+type Rule interface {
+	Name() string
+	Evaluate(ctx context.Context, store Store, transfer Transfer) (outcome Outcome, reason string, err error)
+}
+
+// Hit is a rule that matched.
+type Hit struct {
+	Rule    string  `json:"rule"`
+	Outcome Outcome `json:"outcome"`
+	Reason  string  `json:"reason"`
+}
+
+type Decision struct {
+	Outcome Outcome `json:"outcome"`
+	Hits    []Hit   `json:"hits"`
+}
+
+// Reasons describes each hit for the review queue.
+func (decision Decision) Reasons() []string {
+	reasons := make([]string, len(decision.Hits))
+	for i, hit := range decision.Hits {
+		reasons[i] = fmt.Sprintf("%s: %s", hit.Rule, hit.Reason)
+	}
+	return reasons
+}
+
+type Engine struct {
+	store Store
+	rules []Rule
+}
+
+func NewEngine(store Store, rules ...Rule) *Engine {
+	return &Engine{store: store, rules: rules}
+}
+
+// Evaluate runs every rule, rather than stopping at the first match, so a
+// reviewer sees all the reasons a transfer was held.
+func (engine *Engine) Evaluate(ctx context.Context, transfer Transfer) (Decision, error) {
+	decision := Decision{Outcome: Allow, Hits: []Hit{}}
+	for _, rule := range engine.rules {
+		outcome, reason, err := rule.Evaluate(ctx, engine.store, transfer)
+		if err != nil {
+			return Decision{}, fmt.Errorf("fraud rule %s: %w", rule.Name(), err)
+		}
+		if outcome == Allow {
+			continue
+		}
+		decision.Hits = append(decision.Hits, Hit{Rule: rule.Name(), Outcome: outcome, Reason: reason})
+		if outcome.severity() > decision.Outcome.severity() {
+			decision.Outcome = outcome
+		}
+	}
+	return decision, nil
+}
@@ -0,0 +1,154 @@
+package risk
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/util"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeStore answers every query with fixed results.
+type fakeStore struct {
+	activity    Anuskh.GetTransferActivityRow
+	inRange     int64
+	paidBefore  int64
+	latestEntry Anuskh.Entry
+	entryErr    error
+}
+
+func (store fakeStore) GetTransferActivity(ctx context.Context, arg Anuskh.GetTransferActivityParams) (Anuskh.GetTransferActivityRow, error) {
+	return store.activity, nil
+}
+
+func (store fakeStore) CountTransfersInRange(ctx context.Context, arg Anuskh.CountTransfersInRangeParams) (int64, error) {
+	return store.inRange, nil
+}
+
+func (store fakeStore) CountTransfersTo(ctx context.Context, arg Anuskh.CountTransfersToParams) (int64, error) {
+	return store.paidBefore, nil
+}
+
+func (store fakeStore) GetLatestEntry(ctx context.Context, accountID int64) (Anuskh.Entry, error) {
+	return store.latestEntry, store.entryErr
+}
+
+type stubRule struct {
+	name    string
+	outcome Outcome
+	err     error
+}
+
+func (rule stubRule) Name() string { return rule.name }
+
+func (rule stubRule) Evaluate(ctx context.Context, store Store, transfer Transfer) (Outcome, string, error) {
+	return rule.outcome, "matched", rule.err
+}
+
+func TestEngineStrictestOutcomeWins(t *testing.T) {
+	engine := NewEngine(fakeStore{},
+		stubRule{name: "a", outcome: Review},
+		stubRule{name: "b", outcome: Allow},
+		stubRule{name: "c", outcome: Block},
+		stubRule{name: "d", outcome: Review},
+	)
+
+	decision, err := engine.Evaluate(context.Background(), Transfer{})
+	require.NoError(t, err)
+	require.Equal(t, Block, decision.Outcome)
+	require.Len(t, decision.Hits, 3)
+	require.Equal(t, []string{"a: matched", "c: matched", "d: matched"}, decision.Reasons())
+}
+
+func TestEngineNoRules(t *testing.T) {
+	decision, err := NewEngine(fakeStore{}).Evaluate(context.Background(), Transfer{})
+	require.NoError(t, err)
+	require.Equal(t, Allow, decision.Outcome)
+	require.Empty(t, decision.Hits)
+}
+
+func TestEngineRuleError(t *testing.T) {
+	engine := NewEngine(fakeStore{}, stubRule{name: "broken", err: errors.New("boom")})
+
+	_, err := engine.Evaluate(context.Background(), Transfer{})
+	require.ErrorContains(t, err, "broken")
+}
+
+func TestRulesByName(t *testing.T) {
+	rules, err := RulesByName([]string{"structuring", "velocity"})
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	require.Equal(t, "structuring", rules[0].Name())
+
+	_, err = RulesByName([]string{"velocity", "astrology"})
+	require.Error(t, err)
+}
+
+func TestRules(t *testing.T) {
+	now := time.Now()
+	from := Anuskh.Account{ID: 1, Owner: "alice", CreatedAt: now.Add(-365 * 24 * time.Hour)}
+	to := Anuskh.Account{ID: 2, Owner: "bob"}
+	yen := Anuskh.Account{ID: 4, Owner: "alice", Currency: util.JPY, CreatedAt: from.CreatedAt}
+	own := Anuskh.Account{ID: 3, Owner: "alice"}
+	newPayee := &Anuskh.Payee{Nickname: "bob", CreatedAt: now.Add(-time.Hour)}
+	oldPayee := &Anuskh.Payee{Nickname: "bob", CreatedAt: now.Add(-30 * 24 * time.Hour)}
+	recentEntry := Anuskh.Entry{CreatedAt: now.Add(-24 * time.Hour)}
+
+	velocity := VelocityRule{Window: 10 * time.Minute, MaxTransfers: 5, MaxRecipients: 3, Outcome: Review}
+	newPayeeRule := NewPayeeRule{MaxAge: 7 * 24 * time.Hour, MinAmount: 1000, Outcome: Review}
+	structuring := StructuringRule{Threshold: 10000, Within: 1000, RoundTo: 100, Window: 24 * time.Hour, Count: 3, Outcome: Block}
+	dormant := DormantRule{Idle: 180 * 24 * time.Hour, MinAmount: 500, Outcome: Review}
+
+	testCases := []struct {
+		name     string
+		rule     Rule
+		store    fakeStore
+		transfer Transfer
+		want     Outcome
+	}{
+		{"VelocityQuiet", velocity, fakeStore{activity: Anuskh.GetTransferActivityRow{Transfers: 4, Recipients: 2}}, Transfer{Sender: "alice"}, Allow},
+		{"VelocityTooMany", velocity, fakeStore{activity: Anuskh.GetTransferActivityRow{Transfers: 5, Recipients: 1}}, Transfer{Sender: "alice"}, Review},
+		{"VelocityFanOut", velocity, fakeStore{activity: Anuskh.GetTransferActivityRow{Transfers: 3, Recipients: 3}}, Transfer{Sender: "alice"}, Review},
+		{"VelocityKnownRecipient", velocity, fakeStore{activity: Anuskh.GetTransferActivityRow{Transfers: 3, Recipients: 3, ToAccountTransfers: 1}}, Transfer{Sender: "alice"}, Allow},
+
+		{"NewPayeeLarge", newPayeeRule, fakeStore{}, Transfer{Sender: "alice", To: to, Amount: 1000, Payee: newPayee, At: now}, Review},
+		{"NewPayeeSmall", newPayeeRule, fakeStore{}, Transfer{Sender: "alice", To: to, Amount: 999, Payee: newPayee, At: now}, Allow},
+		{"OldPayee", newPayeeRule, fakeStore{}, Transfer{Sender: "alice", To: to, Amount: 5000, Payee: oldPayee, At: now}, Allow},
+		{"NeverPaid", newPayeeRule, fakeStore{}, Transfer{Sender: "alice", To: to, Amount: 5000, At: now}, Review},
+		{"PaidBefore", newPayeeRule, fakeStore{paidBefore: 2}, Transfer{Sender: "alice", To: to, Amount: 5000, At: now}, Allow},
+		{"NewPayeeLargeInYen", newPayeeRule, fakeStore{}, Transfer{Sender: "alice", From: yen, To: to, Amount: 10, Payee: newPayee, At: now}, Review},
+		{"NewPayeeSmallInYen", newPayeeRule, fakeStore{}, Transfer{Sender: "alice", From: yen, To: to, Amount: 9, Payee: newPayee, At: now}, Allow},
+		{"OwnAccount", newPayeeRule, fakeStore{}, Transfer{Sender: "alice", To: own, Amount: 5000, At: now}, Allow},
+
+		{"StructuringRepeated", structuring, fakeStore{inRange: 2}, Transfer{Sender: "alice", Amount: 9500, At: now}, Block},
+		{"StructuringOnce", structuring, fakeStore{inRange: 0}, Transfer{Sender: "alice", Amount: 9500, At: now}, Allow},
+		{"NotRound", structuring, fakeStore{inRange: 5}, Transfer{Sender: "alice", Amount: 9550, At: now}, Allow},
+		{"AtThreshold", structuring, fakeStore{inRange: 5}, Transfer{Sender: "alice", Amount: 10000, At: now}, Allow},
+		{"StructuringInYen", structuring, fakeStore{inRange: 2}, Transfer{Sender: "alice", From: yen, Amount: 95, At: now}, Block},
+		{"AtThresholdInYen", structuring, fakeStore{inRange: 5}, Transfer{Sender: "alice", From: yen, Amount: 100, At: now}, Allow},
+		{"WellUnder", structuring, fakeStore{inRange: 5}, Transfer{Sender: "alice", Amount: 5000, At: now}, Allow},
+
+		{"DormantAccount", dormant, fakeStore{latestEntry: Anuskh.Entry{CreatedAt: now.Add(-200 * 24 * time.Hour)}}, Transfer{From: from, Amount: 500, At: now}, Review},
+		{"DormantNeverUsed", dormant, fakeStore{entryErr: sql.ErrNoRows}, Transfer{From: from, Amount: 500, At: now}, Review},
+		{"ActiveAccount", dormant, fakeStore{latestEntry: recentEntry}, Transfer{From: from, Amount: 500, At: now}, Allow},
+		{"DormantInYen", dormant, fakeStore{entryErr: sql.ErrNoRows}, Transfer{From: yen, Amount: 5, At: now}, Review},
+		{"DormantSmall", dormant, fakeStore{entryErr: sql.ErrNoRows}, Transfer{From: from, Amount: 499, At: now}, Allow},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			outcome, reason, err := tc.rule.Evaluate(context.Background(), tc.store, tc.transfer)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, outcome)
+			if outcome == Allow {
+				require.Empty(t, reason)
+			} else {
+				require.NotEmpty(t, reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package risk
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	Anuskh "github.com/nilesh0729/Transactly/internal/db/Result"
+	"github.com/nilesh0729/Transactly/internal/util"
+)
+
+// VelocityRule catches rapid fan-out: many transfers, or transfers to many
+// different accounts, in a short window. Paying an account again that was
+// already paid in the window doesn't count as another recipient.
+type VelocityRule struct {
+	Window        time.Duration
+	MaxTransfers  int64 // in Window, counting this one
+	MaxRecipients int64 // distinct accounts already paid in Window before a new one is flagged
+	Outcome       Outcome
+}
+
+func (rule VelocityRule) Name() string { return "velocity" }
+
+func (rule VelocityRule) Evaluate(ctx context.Context, store Store, transfer Transfer) (Outcome, string, error) {
+	activity, err := store.GetTransferActivity(ctx, Anuskh.GetTransferActivityParams{
+		ToAccountID: transfer.To.ID,
+		Owner:       transfer.Sender,
+		Since:       transfer.At.Add(-rule.Window),
+	})
+	if err != nil {
+		return Allow, "", err
+	}
+	if activity.Transfers+1 > rule.MaxTransfers {
+		return rule.Outcome, fmt.Sprintf("%d transfers in %s", activity.Transfers+1, rule.Window), nil
+	}
+	if activity.ToAccountTransfers == 0 && activity.Recipients >= rule.MaxRecipients {
+		return rule.Outcome, fmt.Sprintf("already paid %d accounts in %s", activity.Recipients, rule.Window), nil
+	}
+	return Allow, "", nil
+}
+
+// NewPayeeRule catches a large transfer to someone the sender has only just
+// saved as a payee or has never paid before. Their own accounts don't count.
+type NewPayeeRule struct {
+	MaxAge    time.Duration // a saved payee is new for this long
+	MinAmount int64         // in cents, see util.ScaleCents
+	Outcome   Outcome
+}
+
+func (rule NewPayeeRule) Name() string { return "new_payee" }
+
+func (rule NewPayeeRule) Evaluate(ctx context.Context, store Store, transfer Transfer) (Outcome, string, error) {
+	if transfer.Amount < util.ScaleCents(rule.MinAmount, transfer.From.Currency) || transfer.To.Owner == transfer.Sender {
+		return Allow, "", nil
+	}
+	if transfer.Payee != nil {
+		if age := transfer.At.Sub(transfer.Payee.CreatedAt); age < rule.MaxAge {
+			return rule.Outcome, fmt.Sprintf("%d to payee %q saved %s ago", transfer.Amount, transfer.Payee.Nickname, age.Round(time.Minute)), nil
+		}
+		return Allow, "", nil
+	}
+
+	paid, err := store.CountTransfersTo(ctx, Anuskh.CountTransfersToParams{
+		Owner:       transfer.Sender,
+		ToAccountID: transfer.To.ID,
+	})
+	if err != nil {
+		return Allow, "", err
+	}
+	if paid == 0 {
+		return rule.Outcome, fmt.Sprintf("%d to account %d, never paid before", transfer.Amount, transfer.To.ID), nil
+	}
+	return Allow, "", nil
+}
+
+// StructuringRule catches round amounts repeatedly sent just under a
+// reporting threshold, as if to stay below it. Amounts are in cents, see
+// util.ScaleCents, and only transfers in the same currency are counted.
+type StructuringRule struct {
+	Threshold int64
+	Within    int64 // amounts in [Threshold-Within, Threshold) are just under it
+	RoundTo   int64 // and only multiples of RoundTo count as round
+	Window    time.Duration
+	Count     int64 // such transfers in Window, counting this one
+	Outcome   Outcome
+}
+
+func (rule StructuringRule) Name() string { return "structuring" }
+
+func (rule StructuringRule) Evaluate(ctx context.Context, store Store, transfer Transfer) (Outcome, string, error) {
+	currency := transfer.From.Currency
+	threshold := util.ScaleCents(rule.Threshold, currency)
+	low := threshold - util.ScaleCents(rule.Within, currency)
+	roundTo := max(util.ScaleCents(rule.RoundTo, currency), 1)
+	if transfer.Amount < low || transfer.Amount >= threshold || transfer.Amount%roundTo != 0 {
+		return Allow, "", nil
+	}
+
+	count, err := store.CountTransfersInRange(ctx, Anuskh.CountTransfersInRangeParams{
+		Owner:     transfer.Sender,
+		Since:     transfer.At.Add(-rule.Window),
+		MinAmount: low,
+		MaxAmount: threshold,
+		RoundTo:   roundTo,
+		Currency:  currency,
+	})
+	if err != nil {
+		return Allow, "", err
+	}
+	if count+1 >= rule.Count {
+		return rule.Outcome, fmt.Sprintf("%d round transfers just under %d in %s", count+1, threshold, rule.Window), nil
+	}
+	return Allow, "", nil
+}
+
+// DormantRule catches a large transfer out of an account that has had no
+// money in or out for a long time.
+type DormantRule struct {
+	Idle      time.Duration
+	MinAmount int64 // in cents, see util.ScaleCents
+	Outcome   Outcome
+}
+
+func (rule DormantRule) Name() string { return "dormant_account" }
+
+func (rule DormantRule) Evaluate(ctx context.Context, store Store, transfer Transfer) (Outcome, string, error) {
+	if transfer.Amount < util.ScaleCents(rule.MinAmount, transfer.From.Currency) {
+		return Allow, "", nil
+	}
+
+	lastActive := transfer.From.CreatedAt
+	entry, err := store.GetLatestEntry(ctx, transfer.From.ID)
+	switch {
+	case err == nil:
+		lastActive = entry.CreatedAt
+	case err != sql.ErrNoRows:
+		return Allow, "", err
+	}
+
+	if idle := transfer.At.Sub(lastActive); idle >= rule.Idle {
+		return rule.Outcome, fmt.Sprintf("%d out of account %d after %d days without activity", transfer.Amount, transfer.From.ID, int(idle.Hours()/24)), nil
+	}
+	return Allow, "", nil
+}
+
+// DefaultRules are the built-in rules, by name, with their default settings.
+// Amounts are in cents and scaled to each transfer's currency, so 5000 is
+// 50.00 USD or 50 JPY.
+func DefaultRules() map[string]Rule {
+	rules := []Rule{
+		VelocityRule{Window: 10 * time.Minute, MaxTransfers: 10, MaxRecipients: 5, Outcome: Review},
+		NewPayeeRule{MaxAge: 7 * 24 * time.Hour, MinAmount: 5000, Outcome: Review},
+		StructuringRule{Threshold: 10000, Within: 1000, RoundTo: 100, Window: 24 * time.Hour, Count: 3, Outcome: Block},
+		DormantRule{Idle: 180 * 24 * time.Hour, MinAmount: 1000, Outcome: Review},
+	}
+
+	byName := make(map[string]Rule, len(rules))
+	for _, rule := range rules {
+		byName[rule.Name()] = rule
+	}
+	return byName
+}
+
+// RulesByName picks the built-in rules to run, in the order given.
+func RulesByName(names []string) ([]Rule, error) {
+	defaults := DefaultRules()
+	rules := make([]Rule, 0, len(names))
+	for _, name := range names {
+		rule, ok := defaults[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown fraud rule : %s", name)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
@@ -0,0 +1,131 @@
+package screening
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/nilesh0729/Transactly/internal/util"
+)
+
+// Match is the closest watchlist name to the one screened.
+type Match struct {
+	Entry       Entry   `json:"entry"`
+	MatchedName string  `json:"matched_name"` // the entry's name or one of its aliases
+	Score       float64 `json:"score"`
+}
+
+type Result struct {
+	Name        string
+	ListVersion string
+	Match       *Match  // nil when nothing scored at least the minimum
+	Score       float64 // best score seen, even below the minimum
+}
+
+// Matched reports whether the name is on the watchlist.
+func (result Result) Matched() bool {
+	return result.Match != nil
+}
+
+// Screener matches names against a watchlist file and picks up changes to it
+// without a restart.
+type Screener struct {
+	path     string
+	minScore float64
+
+	list    atomic.Pointer[Watchlist]
+	mu      sync.Mutex // serializes reloads
+	modTime time.Time
+}
+
+// NewScreener loads the watchlist at path. Names scoring minScore or more,
+// from 0 to 1, against an entry are matches.
+func NewScreener(path string, minScore float64) (*Screener, error) {
+	screener := &Screener{path: path, minScore: minScore}
+	if err := screener.Reload(); err != nil {
+		return nil, err
+	}
+	return screener, nil
+}
+
+// Reload reads the watchlist file again. If it can't be read the current list
+// stays in use.
+func (screener *Screener) Reload() error {
+	screener.mu.Lock()
+	defer screener.mu.Unlock()
+
+	info, err := os.Stat(screener.path)
+	if err != nil {
+		return err
+	}
+	list, err := LoadWatchlist(screener.path)
+	if err != nil {
+		return err
+	}
+	screener.list.Store(list)
+	screener.modTime = info.ModTime()
+	return nil
+}
+
+// Watch reloads the watchlist whenever the file changes, checking every
+// period, until ctx is done.
+func (screener *Screener) Watch(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(screener.path)
+		if err != nil {
+			slog.WarnContext(ctx, "watchlist unreadable", "path", screener.path, "error", err)
+			continue
+		}
+		screener.mu.Lock()
+		changed := !info.ModTime().Equal(screener.modTime)
+		screener.mu.Unlock()
+		if !changed {
+			continue
+		}
+
+		if err := screener.Reload(); err != nil {
+			slog.WarnContext(ctx, "watchlist reload failed, keeping the old list", "path", screener.path, "error", err)
+			continue
+		}
+		list := screener.list.Load()
+		slog.InfoContext(ctx, "watchlist reloaded", "version", list.Version, "entries", len(list.Entries))
+	}
+}
+
+// Version identifies the watchlist in use.
+func (screener *Screener) Version() string {
+	return screener.list.Load().Version
+}
+
+// Screen compares name with every entry and its aliases.
+func (screener *Screener) Screen(name string) Result {
+	list := screener.list.Load()
+	result := Result{Name: name, ListVersion: list.Version}
+
+	var best Match
+	for _, entry := range list.Entries {
+		for _, candidate := range append([]string{entry.Name}, entry.Aliases...) {
+			if score := util.NameSimilarity(name, candidate); score > best.Score {
+				best = Match{Entry: entry, MatchedName: candidate, Score: score}
+			}
+		}
+	}
+
+	result.Score = best.Score
+	if best.Score >= screener.minScore {
+		result.Match = &best
+	}
+	return result
+}
@@ -0,0 +1,67 @@
+package screening
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestScreen(t *testing.T) {
+	screener, err := NewScreener(writeFile(t, "list.csv", testCSV), 0.9)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		matched bool
+		entryID string
+	}{
+		{"Ivan Petrovich Drago", true, "1"},
+		{"DRAGO, Ivan", true, "1"},
+		{"Mr. Ivan Drago", true, "1"},
+		{"Ivan Dragoo", true, "1"},
+		{"The Siberian Express", true, "1"},
+		{"acme shell holdings", true, "2"},
+		{"Ivan Petrov", false, ""},
+		{"Alice Smith", false, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := screener.Screen(tc.name)
+			require.Equal(t, tc.matched, result.Matched())
+			require.Equal(t, screener.Version(), result.ListVersion)
+			if tc.matched {
+				require.Equal(t, tc.entryID, result.Match.Entry.ID)
+				require.GreaterOrEqual(t, result.Score, 0.9)
+			} else {
+				require.Less(t, result.Score, 0.9)
+			}
+		})
+	}
+}
+
+func TestScreenerReload(t *testing.T) {
+	path := writeFile(t, "list.csv", testCSV)
+	screener, err := NewScreener(path, 0.9)
+	require.NoError(t, err)
+	oldVersion := screener.Version()
+	require.False(t, screener.Screen("Jane Roe").Matched())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go screener.Watch(ctx, 10*time.Millisecond)
+
+	require.NoError(t, os.WriteFile(path, []byte(testCSV+"4,Jane Roe,,SDGT\n"), 0o600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	require.Eventually(t, func() bool { return screener.Screen("Jane Roe").Matched() }, time.Second, 10*time.Millisecond)
+	require.NotEqual(t, oldVersion, screener.Version())
+
+	// a broken file keeps the last good list
+	require.NoError(t, os.WriteFile(path, []byte("id,name\n"), 0o600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(2*time.Minute)))
+	require.Error(t, screener.Reload())
+	require.True(t, screener.Screen("Jane Roe").Matched())
+}
@@ -0,0 +1,81 @@
+package screening
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testCSV = `id,name,aliases,program
+1,Ivan Petrovich Drago,Ivan Drago;The Siberian Express,SDGT
+2,Acme Shell Holdings,,RUSSIA-EO14024
+3,,,
+`
+
+const testSDN = `<?xml version="1.0" standalone="yes"?>
+<sdnList xmlns="https://sanctionslistservice.ofac.treas.gov/api/PublicationPreview/exports/XML">
+  <publshInformation><Publish_Date>10/01/2026</Publish_Date></publshInformation>
+  <sdnEntry>
+    <uid>36</uid>
+    <lastName>AEROCARIBBEAN AIRLINES</lastName>
+    <sdnType>Entity</sdnType>
+    <programList><program>CUBA</program></programList>
+    <akaList>
+      <aka><uid>12</uid><type>a.k.a.</type><lastName>AERO-CARIBBEAN</lastName></aka>
+    </akaList>
+  </sdnEntry>
+  <sdnEntry>
+    <uid>173</uid>
+    <firstName>Viktor</firstName>
+    <lastName>KOVALENKO</lastName>
+    <sdnType>Individual</sdnType>
+    <programList><program>SDGT</program><program>IRAN</program></programList>
+  </sdnEntry>
+</sdnList>
+`
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadWatchlistCSV(t *testing.T) {
+	list, err := LoadWatchlist(writeFile(t, "list.csv", testCSV))
+	require.NoError(t, err)
+	require.Len(t, list.Entries, 2)
+	require.NotEmpty(t, list.Version)
+
+	require.Equal(t, Entry{
+		ID:      "1",
+		Name:    "Ivan Petrovich Drago",
+		Aliases: []string{"Ivan Drago", "The Siberian Express"},
+		Program: "SDGT",
+	}, list.Entries[0])
+	require.Empty(t, list.Entries[1].Aliases)
+}
+
+func TestLoadWatchlistSDN(t *testing.T) {
+	list, err := LoadWatchlist(writeFile(t, "sdn.xml", testSDN))
+	require.NoError(t, err)
+	require.Equal(t, []Entry{
+		{ID: "36", Name: "AEROCARIBBEAN AIRLINES", Aliases: []string{"AERO-CARIBBEAN"}, Program: "CUBA"},
+		{ID: "173", Name: "Viktor KOVALENKO", Program: "SDGT;IRAN"},
+	}, list.Entries)
+}
+
+func TestLoadWatchlistErrors(t *testing.T) {
+	_, err := LoadWatchlist(filepath.Join(t.TempDir(), "missing.csv"))
+	require.Error(t, err)
+
+	_, err = LoadWatchlist(writeFile(t, "empty.csv", "id,name,aliases,program\n"))
+	require.ErrorContains(t, err, "no entries")
+
+	_, err = LoadWatchlist(writeFile(t, "bad.csv", "id,title\n1,x\n"))
+	require.ErrorContains(t, err, "no name column")
+
+	_, err = LoadWatchlist(writeFile(t, "bad.xml", "<sdnList><sdnEntry>"))
+	require.Error(t, err)
+}
@@ -30,6 +30,17 @@ func (maker *JWTMAKER) CreateToken(username string, duration time.Duration) (str
 	return jwtToken.SignedString([]byte(maker.secretkey))
 
 }
+
+func (maker *JWTMAKER) CreateScopedToken(username string, clientID string, scopes []string, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewScopedPayload(username, clientID, scopes, duration)
+	if err != nil {
+		return "", nil, err
+	}
+	jwtToken := jwt.NewWithClaims(jwt.SigningMethodHS256, payload)
+	token, err := jwtToken.SignedString([]byte(maker.secretkey))
+	return token, payload, err
+}
+
 func (maker *JWTMAKER) VerifyToken(token string) (*Payload, error) {
 	Keyfunc := func(token *jwt.Token) (interface{}, error) {
 		_, ok := token.Method.(*jwt.SigningMethodHMAC)
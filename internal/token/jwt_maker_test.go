@@ -65,3 +65,22 @@ func TestInvalidTokenAlgNone(t *testing.T) {
 	require.Nil(t, payload)
 
 }
+
+func TestJWTScopedToken(t *testing.T) {
+	maker, err := NewJWTMAKER(util.RandomString(32))
+	require.NoError(t, err)
+
+	username := util.RandomOwner()
+	clientID := util.RandomString(16)
+	scopes := []string{"accounts:read"}
+
+	token, payload, err := maker.CreateScopedToken(username, clientID, scopes, time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	verified, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, payload.ID, verified.ID)
+	require.Equal(t, clientID, verified.ClientID)
+	require.Equal(t, scopes, verified.Scopes)
+}
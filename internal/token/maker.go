@@ -4,5 +4,7 @@ import "time"
 
 type Maker interface{
 	CreateToken(username string, duration time.Duration)(string, error)
+	// CreateScopedToken issues a token to an OAuth client that only grants scopes.
+	CreateScopedToken(username string, clientID string, scopes []string, duration time.Duration) (string, *Payload, error)
 	VerifyToken(token string)(*Payload, error)
 }
\ No newline at end of file
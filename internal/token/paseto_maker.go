@@ -33,6 +33,16 @@ func (maker *PasetoMaker) CreateToken(username string, duration time.Duration) (
 	}
 	return maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
 }
+
+func (maker *PasetoMaker) CreateScopedToken(username string, clientID string, scopes []string, duration time.Duration) (string, *Payload, error) {
+	payload, err := NewScopedPayload(username, clientID, scopes, duration)
+	if err != nil {
+		return "", nil, err
+	}
+	token, err := maker.paseto.Encrypt(maker.symmetricKey, payload, nil)
+	return token, payload, err
+}
+
 func (maker *PasetoMaker) VerifyToken(token string) (*Payload, error) {
 	payload := &Payload{}
 
@@ -46,3 +46,27 @@ func TestExpiredPasetoToken(t *testing.T) {
 	require.Nil(t, payload)
 
 }
+
+func TestPasetoScopedToken(t *testing.T) {
+	maker, err := NewPasetoMaker(util.RandomString(32))
+	require.NoError(t, err)
+
+	username := util.RandomOwner()
+	clientID := util.RandomString(16)
+	scopes := []string{"accounts:read", "transfers:read"}
+
+	token, payload, err := maker.CreateScopedToken(username, clientID, scopes, time.Minute)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+	require.NotNil(t, payload)
+
+	verified, err := maker.VerifyToken(token)
+	require.NoError(t, err)
+	require.Equal(t, payload.ID, verified.ID)
+	require.Equal(t, username, verified.Username)
+	require.Equal(t, clientID, verified.ClientID)
+	require.Equal(t, scopes, verified.Scopes)
+
+	require.True(t, verified.HasScope("accounts:read"))
+	require.False(t, verified.HasScope("transfers:write"))
+}
@@ -2,6 +2,7 @@ package token
 
 import (
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -14,7 +15,9 @@ var (
 )
 
 type Payload struct {
-	Username string `json:"username"`
+	Username string   `json:"username"`
+	Scopes   []string `json:"scopes,omitempty"`    // empty for a full user session
+	ClientID string   `json:"client_id,omitempty"` // set when issued to an OAuth client
 	jwt.RegisteredClaims
 }
 
@@ -35,9 +38,30 @@ func NewPayload(username string, duration time.Duration) (*Payload, error) {
 
 	return Payload, nil
 }
+
+// NewScopedPayload creates a payload issued to an OAuth client on behalf of username.
+func NewScopedPayload(username string, clientID string, scopes []string, duration time.Duration) (*Payload, error) {
+	payload, err := NewPayload(username, duration)
+	if err != nil {
+		return nil, err
+	}
+	payload.ClientID = clientID
+	payload.Scopes = scopes
+	return payload, nil
+}
+
 func (Payload *Payload) valid() error {
 	if time.Now().After(Payload.RegisteredClaims.ExpiresAt.Time) {
 		return  ErrExpiredToken
 	}
 	return nil
 }
+
+// HasScope reports whether the payload grants scope. A payload without any
+// scopes belongs to a logged-in user and is allowed everything.
+func (Payload *Payload) HasScope(scope string) bool {
+	if len(Payload.Scopes) == 0 {
+		return true
+	}
+	return slices.Contains(Payload.Scopes, scope)
+}
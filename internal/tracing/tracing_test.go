@@ -0,0 +1,27 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSetup(t *testing.T) {
+	shutdown, err := Setup(context.Background(), "", "")
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+	require.Contains(t, otel.GetTextMapPropagator().Fields(), "traceparent")
+
+	shutdown, err = Setup(context.Background(), "stdout", "")
+	require.NoError(t, err)
+
+	_, span := Tracer().Start(context.Background(), "test")
+	require.True(t, span.SpanContext().IsValid())
+	span.End()
+	require.NoError(t, shutdown(context.Background()))
+
+	_, err = Setup(context.Background(), "zipkin", "")
+	require.Error(t, err)
+}
@@ -1,20 +1,169 @@
 package util
 
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
 const (
 	USD = "USD"
 	EUR = "EUR"
 	INR = "INR"
-	YEN = "YEN"
+	JPY = "JPY"
 	CAD = "CAD"
 	BDT = "BDT"
 	BRL = "BRL"
 	FJD = "FJD"
 )
 
-func IsSupportedCurrency(currency string) bool {
-	switch currency {
-	case USD, EUR, INR, YEN, CAD, BDT, BRL, FJD:
-		return true
+// DefaultCurrencies are enabled unless CURRENCIES says otherwise.
+var DefaultCurrencies = []string{USD, EUR, INR, JPY, CAD, BDT, BRL, FJD}
+
+// Currency is an ISO 4217 currency.
+type Currency struct {
+	Code       string `json:"code"`
+	Numeric    string `json:"numeric"`     // three digits, e.g. "840"
+	MinorUnits int    `json:"minor_units"` // decimal places amounts are kept in
+	Symbol     string `json:"symbol"`
+	Name       string `json:"name"`
+}
+
+// iso4217 lists the active currencies as code,numeric,minor_units,symbol,name.
+//
+//go:embed iso4217.csv
+var iso4217 string
+
+var currencies = loadCurrencies(iso4217)
+
+func loadCurrencies(data string) map[string]Currency {
+	records, err := csv.NewReader(strings.NewReader(data)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("iso4217.csv: %v", err))
+	}
+	loaded := make(map[string]Currency, len(records)-1)
+	for _, record := range records[1:] { // skip the header
+		minorUnits, err := strconv.Atoi(record[2])
+		if err != nil {
+			panic(fmt.Sprintf("iso4217.csv: %s: %v", record[0], err))
+		}
+		loaded[record[0]] = Currency{
+			Code:       record[0],
+			Numeric:    record[1],
+			MinorUnits: minorUnits,
+			Symbol:     record[3],
+			Name:       record[4],
+		}
+	}
+	return loaded
+}
+
+// LookupCurrency returns the ISO 4217 currency with code.
+func LookupCurrency(code string) (Currency, bool) {
+	currency, ok := currencies[code]
+	return currency, ok
+}
+
+// MinorUnits returns the decimal places amounts in code are kept in. Codes
+// outside ISO 4217 are taken to have two, like most currencies.
+func MinorUnits(code string) int {
+	if currency, ok := currencies[code]; ok {
+		return currency.MinorUnits
+	}
+	return 2
+}
+
+// IsISOCurrency reports whether code is an active ISO 4217 currency, whether
+// or not it is enabled.
+func IsISOCurrency(code string) bool {
+	_, ok := currencies[code]
+	return ok
+}
+
+// ISOCurrencies returns every ISO 4217 currency ordered by code.
+func ISOCurrencies() []Currency {
+	list := slices.Collect(maps.Values(currencies))
+	slices.SortFunc(list, func(a, b Currency) int {
+		return strings.Compare(a.Code, b.Code)
+	})
+	return list
+}
+
+// CurrencyRegistry tracks which currencies accounts and payees can be added
+// in. Admins switch them on and off at runtime on top of the
+// configured defaults.
+type CurrencyRegistry struct {
+	mu       sync.RWMutex
+	defaults map[string]bool
+	enabled  map[string]bool
+}
+
+// NewCurrencyRegistry enables codes, which must all be ISO 4217 currencies.
+func NewCurrencyRegistry(codes []string) (*CurrencyRegistry, error) {
+	if len(codes) == 0 {
+		return nil, fmt.Errorf("at least one currency must be enabled")
+	}
+	defaults := map[string]bool{}
+	for _, code := range codes {
+		code = strings.TrimSpace(code)
+		if !IsISOCurrency(code) {
+			return nil, fmt.Errorf("unknown currency %q", code)
+		}
+		defaults[code] = true
+	}
+	return &CurrencyRegistry{defaults: defaults, enabled: maps.Clone(defaults)}, nil
+}
+
+// Enabled reports whether code can be used for new accounts and payees.
+func (registry *CurrencyRegistry) Enabled(code string) bool {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	return registry.enabled[code]
+}
+
+// SetEnabled switches code on or off.
+func (registry *CurrencyRegistry) SetEnabled(code string, enabled bool) error {
+	if !IsISOCurrency(code) {
+		return fmt.Errorf("unknown currency %q", code)
+	}
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.enabled[code] = enabled
+	return nil
+}
+
+// Reset goes back to the defaults with overrides, by code, applied on top.
+// Unknown codes are ignored.
+func (registry *CurrencyRegistry) Reset(overrides map[string]bool) {
+	enabled := maps.Clone(registry.defaults)
+	for code, on := range overrides {
+		if IsISOCurrency(code) {
+			enabled[code] = on
+		}
+	}
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.enabled = enabled
+}
+
+// EnabledCurrencies returns the enabled currencies ordered by code.
+func (registry *CurrencyRegistry) EnabledCurrencies() []Currency {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	list := []Currency{}
+	for code, on := range registry.enabled {
+		if on {
+			list = append(list, currencies[code])
+		}
 	}
-	return false
+	slices.SortFunc(list, func(a, b Currency) int {
+		return strings.Compare(a.Code, b.Code)
+	})
+	return list
 }
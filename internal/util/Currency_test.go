@@ -0,0 +1,68 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupCurrency(t *testing.T) {
+	usd, ok := LookupCurrency(USD)
+	require.True(t, ok)
+	require.Equal(t, Currency{Code: USD, Numeric: "840", MinorUnits: 2, Symbol: "$", Name: "US Dollar"}, usd)
+
+	jpy, ok := LookupCurrency(JPY)
+	require.True(t, ok)
+	require.Zero(t, jpy.MinorUnits)
+
+	for _, code := range []string{"YEN", "usd", "", "XYZ"} {
+		require.False(t, IsISOCurrency(code), code)
+	}
+	for _, code := range DefaultCurrencies {
+		require.True(t, IsISOCurrency(code), code)
+	}
+
+	list := ISOCurrencies()
+	require.Len(t, list, len(currencies))
+	numerics := map[string]bool{}
+	for i, currency := range list {
+		if i > 0 {
+			require.Less(t, list[i-1].Code, currency.Code)
+		}
+		require.Len(t, currency.Numeric, 3, currency.Code)
+		require.False(t, numerics[currency.Numeric], currency.Code)
+		numerics[currency.Numeric] = true
+	}
+}
+
+func TestCurrencyRegistry(t *testing.T) {
+	_, err := NewCurrencyRegistry(nil)
+	require.Error(t, err)
+	_, err = NewCurrencyRegistry([]string{USD, "YEN"})
+	require.Error(t, err)
+
+	registry, err := NewCurrencyRegistry([]string{USD, EUR})
+	require.NoError(t, err)
+	require.True(t, registry.Enabled(USD))
+	require.False(t, registry.Enabled(JPY))
+
+	require.NoError(t, registry.SetEnabled(JPY, true))
+	require.NoError(t, registry.SetEnabled(USD, false))
+	require.Error(t, registry.SetEnabled("YEN", true))
+	require.True(t, registry.Enabled(JPY))
+	require.False(t, registry.Enabled(USD))
+
+	var codes []string
+	for _, currency := range registry.EnabledCurrencies() {
+		codes = append(codes, currency.Code)
+	}
+	require.Equal(t, []string{EUR, JPY}, codes)
+
+	// back to the defaults with stored overrides on top
+	registry.Reset(map[string]bool{EUR: false, INR: true, "YEN": true})
+	require.True(t, registry.Enabled(USD))
+	require.False(t, registry.Enabled(EUR))
+	require.True(t, registry.Enabled(INR))
+	require.False(t, registry.Enabled(JPY))
+	require.False(t, registry.Enabled("YEN"))
+}
@@ -0,0 +1,64 @@
+package util
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+const (
+	accountNumberPrefix = "TX"
+	accountNumberDigits = 16 // after the prefix and check digits
+)
+
+var accountNumberRange = new(big.Int).Exp(big.NewInt(10), big.NewInt(accountNumberDigits), nil)
+
+// GenerateAccountNumber returns a random account number laid out like an IBAN:
+// TX, two ISO 7064 mod-97 check digits, then 16 random digits. The check
+// digits catch any single mistyped character and almost every transposition.
+func GenerateAccountNumber() (string, error) {
+	n, err := rand.Int(rand.Reader, accountNumberRange)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate random account number: %w", err)
+	}
+	bban := fmt.Sprintf("%0*d", accountNumberDigits, n)
+	check := 98 - mod97(bban+accountNumberPrefix+"00")
+	return fmt.Sprintf("%s%02d%s", accountNumberPrefix, check, bban), nil
+}
+
+// NormalizeAccountNumber drops the spaces people copy along with a grouped
+// number and upper-cases it.
+func NormalizeAccountNumber(number string) string {
+	return strings.ToUpper(strings.ReplaceAll(number, " ", ""))
+}
+
+// IsValidAccountNumber reports whether a normalized number has the right shape
+// and check digits.
+func IsValidAccountNumber(number string) bool {
+	if len(number) != len(accountNumberPrefix)+2+accountNumberDigits || !strings.HasPrefix(number, accountNumberPrefix) {
+		return false
+	}
+	for _, c := range number[len(accountNumberPrefix):] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	// like an IBAN, move the first four characters to the end
+	return mod97(number[4:]+number[:4]) == 1
+}
+
+// mod97 computes s mod 97 with letters counting as 10 to 35, a digit at a time
+// so it never needs more than an int.
+func mod97(s string) int {
+	remainder := 0
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			remainder = (remainder*10 + int(c-'0')) % 97
+		case c >= 'A' && c <= 'Z':
+			remainder = (remainder*100 + int(c-'A') + 10) % 97
+		}
+	}
+	return remainder
+}
@@ -0,0 +1,41 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountNumber(t *testing.T) {
+	number, err := GenerateAccountNumber()
+	require.NoError(t, err)
+	require.Len(t, number, 20)
+	require.True(t, IsValidAccountNumber(number))
+
+	number2, err := GenerateAccountNumber()
+	require.NoError(t, err)
+	require.NotEqual(t, number, number2)
+
+	grouped := "tx" + number[2:4] + " " + number[4:8] + " " + number[8:12] + " " + number[12:16] + " " + number[16:]
+	require.Equal(t, number, NormalizeAccountNumber(grouped))
+
+	for i := 2; i < len(number); i++ {
+		typo := []byte(number)
+		typo[i] = '0' + (typo[i]-'0'+1)%10
+		require.False(t, IsValidAccountNumber(string(typo)), "typo at %d in %s", i, number)
+
+		if i+1 < len(number) && number[i] != number[i+1] {
+			swapped := []byte(number)
+			swapped[i], swapped[i+1] = swapped[i+1], swapped[i]
+			require.False(t, IsValidAccountNumber(string(swapped)), "swap at %d in %s", i, number)
+		}
+	}
+
+	require.False(t, IsValidAccountNumber(""))
+	require.False(t, IsValidAccountNumber("GB82WEST12345698765432"))
+	require.False(t, IsValidAccountNumber("XT"+number[2:]))
+	require.False(t, IsValidAccountNumber(number[:19]+"A"))
+
+	// the same arithmetic as a real IBAN
+	require.Equal(t, 1, mod97("WEST12345698765432GB82"))
+}
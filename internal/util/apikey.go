@@ -0,0 +1,42 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	apiKeyTag        = "tly"
+	apiKeyPrefixSize = 16 // 64 bits, so prefixes don't collide as keys pile up
+	apiKeySecretSize = 32
+
+	// keys issued before the prefix grew keep working
+	legacyAPIKeyPrefixSize = 8
+)
+
+// GenerateAPIKey returns a new key in the form tly_<prefix>_<secret>. The
+// prefix is stored in clear to look the key up, only the secret's hash is kept.
+func GenerateAPIKey() (key, prefix, secret string, err error) {
+	prefix, err = RandomHex(apiKeyPrefixSize / 2)
+	if err != nil {
+		return "", "", "", err
+	}
+	secret, err = RandomHex(apiKeySecretSize / 2)
+	if err != nil {
+		return "", "", "", err
+	}
+	key = fmt.Sprintf("%s_%s_%s", apiKeyTag, prefix, secret)
+	return key, prefix, secret, nil
+}
+
+// SplitAPIKey breaks a key produced by GenerateAPIKey back into its prefix and secret.
+func SplitAPIKey(key string) (prefix, secret string, err error) {
+	parts := strings.Split(key, "_")
+	if len(parts) != 3 || parts[0] != apiKeyTag || len(parts[2]) != apiKeySecretSize {
+		return "", "", fmt.Errorf("invalid api key format")
+	}
+	if len(parts[1]) != apiKeyPrefixSize && len(parts[1]) != legacyAPIKeyPrefixSize {
+		return "", "", fmt.Errorf("invalid api key format")
+	}
+	return parts[1], parts[2], nil
+}
@@ -0,0 +1,36 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKey(t *testing.T) {
+	key, prefix, secret, err := GenerateAPIKey()
+	require.NoError(t, err)
+	require.Len(t, prefix, 16)
+
+	gotPrefix, gotSecret, err := SplitAPIKey(key)
+	require.NoError(t, err)
+	require.Equal(t, prefix, gotPrefix)
+	require.Equal(t, secret, gotSecret)
+
+	// keys from before the prefix grew to 16 characters
+	gotPrefix, gotSecret, err = SplitAPIKey("tly_0123abcd_" + secret)
+	require.NoError(t, err)
+	require.Equal(t, "0123abcd", gotPrefix)
+	require.Equal(t, secret, gotSecret)
+
+	for _, bad := range []string{
+		"",
+		"tly_0123abc_" + secret,
+		"xyz_" + prefix + "_" + secret,
+		"tly_" + prefix + "_" + secret[1:],
+		strings.ReplaceAll(key, "_", "-"),
+	} {
+		_, _, err := SplitAPIKey(bad)
+		require.Error(t, err, bad)
+	}
+}
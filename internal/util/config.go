@@ -7,11 +7,57 @@ import (
 )
 
 type Config struct {
-	DBDriver            string        `mapstructure:"DB_DRIVER"`
-	DBSource            string        `mapstructure:"DB_SOURCE"`
-	ServerAddress       string        `mapstructure:"SERVER_ADDRESS"`
-	TokenSymmetricKey   string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
-	AccessTokenDuration time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	DBDriver              string        `mapstructure:"DB_DRIVER"`
+	DBSource              string        `mapstructure:"DB_SOURCE"`
+	ServerAddress         string        `mapstructure:"SERVER_ADDRESS"`
+	TokenSymmetricKey     string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	AccessTokenDuration   time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	TrustedProxies        []string      `mapstructure:"TRUSTED_PROXIES"`         // proxies allowed to set X-Forwarded-For, none by default
+	MetricsAllowedIPs     []string      `mapstructure:"METRICS_ALLOWED_IPS"`     // IPs and CIDRs that may scrape /metrics, loopback by default
+	RateLimitBackend      string        `mapstructure:"RATE_LIMIT_BACKEND"`      // memory (default) or postgres
+	RateLimitPublic       string        `mapstructure:"RATE_LIMIT_PUBLIC"`       // e.g. 10/1m, per IP on unauthenticated routes
+	RateLimitAPI          string        `mapstructure:"RATE_LIMIT_API"`          // per user on authenticated routes
+	RateLimitTransfers    string        `mapstructure:"RATE_LIMIT_TRANSFERS"`    // per user on POST /transfers
+	RateLimitPayeeCheck   string        `mapstructure:"RATE_LIMIT_PAYEE_VERIFY"` // per user on POST /payees/verify
+	TracingExporter       string        `mapstructure:"TRACING_EXPORTER"`        // otlp, stdout or empty to disable
+	OTLPEndpoint          string        `mapstructure:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	LogFormat             string        `mapstructure:"LOG_FORMAT"` // json (default) or text
+	LogLevel              string        `mapstructure:"LOG_LEVEL"`  // debug, info (default), warn or error
+	HTTPReadTimeout       time.Duration `mapstructure:"HTTP_READ_TIMEOUT"`
+	HTTPWriteTimeout      time.Duration `mapstructure:"HTTP_WRITE_TIMEOUT"`
+	HTTPIdleTimeout       time.Duration `mapstructure:"HTTP_IDLE_TIMEOUT"`
+	ShutdownTimeout       time.Duration `mapstructure:"SHUTDOWN_TIMEOUT"`      // how long to wait for in-flight requests on SIGTERM
+	AutoMigrate           bool          `mapstructure:"AUTO_MIGRATE"`          // apply embedded migrations on start
+	TransferIsolation     string        `mapstructure:"DB_TRANSFER_ISOLATION"` // read committed, repeatable read or serializable
+	TxMaxAttempts         int           `mapstructure:"DB_TX_MAX_ATTEMPTS"`    // tries per transaction on serialization failures and deadlocks
+	DBMaxConns            int32         `mapstructure:"DB_MAX_CONNS"`
+	DBMinConns            int32         `mapstructure:"DB_MIN_CONNS"` // pgx only
+	DBMaxConnLifetime     time.Duration `mapstructure:"DB_MAX_CONN_LIFETIME"`
+	DBMaxConnIdleTime     time.Duration `mapstructure:"DB_MAX_CONN_IDLE_TIME"`
+	DBHealthCheckPeriod   time.Duration `mapstructure:"DB_HEALTH_CHECK_PERIOD"`      // pgx only
+	DBStatementCache      int           `mapstructure:"DB_STATEMENT_CACHE_CAPACITY"` // pgx only, 0 disables it
+	DBReplicaSources      []string      `mapstructure:"DB_REPLICA_SOURCES"`          // read replicas for history and listing queries, none by default
+	DBReplicaMaxLag       time.Duration `mapstructure:"DB_REPLICA_MAX_LAG"`          // replicas further behind are skipped
+	DBReplicaCheck        time.Duration `mapstructure:"DB_REPLICA_CHECK_PERIOD"`
+	MaxAccountsPerUser    int64         `mapstructure:"MAX_ACCOUNTS_PER_USER"`   // closed accounts count too, 0 means no limit
+	PayeeCoolingOff       time.Duration `mapstructure:"PAYEE_COOLING_OFF"`       // how long a new payee stays on the reduced limit
+	PayeeCoolingLimit     int64         `mapstructure:"PAYEE_COOLING_OFF_LIMIT"` // most sent to a payee still cooling off within PAYEE_COOLING_OFF
+	UnsavedPayeeLimit     int64         `mapstructure:"UNSAVED_PAYEE_LIMIT"`     // the same for accounts that aren't saved payees, 0 for no cap
+	TransferLimits        string        `mapstructure:"TRANSFER_LIMITS"`         // per tier and currency, see ParseTransferLimits
+	FraudRules            []string      `mapstructure:"FRAUD_RULES"`             // built-in rules to screen transfers with, empty disables screening
+	WatchlistPath         string        `mapstructure:"WATCHLIST_PATH"`          // sanctions list, CSV or OFAC SDN XML, empty disables screening
+	WatchlistReload       time.Duration `mapstructure:"WATCHLIST_RELOAD_PERIOD"` // how often to check the file for changes
+	ScreeningMatchScore   float64       `mapstructure:"SCREENING_MATCH_SCORE"`   // name similarity, 0 to 1, that counts as a hit
+	UnverifiedMaxAccounts int64         `mapstructure:"UNVERIFIED_MAX_ACCOUNTS"` // accounts a user may hold until their KYC is verified
+	BlobBackend           string        `mapstructure:"BLOB_BACKEND"`            // file or memory, where uploaded documents are kept
+	BlobDir               string        `mapstructure:"BLOB_DIR"`                // root of the file backend
+	KycMaxDocumentSize    int64         `mapstructure:"KYC_MAX_DOCUMENT_SIZE"`   // bytes
+	FeeSchedule           string        `mapstructure:"FEE_SCHEDULE"`            // per currency with its fee account, see ParseFeeSchedule
+	InterestRates         string        `mapstructure:"INTEREST_RATES"`          // per account type and currency with the funding account, see ParseInterestRates
+	InterestRunPeriod     time.Duration `mapstructure:"INTEREST_RUN_PERIOD"`     // how often the accrual job checks for days and months to close
+	Currencies            []string      `mapstructure:"CURRENCIES"`              // ISO 4217 codes enabled unless an admin switches them off
+	CurrencyRefreshPeriod time.Duration `mapstructure:"CURRENCY_REFRESH_PERIOD"` // how often admin changes made on other servers are picked up
+	AuditSequencePeriod   time.Duration `mapstructure:"AUDIT_SEQUENCE_PERIOD"`   // how often queued audit events are chained
 }
 
 func LoadConfig(path string) (config Config, err error) {
@@ -24,6 +70,41 @@ func LoadConfig(path string) (config Config, err error) {
 	viper.SetConfigName("app")
 	viper.SetConfigType("env")
 
+	viper.SetDefault("TRUSTED_PROXIES", []string{})
+	viper.SetDefault("METRICS_ALLOWED_IPS", []string{"127.0.0.1", "::1"})
+	viper.SetDefault("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	viper.SetDefault("HTTP_READ_TIMEOUT", 15*time.Second)
+	viper.SetDefault("HTTP_WRITE_TIMEOUT", 30*time.Second)
+	viper.SetDefault("HTTP_IDLE_TIMEOUT", 60*time.Second)
+	viper.SetDefault("SHUTDOWN_TIMEOUT", 30*time.Second)
+	viper.SetDefault("AUTO_MIGRATE", false)
+	viper.SetDefault("DB_TX_MAX_ATTEMPTS", 3)
+	viper.SetDefault("DB_MAX_CONNS", 10)
+	viper.SetDefault("DB_MAX_CONN_LIFETIME", time.Hour)
+	viper.SetDefault("DB_MAX_CONN_IDLE_TIME", 30*time.Minute)
+	viper.SetDefault("DB_HEALTH_CHECK_PERIOD", time.Minute)
+	viper.SetDefault("DB_STATEMENT_CACHE_CAPACITY", 512)
+	viper.SetDefault("DB_REPLICA_SOURCES", []string{})
+	viper.SetDefault("DB_REPLICA_MAX_LAG", 5*time.Second)
+	viper.SetDefault("DB_REPLICA_CHECK_PERIOD", 2*time.Second)
+	viper.SetDefault("MAX_ACCOUNTS_PER_USER", 10)
+	viper.SetDefault("RATE_LIMIT_PAYEE_VERIFY", "20/1h")
+	viper.SetDefault("PAYEE_COOLING_OFF", 24*time.Hour)
+	viper.SetDefault("PAYEE_COOLING_OFF_LIMIT", 1000)
+	viper.SetDefault("UNSAVED_PAYEE_LIMIT", 0)
+	viper.SetDefault("FRAUD_RULES", []string{"velocity", "new_payee", "structuring", "dormant_account"})
+	viper.SetDefault("WATCHLIST_RELOAD_PERIOD", time.Minute)
+	viper.SetDefault("SCREENING_MATCH_SCORE", 0.9)
+	viper.SetDefault("TRANSFER_LIMITS", "standard/*:10000,50000,200000,20;premium/*:100000,500000,2000000,100;unverified/*:1000,2000,5000,5")
+	viper.SetDefault("UNVERIFIED_MAX_ACCOUNTS", 1)
+	viper.SetDefault("BLOB_BACKEND", "file")
+	viper.SetDefault("BLOB_DIR", "data/blobs")
+	viper.SetDefault("KYC_MAX_DOCUMENT_SIZE", 5<<20)
+	viper.SetDefault("INTEREST_RUN_PERIOD", "1h")
+	viper.SetDefault("CURRENCIES", DefaultCurrencies)
+	viper.SetDefault("CURRENCY_REFRESH_PERIOD", "1m")
+	viper.SetDefault("AUDIT_SEQUENCE_PERIOD", "1s")
+
 	viper.AutomaticEnv()
 
 	err = viper.ReadInConfig()
@@ -0,0 +1,24 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// AutomaticEnv only overrides keys viper already knows about, so every
+// setting needs a default or a line in app.env to be read from the
+// environment.
+func TestLoadConfigFromEnv(t *testing.T) {
+	t.Setenv("TRUSTED_PROXIES", "10.0.0.0/8")
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://collector:4318")
+	t.Setenv("AUTO_MIGRATE", "true")
+	t.Setenv("DB_REPLICA_SOURCES", "postgres://replica1/bank,postgres://replica2/bank")
+
+	config, err := LoadConfig("../..")
+	require.NoError(t, err)
+	require.Equal(t, []string{"10.0.0.0/8"}, config.TrustedProxies)
+	require.Equal(t, "http://collector:4318", config.OTLPEndpoint)
+	require.True(t, config.AutoMigrate)
+	require.Equal(t, []string{"postgres://replica1/bank", "postgres://replica2/bank"}, config.DBReplicaSources)
+}
@@ -0,0 +1,143 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FeeTier charges Flat plus Bps hundredths of a percent of the amount on
+// transfers of at least From.
+type FeeTier struct {
+	From int64 `json:"from"`
+	Flat int64 `json:"flat"`
+	Bps  int64 `json:"bps"`
+}
+
+// Fee works out the tier's fee on amount, rounding half a minor unit up.
+func (tier FeeTier) Fee(amount int64) int64 {
+	// Split the amount so amount*Bps can't overflow; Bps is at most 10000.
+	percent := amount/10000*tier.Bps + (amount%10000*tier.Bps+5000)/10000
+	return tier.Flat + percent
+}
+
+// CurrencyFees is how transfers in one currency are charged and the house
+// account their fees are credited to.
+type CurrencyFees struct {
+	Account int64     `json:"account_id"`
+	Tiers   []FeeTier `json:"tiers"` // ascending From; the highest tier the amount reaches applies
+}
+
+// FeeSchedule holds the CurrencyFees for each charged currency.
+type FeeSchedule map[string]CurrencyFees
+
+// ParseFeeSchedule reads fees written as
+// "<currency>@<account_id>:<from>=<fee>,<from>=<fee>..." separated by
+// semicolons, where each fee is a flat amount, a percentage or both joined by
+// +, e.g. "USD@1:0=25+0.5%,100000=0.25%;EUR@2:0=30". An empty string gives
+// nil, no fees at all.
+func ParseFeeSchedule(s string) (FeeSchedule, error) {
+	var schedule FeeSchedule
+	for _, rule := range strings.Split(s, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		key, tiers, ok := strings.Cut(rule, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid fee %q: expected <currency>@<account_id>:<tiers>", rule)
+		}
+		currency, account, ok := strings.Cut(key, "@")
+		if !ok || !IsISOCurrency(currency) {
+			return nil, fmt.Errorf("invalid fee %q: unknown currency or missing fee account", rule)
+		}
+		accountID, err := strconv.ParseInt(account, 10, 64)
+		if err != nil || accountID <= 0 {
+			return nil, fmt.Errorf("invalid fee %q: fee account must be an account id", rule)
+		}
+		if _, ok := schedule[currency]; ok {
+			return nil, fmt.Errorf("invalid fee %q: %s is listed twice", rule, currency)
+		}
+
+		fees := CurrencyFees{Account: accountID}
+		for _, field := range strings.Split(tiers, ",") {
+			tier, err := parseFeeTier(strings.TrimSpace(field))
+			if err != nil {
+				return nil, fmt.Errorf("invalid fee %q: %w", rule, err)
+			}
+			if n := len(fees.Tiers); n > 0 && tier.From <= fees.Tiers[n-1].From {
+				return nil, fmt.Errorf("invalid fee %q: tiers must be in ascending order", rule)
+			}
+			fees.Tiers = append(fees.Tiers, tier)
+		}
+		if schedule == nil {
+			schedule = FeeSchedule{}
+		}
+		schedule[currency] = fees
+	}
+	return schedule, nil
+}
+
+// parseFeeTier reads "<from>=<fee>".
+func parseFeeTier(s string) (FeeTier, error) {
+	from, fee, ok := strings.Cut(s, "=")
+	if !ok {
+		return FeeTier{}, fmt.Errorf("tier %q: expected <from>=<fee>", s)
+	}
+	var tier FeeTier
+	var err error
+	if tier.From, err = strconv.ParseInt(from, 10, 64); err != nil || tier.From < 0 {
+		return FeeTier{}, fmt.Errorf("tier %q: amounts must be non-negative integers", s)
+	}
+
+	for _, part := range strings.Split(fee, "+") {
+		if percent, ok := strings.CutSuffix(part, "%"); ok {
+			if tier.Bps != 0 {
+				return FeeTier{}, fmt.Errorf("tier %q: more than one percentage", s)
+			}
+			if tier.Bps, err = parseBps(percent); err != nil {
+				return FeeTier{}, fmt.Errorf("tier %q: %w", s, err)
+			}
+			continue
+		}
+		if tier.Flat != 0 {
+			return FeeTier{}, fmt.Errorf("tier %q: more than one flat fee", s)
+		}
+		if tier.Flat, err = strconv.ParseInt(part, 10, 64); err != nil || tier.Flat < 0 {
+			return FeeTier{}, fmt.Errorf("tier %q: amounts must be non-negative integers", s)
+		}
+	}
+	return tier, nil
+}
+
+// parseBps turns a percentage with at most two decimals, like "0.25", into
+// basis points.
+func parseBps(percent string) (int64, error) {
+	whole, frac, _ := strings.Cut(percent, ".")
+	if len(frac) > 2 {
+		return 0, fmt.Errorf("percentage %s%% has more than two decimals", percent)
+	}
+	frac += strings.Repeat("0", 2-len(frac))
+	bps, err := strconv.ParseInt(whole+frac, 10, 64)
+	if whole == "" || err != nil || bps < 0 || bps > 10000 {
+		return 0, fmt.Errorf("percentage %s%% must be between 0 and 100", percent)
+	}
+	return bps, nil
+}
+
+// Quote returns the fee on a transfer of amount in currency and the account
+// it is credited to. A currency with no schedule, or an amount below its
+// lowest tier, is free.
+func (schedule FeeSchedule) Quote(currency string, amount int64) (fee int64, accountID int64) {
+	fees, ok := schedule[currency]
+	if !ok {
+		return 0, 0
+	}
+	for i := len(fees.Tiers) - 1; i >= 0; i-- {
+		if amount >= fees.Tiers[i].From {
+			return fees.Tiers[i].Fee(amount), fees.Account
+		}
+	}
+	return 0, 0
+}
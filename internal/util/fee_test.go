@@ -0,0 +1,73 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFeeSchedule(t *testing.T) {
+	schedule, err := ParseFeeSchedule("USD@1:0=25+0.5%,100000=0.25%; EUR@2:1000=30")
+	require.NoError(t, err)
+	require.Equal(t, FeeSchedule{
+		USD: {Account: 1, Tiers: []FeeTier{{From: 0, Flat: 25, Bps: 50}, {From: 100000, Bps: 25}}},
+		EUR: {Account: 2, Tiers: []FeeTier{{From: 1000, Flat: 30}}},
+	}, schedule)
+
+	schedule, err = ParseFeeSchedule("")
+	require.NoError(t, err)
+	require.Nil(t, schedule)
+
+	for _, s := range []string{
+		"USD",
+		"USD:0=25",
+		"XYZ@1:0=25",
+		"USD@x:0=25",
+		"USD@0:0=25",
+		"USD@1:0",
+		"USD@1:0=",
+		"USD@1:-1=25",
+		"USD@1:0=-25",
+		"USD@1:0=25+30",
+		"USD@1:0=1%+2%",
+		"USD@1:0=0.125%",
+		"USD@1:0=101%",
+		"USD@1:0=%",
+		"USD@1:100=25,100=30",
+		"USD@1:0=25;USD@2:0=30",
+	} {
+		_, err := ParseFeeSchedule(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestFeeScheduleQuote(t *testing.T) {
+	schedule, err := ParseFeeSchedule("USD@1:0=25+0.5%,100000=0.25%;EUR@2:1000=30")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		currency string
+		amount   int64
+		fee      int64
+		account  int64
+	}{
+		{USD, 1000, 30, 1},                   // 25 + 5
+		{USD, 99, 25, 1},                     // 0.495 rounds to 0
+		{USD, 100, 26, 1},                    // 0.5 rounds up
+		{USD, 100000, 250, 1},                // the higher tier has no flat part
+		{USD, 1 << 62, 11529215046068470, 1}, // no overflow
+		{EUR, 999, 0, 0},
+		{EUR, 1000, 30, 2},
+		{INR, 1000, 0, 0},
+	}
+	for _, tc := range testCases {
+		fee, account := schedule.Quote(tc.currency, tc.amount)
+		require.Equal(t, tc.fee, fee, "%s %d", tc.currency, tc.amount)
+		require.Equal(t, tc.account, account, "%s %d", tc.currency, tc.amount)
+	}
+
+	var none FeeSchedule
+	fee, account := none.Quote(USD, 1000)
+	require.Zero(t, fee)
+	require.Zero(t, account)
+}
@@ -0,0 +1,116 @@
+package util
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MicrosPerUnit is how many micros, the unit interest accrues in, make one
+// minor unit.
+const MicrosPerUnit = 1_000_000
+
+// InterestRate is what one account product earns and the house account the
+// interest is paid from.
+type InterestRate struct {
+	Bps            int64 `json:"bps"` // annual, in hundredths of a percent
+	FundingAccount int64 `json:"funding_account_id"`
+}
+
+// InterestRates holds an InterestRate per "<account_type>/<currency>".
+type InterestRates map[string]InterestRate
+
+var accountTypePattern = regexp.MustCompile(`^[a-z_]+$`)
+
+// ParseInterestRates reads rates written as
+// "<account_type>/<currency>@<funding_account_id>:<annual_rate>%" separated
+// by semicolons, e.g. "savings/USD@3:2.5%;savings/EUR@4:1.75%". An empty
+// string gives nil, no account earns interest.
+func ParseInterestRates(s string) (InterestRates, error) {
+	var rates InterestRates
+	for _, rule := range strings.Split(s, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		key, rate, ok := strings.Cut(rule, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid interest rate %q: expected <account_type>/<currency>@<funding_account_id>:<rate>", rule)
+		}
+		key, account, ok := strings.Cut(key, "@")
+		if !ok {
+			return nil, fmt.Errorf("invalid interest rate %q: missing funding account", rule)
+		}
+		accountType, currency, ok := strings.Cut(key, "/")
+		if !ok || !accountTypePattern.MatchString(accountType) || !IsISOCurrency(currency) {
+			return nil, fmt.Errorf("invalid interest rate %q: unknown account type or currency", rule)
+		}
+		accountID, err := strconv.ParseInt(account, 10, 64)
+		if err != nil || accountID <= 0 {
+			return nil, fmt.Errorf("invalid interest rate %q: funding account must be an account id", rule)
+		}
+		if _, ok := rates[key]; ok {
+			return nil, fmt.Errorf("invalid interest rate %q: %s is listed twice", rule, key)
+		}
+		percent, ok := strings.CutSuffix(rate, "%")
+		if !ok {
+			return nil, fmt.Errorf("invalid interest rate %q: rate must be a percentage", rule)
+		}
+		bps, err := parseBps(percent)
+		if err != nil {
+			return nil, fmt.Errorf("invalid interest rate %q: %w", rule, err)
+		}
+
+		if rates == nil {
+			rates = InterestRates{}
+		}
+		rates[key] = InterestRate{Bps: bps, FundingAccount: accountID}
+	}
+	return rates, nil
+}
+
+// For returns the rate accounts of accountType earn in currency, if any.
+func (rates InterestRates) For(accountType string, currency string) (InterestRate, bool) {
+	rate, ok := rates[accountType+"/"+currency]
+	return rate, ok
+}
+
+// DaysInYear is 366 in leap years and 365 otherwise.
+func DaysInYear(year int) int64 {
+	if time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay() == 366 {
+		return 366
+	}
+	return 365
+}
+
+// DailyInterestMicros is a day's interest on balance at an annual rate of bps,
+// in micros and rounded down: balance * bps / 10000 / DaysInYear. Balances
+// at or below zero earn nothing.
+func DailyInterestMicros(balance int64, bps int64, day time.Time) (int64, error) {
+	if balance <= 0 || bps <= 0 {
+		return 0, nil
+	}
+	n := new(big.Int).Mul(big.NewInt(balance), big.NewInt(bps))
+	n.Mul(n, big.NewInt(MicrosPerUnit/10000))
+	n.Quo(n, big.NewInt(DaysInYear(day.Year())))
+	if !n.IsInt64() {
+		return 0, fmt.Errorf("interest on %d at %d bps is too large", balance, bps)
+	}
+	return n.Int64(), nil
+}
+
+// SplitMicros splits micros into whole minor units, rounded down, and the
+// micros left over.
+func SplitMicros(micros int64) (units int64, carry int64) {
+	return micros / MicrosPerUnit, micros % MicrosPerUnit
+}
+
+// MonthStart returns midnight UTC on the first of the month that includes at.
+func MonthStart(at time.Time) time.Time {
+	at = at.UTC()
+	return time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,95 @@
+package util
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInterestRates(t *testing.T) {
+	rates, err := ParseInterestRates("savings/USD@3:2.5%; savings/EUR@4:0.75%")
+	require.NoError(t, err)
+	require.Equal(t, InterestRates{
+		"savings/USD": {Bps: 250, FundingAccount: 3},
+		"savings/EUR": {Bps: 75, FundingAccount: 4},
+	}, rates)
+
+	rate, ok := rates.For("savings", USD)
+	require.True(t, ok)
+	require.Equal(t, InterestRate{Bps: 250, FundingAccount: 3}, rate)
+	_, ok = rates.For("checking", USD)
+	require.False(t, ok)
+
+	rates, err = ParseInterestRates("")
+	require.NoError(t, err)
+	require.Nil(t, rates)
+
+	for _, s := range []string{
+		"savings/USD:2.5%",
+		"savings/USD@3",
+		"savings/USD@3:2.5",
+		"savings/XYZ@3:2.5%",
+		"Savings/USD@3:2.5%",
+		"savings@3:2.5%",
+		"savings/USD@0:2.5%",
+		"savings/USD@3:2.125%",
+		"savings/USD@3:-1%",
+		"savings/USD@3:101%",
+		"savings/USD@3:1%;savings/USD@4:2%",
+	} {
+		_, err := ParseInterestRates(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestDailyInterestMicros(t *testing.T) {
+	day := time.Date(2023, time.June, 1, 0, 0, 0, 0, time.UTC)
+	leapDay := time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		balance int64
+		bps     int64
+		day     time.Time
+		micros  int64
+	}{
+		{100000, 250, day, 6849315},     // 1000.00 at 2.5%: 6.849315068... minor units
+		{100000, 250, leapDay, 6830601}, // 366 days
+		{1, 1, day, 0},                  // 0.000000274 rounds down
+		{36500, 100, day, 1000000},      // exactly one minor unit
+		{0, 250, day, 0},
+		{-5000, 250, day, 0},
+		{100000, 0, day, 0},
+	}
+	for _, tc := range testCases {
+		micros, err := DailyInterestMicros(tc.balance, tc.bps, tc.day)
+		require.NoError(t, err)
+		require.Equal(t, tc.micros, micros, "%d at %d bps", tc.balance, tc.bps)
+	}
+
+	_, err := DailyInterestMicros(math.MaxInt64, 10000, day)
+	require.Error(t, err)
+}
+
+func TestSplitMicros(t *testing.T) {
+	units, carry := SplitMicros(205479450)
+	require.Equal(t, int64(205), units)
+	require.Equal(t, int64(479450), carry)
+
+	units, carry = SplitMicros(999999)
+	require.Zero(t, units)
+	require.Equal(t, int64(999999), carry)
+}
+
+func TestDaysInYear(t *testing.T) {
+	require.Equal(t, int64(365), DaysInYear(2023))
+	require.Equal(t, int64(366), DaysInYear(2024))
+	require.Equal(t, int64(365), DaysInYear(1900))
+	require.Equal(t, int64(366), DaysInYear(2000))
+}
+
+func TestMonthStart(t *testing.T) {
+	at := time.Date(2024, time.March, 31, 23, 30, 0, 0, time.FixedZone("", -5*60*60))
+	require.Equal(t, time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), MonthStart(at))
+}
@@ -0,0 +1,116 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("currencies differ")
+	ErrMoneyOverflow    = errors.New("amount out of range")
+)
+
+// Money is an amount in the minor units of its currency, e.g. cents for USD.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ScaleCents converts an amount given in hundredths of a major unit, as
+// configured thresholds and limits are, to the minor units of currency: 5000
+// is 5000 cents in USD, 50 yen in JPY and 50000 fils in BHD. See MinorUnits
+// for currencies outside ISO 4217.
+func ScaleCents(cents int64, currency string) int64 {
+	minorUnits := MinorUnits(currency)
+	for ; minorUnits < 2; minorUnits++ {
+		cents /= 10
+	}
+	for ; minorUnits > 2; minorUnits-- {
+		cents *= 10
+	}
+	return cents
+}
+
+// Add returns m + other, failing rather than overflowing.
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	sum := m.Amount + other.Amount
+	if (other.Amount > 0 && sum < m.Amount) || (other.Amount < 0 && sum > m.Amount) {
+		return Money{}, fmt.Errorf("%w: %s + %s", ErrMoneyOverflow, m, other)
+	}
+	return Money{Amount: sum, Currency: m.Currency}, nil
+}
+
+// Sub returns m - other, failing rather than overflowing.
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	diff := m.Amount - other.Amount
+	if (other.Amount > 0 && diff > m.Amount) || (other.Amount < 0 && diff < m.Amount) {
+		return Money{}, fmt.Errorf("%w: %s - %s", ErrMoneyOverflow, m, other)
+	}
+	return Money{Amount: diff, Currency: m.Currency}, nil
+}
+
+// Decimal writes the amount in major units with as many decimals as the
+// currency has minor units, e.g. "12.34" for 1234 USD or "1234" for 1234 JPY.
+// See MinorUnits for currencies outside ISO 4217.
+func (m Money) Decimal() string {
+	abs := uint64(m.Amount)
+	if m.Amount < 0 {
+		abs = -abs // also right for math.MinInt64
+	}
+	digits := strconv.FormatUint(abs, 10)
+
+	if places := MinorUnits(m.Currency); places > 0 {
+		if len(digits) <= places {
+			digits = strings.Repeat("0", places-len(digits)+1) + digits
+		}
+		digits = digits[:len(digits)-places] + "." + digits[len(digits)-places:]
+	}
+	if m.Amount < 0 {
+		return "-" + digits
+	}
+	return digits
+}
+
+// String writes the amount with its currency code, e.g. "12.34 USD".
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+type moneyJSON struct {
+	Amount     string `json:"amount"`
+	MinorUnits int64  `json:"minor_units"`
+	Currency   string `json:"currency"`
+}
+
+// MarshalJSON writes the decimal amount alongside the minor units, e.g.
+// {"amount":"12.34","minor_units":1234,"currency":"USD"}.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.Decimal(), MinorUnits: m.Amount, Currency: m.Currency})
+}
+
+// UnmarshalJSON takes the minor units and checks the decimal amount agrees.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var v moneyJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	money := Money{Amount: v.MinorUnits, Currency: v.Currency}
+	if v.Amount != money.Decimal() {
+		return fmt.Errorf("amount %q does not match %d minor units of %s", v.Amount, v.MinorUnits, v.Currency)
+	}
+	*m = money
+	return nil
+}
@@ -0,0 +1,86 @@
+package util
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoneyDecimal(t *testing.T) {
+	testCases := []struct {
+		money Money
+		want  string
+	}{
+		{NewMoney(1234, USD), "12.34"},
+		{NewMoney(5, USD), "0.05"},
+		{NewMoney(-5, USD), "-0.05"},
+		{NewMoney(0, USD), "0.00"},
+		{NewMoney(1234, JPY), "1234"},
+		{NewMoney(1234, "BHD"), "1.234"},
+		{NewMoney(1234, "XYZ"), "12.34"},
+		{NewMoney(math.MinInt64, USD), "-92233720368547758.08"},
+		{NewMoney(math.MaxInt64, USD), "92233720368547758.07"},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.want, tc.money.Decimal(), tc.money.Amount)
+	}
+	require.Equal(t, "12.34 USD", NewMoney(1234, USD).String())
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	sum, err := NewMoney(1000, USD).Add(NewMoney(25, USD))
+	require.NoError(t, err)
+	require.Equal(t, NewMoney(1025, USD), sum)
+
+	diff, err := NewMoney(1000, USD).Sub(NewMoney(1025, USD))
+	require.NoError(t, err)
+	require.Equal(t, NewMoney(-25, USD), diff)
+
+	_, err = NewMoney(1000, USD).Add(NewMoney(25, EUR))
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+	_, err = NewMoney(1000, USD).Sub(NewMoney(25, EUR))
+	require.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = NewMoney(math.MaxInt64, USD).Add(NewMoney(1, USD))
+	require.ErrorIs(t, err, ErrMoneyOverflow)
+	_, err = NewMoney(math.MinInt64, USD).Add(NewMoney(-1, USD))
+	require.ErrorIs(t, err, ErrMoneyOverflow)
+	_, err = NewMoney(0, USD).Sub(NewMoney(math.MinInt64, USD))
+	require.ErrorIs(t, err, ErrMoneyOverflow)
+	_, err = NewMoney(math.MinInt64, USD).Sub(NewMoney(1, USD))
+	require.ErrorIs(t, err, ErrMoneyOverflow)
+}
+
+func TestScaleCents(t *testing.T) {
+	require.Equal(t, int64(5000), ScaleCents(5000, USD))
+	require.Equal(t, int64(50), ScaleCents(5000, JPY))
+	require.Equal(t, int64(50000), ScaleCents(5000, "BHD"))
+	require.Equal(t, int64(5000), ScaleCents(5000, "XYZ"))
+	require.Equal(t, int64(0), ScaleCents(99, JPY))
+}
+
+// Amounts in a code outside ISO 4217 mean the same to ScaleCents and Decimal.
+func TestMinorUnits(t *testing.T) {
+	require.Equal(t, 2, MinorUnits(USD))
+	require.Equal(t, 0, MinorUnits(JPY))
+	require.Equal(t, 3, MinorUnits("BHD"))
+	require.Equal(t, 2, MinorUnits("XYZ"))
+
+	require.Equal(t, "50.00", NewMoney(ScaleCents(5000, "XYZ"), "XYZ").Decimal())
+	require.Equal(t, "50", NewMoney(ScaleCents(5000, JPY), JPY).Decimal())
+	require.Equal(t, "50.000", NewMoney(ScaleCents(5000, "BHD"), "BHD").Decimal())
+}
+
+func TestMoneyJSON(t *testing.T) {
+	data, err := json.Marshal(NewMoney(1234, USD))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"amount":"12.34","minor_units":1234,"currency":"USD"}`, string(data))
+
+	var money Money
+	require.NoError(t, json.Unmarshal(data, &money))
+	require.Equal(t, NewMoney(1234, USD), money)
+
+	require.Error(t, json.Unmarshal([]byte(`{"amount":"1.00","minor_units":1234,"currency":"USD"}`), &money))
+}
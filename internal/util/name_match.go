@@ -0,0 +1,135 @@
+package util
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+type NameMatch string
+
+const (
+	NameMatched      NameMatch = "match"
+	NameCloseMatched NameMatch = "close_match"
+	NameNotMatched   NameMatch = "no_match"
+)
+
+// closeMatchSimilarity is how alike two names must be, after normalizing, to
+// count as a close match: one typo in a short name, two in a long one.
+const closeMatchSimilarity = 0.8
+
+var honorifics = []string{"mr", "mrs", "ms", "miss", "mx", "dr", "prof", "sir"}
+
+// MatchName compares the name someone expects an account to be held under
+// with the holder's actual name. Case, punctuation, spacing and honorifics
+// never matter. A close match is the same words in a different order,
+// initials for some of the given names, or a small typo.
+func MatchName(expected, actual string) NameMatch {
+	want, got := nameTokens(expected), nameTokens(actual)
+	if len(want) == 0 || len(got) == 0 {
+		return NameNotMatched
+	}
+	if slices.Equal(want, got) {
+		return NameMatched
+	}
+
+	if matchesInitials(want, got) || sameWords(want, got) ||
+		similarity(strings.Join(want, " "), strings.Join(got, " ")) >= closeMatchSimilarity {
+		return NameCloseMatched
+	}
+	return NameNotMatched
+}
+
+// NameSimilarity scores how alike two names are, from 0 to 1, for screening
+// against watchlists. Besides what MatchName ignores it forgives word order
+// and words only one name has, such as a middle name, as long as at least two
+// words line up.
+func NameSimilarity(a, b string) float64 {
+	x, y := nameTokens(a), nameTokens(b)
+	if len(x) == 0 || len(y) == 0 {
+		return 0
+	}
+
+	score := similarity(strings.Join(x, " "), strings.Join(y, " "))
+	if sorted := similarity(strings.Join(slices.Sorted(slices.Values(x)), " "), strings.Join(slices.Sorted(slices.Values(y)), " ")); sorted > score {
+		score = sorted
+	}
+
+	if len(x) > len(y) {
+		x, y = y, x
+	}
+	if len(x) >= 2 {
+		var total float64
+		for _, word := range x {
+			var best float64
+			for _, other := range y {
+				best = max(best, similarity(word, other))
+			}
+			total += best
+		}
+		score = max(score, total/float64(len(x)))
+	}
+	return score
+}
+
+func nameTokens(name string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return slices.DeleteFunc(fields, func(field string) bool {
+		return slices.Contains(honorifics, field)
+	})
+}
+
+// matchesInitials accepts "J Smith" or "John R Smith" for "John Robert
+// Smith": the same number of words and surname, every other word either equal
+// or its initial.
+func matchesInitials(want, got []string) bool {
+	if len(want) != len(got) || want[len(want)-1] != got[len(got)-1] {
+		return false
+	}
+	for i := range want[:len(want)-1] {
+		a, b := []rune(want[i]), []rune(got[i])
+		if want[i] != got[i] && !(len(a) == 1 && a[0] == b[0]) && !(len(b) == 1 && b[0] == a[0]) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameWords(want, got []string) bool {
+	want, got = slices.Clone(want), slices.Clone(got)
+	slices.Sort(want)
+	slices.Sort(got)
+	return slices.Equal(want, got)
+}
+
+// similarity is 1 minus the edit distance over the longer length.
+func similarity(a, b string) float64 {
+	ra, rb := []rune(a), []rune(b)
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 1
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,46 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchName(t *testing.T) {
+	testCases := []struct {
+		expected string
+		actual   string
+		want     NameMatch
+	}{
+		{"John Smith", "John Smith", NameMatched},
+		{"  john   SMITH ", "John Smith", NameMatched},
+		{"Mr. John Smith", "John Smith", NameMatched},
+		{"Anne-Marie O'Neil", "anne marie o neil", NameMatched},
+		{"J Smith", "John Smith", NameCloseMatched},
+		{"John R. Smith", "John Robert Smith", NameCloseMatched},
+		{"Smith John", "John Smith", NameCloseMatched},
+		{"Jon Smith", "John Smith", NameCloseMatched},
+		{"John Smyth", "John Smith", NameCloseMatched},
+		{"K Smith", "John Smith", NameNotMatched},
+		{"John Jones", "John Smith", NameNotMatched},
+		{"Jane Doe", "John Smith", NameNotMatched},
+		{"John", "John Smith", NameNotMatched},
+		{"", "John Smith", NameNotMatched},
+		{"Mr", "John Smith", NameNotMatched},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.want, MatchName(tc.expected, tc.actual), "%q vs %q", tc.expected, tc.actual)
+	}
+}
+
+func TestNameSimilarity(t *testing.T) {
+	require.Equal(t, 1.0, NameSimilarity("Vladimir PUTIN", "vladimir putin"))
+	require.Equal(t, 1.0, NameSimilarity("PUTIN, Vladimir", "Vladimir Putin"))
+	require.Equal(t, 1.0, NameSimilarity("Vladimir Putin", "Vladimir Vladimirovich PUTIN"))
+	require.Greater(t, NameSimilarity("Vladmir Puttin", "Vladimir Vladimirovich Putin"), 0.85)
+	require.Less(t, NameSimilarity("Jane Doe", "Vladimir Putin"), 0.5)
+	// one shared word isn't enough to ignore the rest
+	require.Less(t, NameSimilarity("Putin", "Vladimir Putin"), 0.5)
+	require.Zero(t, NameSimilarity("", "Vladimir Putin"))
+}
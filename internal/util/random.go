@@ -38,7 +38,7 @@ func RandomBalance()int64{
 
 //Generate a Random Currency
 func RandomCurrency()string{ 
-    Currencies := []string{"INR","CAD","EUR","USD","YEN"}
+    Currencies := []string{"INR","CAD","EUR","USD","JPY"}
 	N:= len(Currencies)
 
 	return Currencies[rand.Intn(N)]
@@ -0,0 +1,34 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+)
+
+// RandomHex returns n cryptographically random bytes encoded as hex.
+func RandomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// HashSecret hashes a machine-generated secret (API keys, OAuth client secrets
+// and codes). They are random and long enough that a plain SHA-256 is
+// sufficient, which keeps per-request checks cheap; use HashedPassword for
+// anything a human chose.
+func HashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func CheckSecret(secret, hashedSecret string) error {
+	if subtle.ConstantTimeCompare([]byte(HashSecret(secret)), []byte(hashedSecret)) != 1 {
+		return fmt.Errorf("secret does not match")
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package util
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	StandardTier = "standard"
+	PremiumTier  = "premium"
+
+	// UnverifiedTier is never a user's tier. Its limits apply on top of the
+	// user's own until their KYC is verified.
+	UnverifiedTier = "unverified"
+)
+
+func IsSupportedTier(tier string) bool {
+	switch tier {
+	case StandardTier, PremiumTier:
+		return true
+	}
+	return false
+}
+
+// TransferLimit caps what a user of one tier may send in one currency. A zero
+// field means no cap.
+type TransferLimit struct {
+	PerTransaction int64 `json:"per_transaction"`
+	Daily          int64 `json:"daily"`        // per UTC calendar day
+	Monthly        int64 `json:"monthly"`      // per UTC calendar month
+	HourlyCount    int64 `json:"hourly_count"` // transfers in any rolling hour
+}
+
+func (limit TransferLimit) IsZero() bool {
+	return limit == TransferLimit{}
+}
+
+// Stricter combines two limits, keeping the lower cap of each field.
+func (limit TransferLimit) Stricter(other TransferLimit) TransferLimit {
+	lower := func(a, b int64) int64 {
+		if a == 0 || (b != 0 && b < a) {
+			return b
+		}
+		return a
+	}
+	return TransferLimit{
+		PerTransaction: lower(limit.PerTransaction, other.PerTransaction),
+		Daily:          lower(limit.Daily, other.Daily),
+		Monthly:        lower(limit.Monthly, other.Monthly),
+		HourlyCount:    lower(limit.HourlyCount, other.HourlyCount),
+	}
+}
+
+// TransferLimits holds a TransferLimit per "<tier>/<currency>", where the
+// currency may be * to cover every currency the tier has no entry for.
+// Amounts are in the currency's minor units, or cents for *.
+type TransferLimits map[string]TransferLimit
+
+// ParseTransferLimits reads limits written as
+// "<tier>/<currency>:<per_transaction>,<daily>,<monthly>,<hourly_count>"
+// separated by semicolons, e.g. "standard/*:1000,5000,20000,10;premium/USD:0,50000,0,0".
+// An empty string gives nil, no limits at all.
+func ParseTransferLimits(s string) (TransferLimits, error) {
+	var limits TransferLimits
+	for _, rule := range strings.Split(s, ";") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		key, values, ok := strings.Cut(rule, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid transfer limit %q: expected <tier>/<currency>:<limits>", rule)
+		}
+		tier, currency, ok := strings.Cut(key, "/")
+		if !ok || !(IsSupportedTier(tier) || tier == UnverifiedTier) || (currency != "*" && !IsISOCurrency(currency)) {
+			return nil, fmt.Errorf("invalid transfer limit %q: unknown tier or currency", rule)
+		}
+		if _, ok := limits[key]; ok {
+			return nil, fmt.Errorf("invalid transfer limit %q: %s is listed twice", rule, key)
+		}
+
+		fields := strings.Split(values, ",")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("invalid transfer limit %q: expected per_transaction,daily,monthly,hourly_count", rule)
+		}
+		var n [4]int64
+		for i, field := range fields {
+			v, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64)
+			if err != nil || v < 0 {
+				return nil, fmt.Errorf("invalid transfer limit %q: limits must be non-negative integers", rule)
+			}
+			n[i] = v
+		}
+		if limits == nil {
+			limits = TransferLimits{}
+		}
+		limits[key] = TransferLimit{PerTransaction: n[0], Daily: n[1], Monthly: n[2], HourlyCount: n[3]}
+	}
+	return limits, nil
+}
+
+// For returns the limit on tier in currency, falling back to the tier's *
+// entry. Without either there is no limit. The * amounts are in cents and
+// scaled to currency, see ScaleCents, so the same entry suits USD and JPY.
+func (limits TransferLimits) For(tier string, currency string) TransferLimit {
+	if limit, ok := limits[tier+"/"+currency]; ok {
+		return limit
+	}
+	limit := limits[tier+"/*"]
+	scale := func(cents int64) int64 {
+		if cents == 0 {
+			return 0
+		}
+		return max(ScaleCents(cents, currency), 1) // still a cap, not none
+	}
+	return TransferLimit{
+		PerTransaction: scale(limit.PerTransaction),
+		Daily:          scale(limit.Daily),
+		Monthly:        scale(limit.Monthly),
+		HourlyCount:    limit.HourlyCount,
+	}
+}
+
+// LimitWindows returns when the hourly, daily and monthly windows that
+// include at began.
+func LimitWindows(at time.Time) (hour time.Time, day time.Time, month time.Time) {
+	at = at.UTC()
+	hour = at.Add(-time.Hour)
+	day = time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	month = time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return hour, day, month
+}
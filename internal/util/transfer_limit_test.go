@@ -0,0 +1,64 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTransferLimits(t *testing.T) {
+	limits, err := ParseTransferLimits("standard/*:1000,5000,20000,10; premium/USD:0,50000,0,0")
+	require.NoError(t, err)
+
+	require.Equal(t, TransferLimit{PerTransaction: 1000, Daily: 5000, Monthly: 20000, HourlyCount: 10}, limits.For(StandardTier, USD))
+	require.Equal(t, TransferLimit{PerTransaction: 1000, Daily: 5000, Monthly: 20000, HourlyCount: 10}, limits.For(StandardTier, EUR))
+	// * is scaled to the currency's minor units
+	require.Equal(t, TransferLimit{PerTransaction: 10, Daily: 50, Monthly: 200, HourlyCount: 10}, limits.For(StandardTier, JPY))
+	require.Equal(t, TransferLimit{PerTransaction: 10000, Daily: 50000, Monthly: 200000, HourlyCount: 10}, limits.For(StandardTier, "BHD"))
+	require.Equal(t, TransferLimit{Daily: 50000}, limits.For(PremiumTier, USD))
+	require.True(t, limits.For(PremiumTier, EUR).IsZero())
+
+	limits, err = ParseTransferLimits("unverified/*:100,200,500,3")
+	require.NoError(t, err)
+	require.Equal(t, TransferLimit{PerTransaction: 100, Daily: 200, Monthly: 500, HourlyCount: 3}, limits.For(UnverifiedTier, USD))
+	// a cap too small to scale is kept rather than lifted
+	require.Equal(t, TransferLimit{PerTransaction: 1, Daily: 2, Monthly: 5, HourlyCount: 3}, limits.For(UnverifiedTier, JPY))
+
+	limits, err = ParseTransferLimits("")
+	require.NoError(t, err)
+	require.Nil(t, limits)
+
+	for _, s := range []string{
+		"standard/*",
+		"standard:1,2,3,4",
+		"gold/*:1,2,3,4",
+		"standard/XYZ:1,2,3,4",
+		"standard/*:1,2,3",
+		"standard/*:1,2,3,-4",
+		"standard/*:1,2,3,x",
+		"standard/*:1,2,3,4;standard/*:5,6,7,8",
+	} {
+		_, err := ParseTransferLimits(s)
+		require.Error(t, err, s)
+	}
+}
+
+func TestTransferLimitStricter(t *testing.T) {
+	tier := TransferLimit{PerTransaction: 1000, Daily: 5000, Monthly: 0, HourlyCount: 10}
+	unverified := TransferLimit{PerTransaction: 100, Daily: 0, Monthly: 2000, HourlyCount: 20}
+
+	require.Equal(t, TransferLimit{PerTransaction: 100, Daily: 5000, Monthly: 2000, HourlyCount: 10}, tier.Stricter(unverified))
+	require.Equal(t, tier.Stricter(unverified), unverified.Stricter(tier))
+	require.Equal(t, tier, tier.Stricter(TransferLimit{}))
+	require.True(t, TransferLimit{}.Stricter(TransferLimit{}).IsZero())
+}
+
+func TestLimitWindows(t *testing.T) {
+	at := time.Date(2024, time.March, 1, 0, 30, 0, 0, time.UTC)
+
+	hour, day, month := LimitWindows(at)
+	require.Equal(t, time.Date(2024, time.February, 29, 23, 30, 0, 0, time.UTC), hour)
+	require.Equal(t, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), day)
+	require.Equal(t, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), month)
+}